@@ -0,0 +1,143 @@
+package acceptance_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/matryer/is"
+	"github.com/quii/todo-eisenhower/adapters/memory"
+	"github.com/quii/todo-eisenhower/adapters/ui"
+	"github.com/quii/todo-eisenhower/domain/todo"
+	"github.com/quii/todo-eisenhower/usecases"
+)
+
+// Story 029: Soft Delete to Trash
+
+func TestStory029_DeletingMovesTodoToTrash(t *testing.T) {
+	// Scenario: Deleting a todo moves it to the trash
+	is := is.New(t)
+
+	repository := memory.NewRepository()
+	err := repository.SaveAll([]todo.Todo{
+		todo.New("Plan offsite", todo.PriorityB),
+	})
+	is.NoErr(err)
+
+	m, err := usecases.LoadMatrix(repository)
+	is.NoErr(err)
+
+	model := ui.NewModelWithRepository(m, "test.txt", repository)
+	model = updateModel(model, tea.WindowSizeMsg{Width: 120, Height: 40})
+	model = updateModel(model, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'2'}})
+	model = updateModel(model, tea.KeyMsg{Type: tea.KeyBackspace})
+	model = updateModel(model, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'y'}})
+
+	is.Equal(len(model.GetMatrix().Schedule()), 0)
+	is.Equal(repository.String(), "")
+
+	today := time.Now().Format("2006-01-02")
+	is.Equal(repository.TrashString(), "(B) Plan offsite deleted:"+today+"\n")
+}
+
+func TestStory029_BrowseRecentlyDeleted(t *testing.T) {
+	// Scenario: Browse recently deleted todos
+	is := is.New(t)
+
+	repository := memory.NewRepository()
+	err := repository.SaveTrash([]todo.Todo{
+		todo.New("Older idea", todo.PriorityC).MarkDeleted(time.Now().AddDate(0, 0, -7)),
+		todo.New("Old idea", todo.PriorityA).MarkDeleted(time.Now().AddDate(0, 0, -1)),
+	})
+	is.NoErr(err)
+
+	m, err := usecases.LoadMatrix(repository)
+	is.NoErr(err)
+
+	model := ui.NewModelWithRepository(m, "test.txt", repository)
+	model = updateModel(model, tea.WindowSizeMsg{Width: 120, Height: 40})
+	model = updateModel(model, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'t'}})
+
+	view := stripANSI(model.View())
+	is.True(strings.Contains(view, "Recently Deleted"))
+	is.True(strings.Index(view, "Old idea") < strings.Index(view, "Older idea")) // most recent first
+	is.True(strings.Contains(view, "Do First"))
+	is.True(strings.Contains(view, "Delegate"))
+}
+
+func TestStory029_RestoreDeletedTodo(t *testing.T) {
+	// Scenario: Restore a deleted todo
+	is := is.New(t)
+
+	repository := memory.NewRepository()
+	err := repository.SaveAll([]todo.Todo{
+		todo.New("Plan offsite", todo.PriorityB),
+	})
+	is.NoErr(err)
+
+	m, err := usecases.LoadMatrix(repository)
+	is.NoErr(err)
+
+	model := ui.NewModelWithRepository(m, "test.txt", repository)
+	model = updateModel(model, tea.WindowSizeMsg{Width: 120, Height: 40})
+
+	// Delete it
+	model = updateModel(model, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'2'}})
+	model = updateModel(model, tea.KeyMsg{Type: tea.KeyBackspace})
+	model = updateModel(model, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'y'}})
+
+	// Open the trash from overview and restore it
+	model = updateModel(model, tea.KeyMsg{Type: tea.KeyEsc})
+	model = updateModel(model, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'t'}})
+	model = updateModel(model, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'r'}})
+
+	is.Equal(len(model.GetMatrix().Schedule()), 1)
+	is.Equal(model.GetMatrix().Schedule()[0].Description(), "Plan offsite")
+	is.Equal(repository.TrashString(), "")
+	is.Equal(repository.String(), "(B) Plan offsite\n")
+	is.True(strings.Contains(stripANSI(model.View()), "trash is empty"))
+}
+
+func TestStory029_PurgeOldItems(t *testing.T) {
+	// Scenario: Purge old items on startup
+	is := is.New(t)
+
+	repository := memory.NewRepository()
+	err := repository.SaveTrash([]todo.Todo{
+		todo.New("Ancient", todo.PriorityA).MarkDeleted(time.Now().AddDate(0, 0, -45)),
+		todo.New("Recent", todo.PriorityA).MarkDeleted(time.Now().AddDate(0, 0, -3)),
+	})
+	is.NoErr(err)
+
+	purged, err := usecases.PurgeTrash(repository, usecases.DefaultTrashRetentionDays, time.Now())
+	is.NoErr(err)
+	is.Equal(purged, 1)
+
+	trash, err := repository.LoadTrash()
+	is.NoErr(err)
+	is.Equal(len(trash), 1)
+	is.Equal(trash[0].Description(), "Recent")
+}
+
+func TestStory029_RestoreDisabledInReadOnlyMode(t *testing.T) {
+	// Scenario: Restore is disabled in read-only mode
+	is := is.New(t)
+
+	repository := memory.NewRepository()
+	err := repository.SaveTrash([]todo.Todo{
+		todo.New("Old idea", todo.PriorityA).MarkDeleted(time.Now()),
+	})
+	is.NoErr(err)
+
+	m, err := usecases.LoadMatrix(repository)
+	is.NoErr(err)
+
+	model := ui.NewModelWithRepository(m, "(stdin)", repository).SetReadOnly(true)
+	model = updateModel(model, tea.WindowSizeMsg{Width: 120, Height: 40})
+	model = updateModel(model, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'t'}})
+	model = updateModel(model, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'r'}})
+
+	is.Equal(len(model.GetMatrix().DoFirst()), 0)
+	is.True(strings.Contains(repository.TrashString(), "Old idea"))
+}
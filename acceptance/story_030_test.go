@@ -0,0 +1,144 @@
+package acceptance_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/matryer/is"
+	"github.com/quii/todo-eisenhower/adapters/memory"
+	"github.com/quii/todo-eisenhower/adapters/ui"
+	"github.com/quii/todo-eisenhower/domain/todo"
+	"github.com/quii/todo-eisenhower/usecases"
+)
+
+// Story 030: Browse and Unarchive from done.txt
+
+func newArchiveRepository(t *testing.T) *memory.Repository {
+	t.Helper()
+	repository := memory.NewRepository()
+
+	yesterday := time.Now().AddDate(0, 0, -1)
+	lastWeek := time.Now().AddDate(0, 0, -7)
+	err := repository.SaveArchive([]todo.Todo{
+		todo.NewCompletedWithTags("Write RFC", todo.PriorityA, &lastWeek, []string{"WebApp"}, nil),
+		todo.NewCompleted("Ship release", todo.PriorityB, &yesterday),
+	})
+	if err != nil {
+		t.Fatalf("failed to seed archive: %v", err)
+	}
+	return repository
+}
+
+func openArchiveView(t *testing.T, repository *memory.Repository) ui.Model {
+	t.Helper()
+	m, err := usecases.LoadMatrix(repository)
+	if err != nil {
+		t.Fatalf("failed to load matrix: %v", err)
+	}
+
+	model := ui.NewModelWithRepository(m, "test.txt", repository)
+	model = updateModel(model, tea.WindowSizeMsg{Width: 120, Height: 40})
+	return updateModel(model, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'A'}})
+}
+
+func searchArchive(model ui.Model, query string) ui.Model {
+	model = updateModel(model, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'/'}})
+	model = updateModel(model, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(query)})
+	return updateModel(model, tea.KeyMsg{Type: tea.KeyEnter})
+}
+
+func TestStory030_OpenArchiveFromOverview(t *testing.T) {
+	// Scenario: Open the archive from overview
+	is := is.New(t)
+
+	model := openArchiveView(t, newArchiveRepository(t))
+
+	view := stripANSI(model.View())
+	is.True(strings.Contains(view, "Archive"))
+	is.True(strings.Index(view, "Ship release") < strings.Index(view, "Write RFC")) // most recent first
+	is.True(strings.Contains(view, "Schedule"))
+	is.True(strings.Contains(view, "Do First"))
+}
+
+func TestStory030_SearchArchive(t *testing.T) {
+	// Scenario: Search the archive
+	is := is.New(t)
+
+	model := searchArchive(openArchiveView(t, newArchiveRepository(t)), "rfc")
+
+	view := stripANSI(model.View())
+	is.True(strings.Contains(view, "Write RFC"))
+	is.True(!strings.Contains(view, "Ship release"))
+}
+
+func TestStory030_FilterArchiveByTag(t *testing.T) {
+	// Scenario: Filter the archive by tag
+	is := is.New(t)
+
+	model := searchArchive(openArchiveView(t, newArchiveRepository(t)), "+WebApp")
+
+	view := stripANSI(model.View())
+	is.True(strings.Contains(view, "Write RFC"))
+	is.True(!strings.Contains(view, "Ship release"))
+}
+
+func TestStory030_ClearSearch(t *testing.T) {
+	// Scenario: Clear the search
+	is := is.New(t)
+
+	model := searchArchive(openArchiveView(t, newArchiveRepository(t)), "rfc")
+	model = updateModel(model, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'c'}})
+
+	view := stripANSI(model.View())
+	is.True(strings.Contains(view, "Write RFC"))
+	is.True(strings.Contains(view, "Ship release"))
+}
+
+func TestStory030_UnarchiveAsCompleted(t *testing.T) {
+	// Scenario: Unarchive a todo as completed
+	is := is.New(t)
+
+	repository := newArchiveRepository(t)
+	model := openArchiveView(t, repository)
+	model = updateModel(model, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'u'}})
+
+	schedule := model.GetMatrix().Schedule()
+	is.Equal(len(schedule), 1)
+	is.Equal(schedule[0].Description(), "Ship release")
+	is.True(schedule[0].IsCompleted())
+	is.True(!strings.Contains(repository.ArchiveString(), "Ship release"))
+	is.True(strings.Contains(repository.String(), "Ship release"))
+}
+
+func TestStory030_UnarchiveAsNotDone(t *testing.T) {
+	// Scenario: Unarchive a todo as not done
+	is := is.New(t)
+
+	repository := newArchiveRepository(t)
+	model := openArchiveView(t, repository)
+	model = updateModel(model, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'U'}})
+
+	schedule := model.GetMatrix().Schedule()
+	is.Equal(len(schedule), 1)
+	is.True(!schedule[0].IsCompleted())
+	is.Equal(repository.String(), "(B) Ship release\n")
+}
+
+func TestStory030_UnarchiveDisabledInReadOnlyMode(t *testing.T) {
+	// Scenario: Unarchive is disabled in read-only mode
+	is := is.New(t)
+
+	repository := newArchiveRepository(t)
+	m, err := usecases.LoadMatrix(repository)
+	is.NoErr(err)
+
+	model := ui.NewModelWithRepository(m, "(stdin)", repository).SetReadOnly(true)
+	model = updateModel(model, tea.WindowSizeMsg{Width: 120, Height: 40})
+	model = updateModel(model, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'A'}})
+	model = updateModel(model, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'u'}})
+
+	is.Equal(len(model.GetMatrix().AllTodos()), 0)
+	is.True(strings.Contains(repository.ArchiveString(), "Ship release"))
+}
@@ -0,0 +1,124 @@
+package acceptance_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/matryer/is"
+	"github.com/quii/todo-eisenhower/adapters/memory"
+	"github.com/quii/todo-eisenhower/adapters/ui"
+	"github.com/quii/todo-eisenhower/domain/todo"
+	"github.com/quii/todo-eisenhower/usecases"
+)
+
+// Story 032: Automatic Archive Policy
+
+func TestStory032_StartupToastDisappearsOnKeyPress(t *testing.T) {
+	// Scenario: Toast disappears on the next key press
+	is := is.New(t)
+
+	repository := memory.NewRepository()
+	err := repository.SaveAll([]todo.Todo{
+		todo.New("Ship release", todo.PriorityA).ToggleCompletion(time.Now().AddDate(0, 0, -30)),
+		todo.New("Write notes", todo.PriorityA).ToggleCompletion(time.Now().AddDate(0, 0, -1)),
+	})
+	is.NoErr(err)
+
+	m, err := usecases.LoadMatrix(repository)
+	is.NoErr(err)
+
+	policy := usecases.ArchivePolicy{OnStartup: true, OlderThanDays: 14}
+	m, archived, err := usecases.ApplyStartupArchivePolicy(repository, m, policy, time.Now())
+	is.NoErr(err)
+	is.Equal(archived, 1)
+
+	model := ui.NewModelWithRepository(m, "test.txt", repository).SetStatusMessage("Archived 1 completed todo")
+	model = updateModel(model, tea.WindowSizeMsg{Width: 120, Height: 40})
+
+	view := stripANSI(model.View())
+	is.True(strings.Contains(view, "Archived 1 completed todo"))
+	is.True(strings.Contains(view, "Write notes"))
+	is.True(!strings.Contains(view, "Ship release"))
+
+	model = updateModel(model, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'h'}})
+
+	view = stripANSI(model.View())
+	is.True(!strings.Contains(view, "Archived 1 completed todo"))
+}
+
+func TestStory032_ArchiveOnQuit(t *testing.T) {
+	// Scenario: Archive on quit
+	is := is.New(t)
+
+	repository := memory.NewRepository()
+	err := repository.SaveAll([]todo.Todo{
+		todo.New("Ship release", todo.PriorityA).ToggleCompletion(time.Now()),
+		todo.New("Plan roadmap", todo.PriorityB),
+	})
+	is.NoErr(err)
+
+	m, err := usecases.LoadMatrix(repository)
+	is.NoErr(err)
+
+	model := ui.NewModelWithRepository(m, "test.txt", repository).SetArchivePolicy(usecases.ArchivePolicy{OnQuit: true})
+	model = updateModel(model, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'q'}})
+
+	is.Equal(model.ArchivedOnQuit(), 1)
+	is.True(strings.Contains(repository.ArchiveString(), "Ship release"))
+	is.Equal(repository.String(), "(B) Plan roadmap\n")
+}
+
+func TestStory032_NoArchiveOnQuitInReadOnlyMode(t *testing.T) {
+	// Scenario: Disabled in read-only mode
+	is := is.New(t)
+
+	repository := memory.NewRepository()
+	err := repository.SaveAll([]todo.Todo{
+		todo.New("Ship release", todo.PriorityA).ToggleCompletion(time.Now()),
+	})
+	is.NoErr(err)
+
+	m, err := usecases.LoadMatrix(repository)
+	is.NoErr(err)
+
+	model := ui.NewModelWithRepository(m, "(stdin)", repository).
+		SetReadOnly(true).
+		SetArchivePolicy(usecases.ArchivePolicy{OnQuit: true})
+	model = updateModel(model, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'q'}})
+
+	is.Equal(model.ArchivedOnQuit(), 0)
+	is.Equal(repository.ArchiveString(), "")
+}
+
+// archiveFailingRepository can't write to the archive, like a full disk
+type archiveFailingRepository struct {
+	*memory.Repository
+}
+
+func (archiveFailingRepository) AppendToArchive(todo.Todo) error {
+	return errors.New("disk full")
+}
+
+func TestStory032_ArchiveOnQuitFails(t *testing.T) {
+	// Scenario: Archive on quit fails
+	is := is.New(t)
+
+	repository := archiveFailingRepository{memory.NewRepository()}
+	err := repository.SaveAll([]todo.Todo{
+		todo.New("Ship release", todo.PriorityA).ToggleCompletion(time.Now()),
+	})
+	is.NoErr(err)
+
+	m, err := usecases.LoadMatrix(repository)
+	is.NoErr(err)
+
+	model := ui.NewModelWithRepository(m, "test.txt", repository).SetArchivePolicy(usecases.ArchivePolicy{OnQuit: true})
+	model = updateModel(model, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'q'}})
+
+	is.Equal(model.ArchivedOnQuit(), 0)
+	is.True(model.QuitArchiveError() != nil)
+	is.True(strings.Contains(repository.String(), "Ship release")) // still in todo.txt
+}
@@ -0,0 +1,47 @@
+package acceptance_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/matryer/is"
+	"github.com/quii/todo-eisenhower/adapters/file"
+	"github.com/quii/todo-eisenhower/adapters/ui"
+	"github.com/quii/todo-eisenhower/domain/todo"
+	"github.com/quii/todo-eisenhower/usecases"
+)
+
+// Story 033: Append-only Mutation Journal
+
+func TestStory033_MovingATodoIsJournaled(t *testing.T) {
+	// Scenario: Moving a todo is journaled
+	is := is.New(t)
+
+	dir := t.TempDir()
+	todoPath := filepath.Join(dir, "todo.txt")
+	journal := file.NewJournal(file.JournalPath(todoPath))
+	repository := usecases.WithJournal(file.NewRepository(todoPath), journal, "alice")
+
+	err := repository.SaveAll([]todo.Todo{todo.New("Deploy API", todo.PriorityB)})
+	is.NoErr(err)
+
+	m, err := usecases.LoadMatrix(repository)
+	is.NoErr(err)
+
+	model := ui.NewModelWithRepository(m, todoPath, repository)
+	model = updateModel(model, tea.WindowSizeMsg{Width: 120, Height: 40})
+	model = updateModel(model, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'2'}})
+	model = updateModel(model, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'m'}})
+	_ = updateModel(model, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'1'}})
+
+	events, err := journal.Load()
+	is.NoErr(err)
+	is.Equal(len(events), 1)
+	is.Equal(events[0].Action, usecases.ActionMove)
+	is.Equal(events[0].Before, "(B) Deploy API")
+	is.Equal(events[0].FromQuadrant, "schedule")
+	is.Equal(events[0].ToQuadrant, "do-first")
+	is.Equal(events[0].User, "alice")
+	is.True(!events[0].Timestamp.IsZero())
+}
@@ -0,0 +1,100 @@
+package acceptance_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/matryer/is"
+	"github.com/quii/todo-eisenhower/adapters/file"
+	"github.com/quii/todo-eisenhower/adapters/ui"
+	"github.com/quii/todo-eisenhower/usecases"
+)
+
+// Story 034: Multi-file Workspaces
+
+// newWorkspaceModel opens work.txt and personal.txt as one workspace
+func newWorkspaceModel(t *testing.T) (ui.Model, string) {
+	t.Helper()
+	dir := t.TempDir()
+	for name, content := range map[string]string{
+		"work.txt":     "(A) Deploy API +ops\n",
+		"personal.txt": "(A) Book dentist\n(B) Renew passport\n",
+	} {
+		//nolint:gosec // G306: test file permissions intentionally match production (0o644)
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+			t.Fatalf("failed to write %s: %v", name, err)
+		}
+	}
+
+	repository, err := file.NewWorkspace([]string{
+		filepath.Join(dir, "work.txt"),
+		filepath.Join(dir, "personal.txt"),
+	})
+	if err != nil {
+		t.Fatalf("failed to open workspace: %v", err)
+	}
+
+	m, err := usecases.LoadMatrix(repository)
+	if err != nil {
+		t.Fatalf("failed to load workspace: %v", err)
+	}
+
+	model := ui.NewModelWithRepository(m, "work.txt, personal.txt", repository)
+	model = updateModel(model, tea.WindowSizeMsg{Width: 160, Height: 40})
+	return model, dir
+}
+
+func TestStory034_OneMatrixWithSourceColumn(t *testing.T) {
+	// Scenario: Several files shown as one matrix
+	is := is.New(t)
+	model, _ := newWorkspaceModel(t)
+
+	is.Equal(len(model.GetMatrix().DoFirst()), 2)
+
+	model = updateModel(model, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'1'}})
+	view := stripANSI(model.View())
+
+	is.True(strings.Contains(view, "Source"))
+	is.True(strings.Contains(view, "work"))
+	is.True(strings.Contains(view, "personal"))
+}
+
+func TestStory034_MovesAreWrittenBackToTheirFile(t *testing.T) {
+	// Scenario: Changes are written back to the right file
+	is := is.New(t)
+	model, dir := newWorkspaceModel(t)
+
+	// Move "Renew passport" from Schedule to Do First
+	model = updateModel(model, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'2'}})
+	model = updateModel(model, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'m'}})
+	_ = updateModel(model, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'1'}})
+
+	work, err := os.ReadFile(filepath.Join(dir, "work.txt"))
+	is.NoErr(err)
+	is.Equal(string(work), "(A) Deploy API +ops\n")
+
+	personal, err := os.ReadFile(filepath.Join(dir, "personal.txt"))
+	is.NoErr(err)
+	is.True(strings.Contains(string(personal), "(A) Renew passport"))
+	is.True(strings.Contains(string(personal), "(A) Book dentist"))
+}
+
+func TestStory034_FilterBySource(t *testing.T) {
+	// Scenario: Filter by source file
+	is := is.New(t)
+	model, _ := newWorkspaceModel(t)
+
+	model = updateModel(model, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'f'}})
+	for _, r := range "source:work" {
+		model = updateModel(model, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	}
+	model = updateModel(model, tea.KeyMsg{Type: tea.KeyEnter})
+	model = updateModel(model, tea.KeyMsg{Type: tea.KeyEnter})
+
+	view := stripANSI(model.View())
+	is.True(strings.Contains(view, "Deploy API"))
+	is.True(!strings.Contains(view, "Book dentist"))
+}
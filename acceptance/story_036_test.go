@@ -0,0 +1,106 @@
+package acceptance_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/matryer/is"
+	"github.com/quii/todo-eisenhower/adapters/encrypted"
+	"github.com/quii/todo-eisenhower/adapters/ui"
+	"github.com/quii/todo-eisenhower/usecases"
+)
+
+// Story 036: Encrypted Todo File
+
+func TestStory036_EnterPassphraseToUnlock(t *testing.T) {
+	// Scenario: Opening an encrypted file
+	is := is.New(t)
+
+	var model tea.Model = ui.NewPassphraseModel("todo.txt.enc")
+	is.True(strings.Contains(stripANSI(model.View()), "Unlock todo.txt.enc"))
+
+	for _, r := range "correct horse" {
+		model, _ = model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	}
+	is.True(!strings.Contains(stripANSI(model.View()), "correct horse")) // passphrase is masked
+
+	model, cmd := model.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	is.True(cmd != nil) // quits the prompt
+
+	passphrase, ok := model.(ui.PassphraseModel).Passphrase()
+	is.True(ok)
+	is.Equal(passphrase, "correct horse")
+}
+
+func TestStory036_CancellingThePrompt(t *testing.T) {
+	// Scenario: Cancelling the prompt
+	is := is.New(t)
+
+	var model tea.Model = ui.NewPassphraseModel("todo.txt.enc")
+	model, _ = model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'x'}})
+	model, _ = model.Update(tea.KeyMsg{Type: tea.KeyEsc})
+
+	_, ok := model.(ui.PassphraseModel).Passphrase()
+	is.True(!ok)
+}
+
+func TestStory036_ChangesStayEncrypted(t *testing.T) {
+	// Scenario: Changes stay encrypted
+	is := is.New(t)
+	path := filepath.Join(t.TempDir(), "todo.txt.enc")
+	repository := encrypted.NewRepository(path, "correct horse", encrypted.WithKeyIterations(encrypted.MinKeyIterations))
+
+	m, err := usecases.LoadMatrix(repository)
+	is.NoErr(err)
+
+	model := ui.NewModelWithRepository(m, path, repository)
+	model = updateModel(model, tea.WindowSizeMsg{Width: 160, Height: 40})
+	model = updateModel(model, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'1'}})
+	model = updateModel(model, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'a'}})
+	for _, r := range "Renew passport" {
+		model = updateModel(model, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	}
+	_ = updateModel(model, tea.KeyMsg{Type: tea.KeyEnter})
+
+	data, err := os.ReadFile(path) //nolint:gosec // G304: test reads its own temp file
+	is.NoErr(err)
+	is.True(!strings.Contains(string(data), "Renew passport"))
+
+	reloaded, err := usecases.LoadMatrix(encrypted.NewRepository(path, "correct horse"))
+	is.NoErr(err)
+	is.Equal(len(reloaded.DoFirst()), 1)
+	is.Equal(reloaded.DoFirst()[0].Description(), "Renew passport")
+
+	_, err = usecases.LoadMatrix(encrypted.NewRepository(path, "wrong"))
+	is.True(err != nil)
+}
+
+func TestStory036_NewFileAsksForThePassphraseTwice(t *testing.T) {
+	// Scenario: Creating an encrypted file
+	is := is.New(t)
+
+	var model tea.Model = ui.NewPassphraseModel("todo.txt.enc").SetNewFile()
+	typePassphrase := func(passphrase string) {
+		for _, r := range passphrase {
+			model, _ = model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+		}
+		model, _ = model.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	}
+
+	typePassphrase("correct horse")
+	is.True(strings.Contains(stripANSI(model.View()), "Repeat the passphrase for todo.txt.enc"))
+
+	typePassphrase("correct hose")
+	is.True(strings.Contains(stripANSI(model.View()), "didn't match"))
+	_, ok := model.(ui.PassphraseModel).Passphrase()
+	is.True(!ok)
+
+	typePassphrase("correct horse")
+	typePassphrase("correct horse")
+	passphrase, ok := model.(ui.PassphraseModel).Passphrase()
+	is.True(ok)
+	is.Equal(passphrase, "correct horse")
+}
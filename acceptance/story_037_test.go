@@ -0,0 +1,59 @@
+package acceptance_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/matryer/is"
+	"github.com/quii/todo-eisenhower/adapters/memory"
+	"github.com/quii/todo-eisenhower/adapters/ui"
+	"github.com/quii/todo-eisenhower/domain/todo"
+	"github.com/quii/todo-eisenhower/usecases"
+)
+
+// Story 037: WebDAV Repository
+
+// conflictingRepository rejects every save, like a WebDAV server whose file someone else has changed
+type conflictingRepository struct {
+	*memory.Repository
+}
+
+func (conflictingRepository) SaveAll([]todo.Todo) error {
+	return errors.New("todo.txt changed on the server since it was read; your version was saved to todo.txt.conflict")
+}
+
+func TestStory037_ConflictIsShownAndServerVersionReloaded(t *testing.T) {
+	// Scenario: Saving after someone else changed the file
+	is := is.New(t)
+
+	server := memory.NewRepository()
+	err := server.SaveAll([]todo.Todo{todo.New("Ship release", todo.PriorityA)})
+	is.NoErr(err)
+
+	m, err := usecases.LoadMatrix(server)
+	is.NoErr(err)
+
+	model := ui.NewModelWithRepository(m, "todo.txt", conflictingRepository{server})
+	model = updateModel(model, tea.WindowSizeMsg{Width: 120, Height: 40})
+
+	// Someone else adds a todo on the server
+	err = server.SaveAll([]todo.Todo{
+		todo.New("Ship release", todo.PriorityA),
+		todo.New("Fix prod", todo.PriorityA),
+	})
+	is.NoErr(err)
+
+	model = updateModel(model, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'1'}})
+	model = updateModel(model, tea.KeyMsg{Type: tea.KeySpace})
+
+	view := stripANSI(model.View())
+	is.True(strings.Contains(view, "Save failed"))
+	is.True(strings.Contains(view, "todo.txt.conflict"))
+	is.True(strings.Contains(view, "Fix prod"))
+
+	for _, item := range model.GetMatrix().DoFirst() {
+		is.True(!item.IsCompleted()) // the rejected change isn't shown as saved
+	}
+}
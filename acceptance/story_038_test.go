@@ -0,0 +1,118 @@
+package acceptance_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/matryer/is"
+	"github.com/quii/todo-eisenhower/adapters/memory"
+	"github.com/quii/todo-eisenhower/adapters/remote"
+	"github.com/quii/todo-eisenhower/adapters/ui"
+	"github.com/quii/todo-eisenhower/usecases"
+)
+
+// Story 038: Read-only Remote Todo Lists
+
+// publishedList serves a team todo list that can be republished during a test
+type publishedList struct {
+	mu      sync.Mutex
+	content string
+}
+
+func (p *publishedList) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	_, _ = w.Write([]byte(p.content))
+}
+
+func (p *publishedList) publish(content string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.content = content
+}
+
+// runRefresh waits for the model's next refresh and applies it
+func runRefresh(t *testing.T, model ui.Model) ui.Model {
+	t.Helper()
+	tick := model.Init()
+	if tick == nil {
+		t.Fatal("expected a refresh to be scheduled")
+	}
+	updated, fetch := model.Update(tick())
+	if fetch == nil {
+		t.Fatal("expected the refresh tick to fetch the source")
+	}
+	updated, _ = updated.Update(fetch())
+	return updated.(ui.Model)
+}
+
+func TestStory038_RemoteListIsReadOnlyAndRefreshes(t *testing.T) {
+	// Scenario: Viewing a team list published at a URL
+	// Scenario: The list is refreshed periodically
+	is := is.New(t)
+	list := &publishedList{content: "(A) Ship release +team\n"}
+	server := httptest.NewServer(list)
+	defer server.Close()
+
+	source, err := remote.NewSource(server.URL + "/team.txt")
+	is.NoErr(err)
+	todos, _, err := source.Fetch()
+	is.NoErr(err)
+
+	repository := memory.NewRepository()
+	is.NoErr(repository.SaveAll(todos))
+	m, err := usecases.LoadMatrix(repository)
+	is.NoErr(err)
+
+	model := ui.NewModel(m, server.URL+"/team.txt").
+		SetRepository(repository).
+		SetReadOnly(true).
+		SetRefresh(time.Millisecond, source.Fetch)
+	model = updateModel(model, tea.WindowSizeMsg{Width: 160, Height: 40})
+
+	view := stripANSI(model.View())
+	is.True(strings.Contains(view, "(read-only)"))
+	is.True(strings.Contains(view, "Ship release"))
+
+	list.publish("(A) Ship release +team\n(B) Plan offsite +team\n")
+	model = runRefresh(t, model)
+
+	view = stripANSI(model.View())
+	is.True(strings.Contains(view, "Plan offsite"))
+	is.Equal(len(model.GetMatrix().AllTodos()), 2)
+}
+
+func TestStory038_FailedRefreshKeepsTheLastVersion(t *testing.T) {
+	// Scenario: The server is unreachable during a refresh
+	is := is.New(t)
+	list := &publishedList{content: "(A) Ship release\n"}
+	server := httptest.NewServer(list)
+
+	source, err := remote.NewSource(server.URL + "/team.txt")
+	is.NoErr(err)
+	todos, _, err := source.Fetch()
+	is.NoErr(err)
+
+	repository := memory.NewRepository()
+	is.NoErr(repository.SaveAll(todos))
+	m, err := usecases.LoadMatrix(repository)
+	is.NoErr(err)
+
+	model := ui.NewModel(m, server.URL+"/team.txt").
+		SetRepository(repository).
+		SetReadOnly(true).
+		SetRefresh(time.Millisecond, source.Fetch)
+	model = updateModel(model, tea.WindowSizeMsg{Width: 160, Height: 40})
+
+	server.Close()
+	model = runRefresh(t, model)
+
+	view := stripANSI(model.View())
+	is.True(strings.Contains(view, "Ship release"))
+	is.True(strings.Contains(view, "Refresh failed"))
+}
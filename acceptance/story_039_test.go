@@ -0,0 +1,139 @@
+package acceptance_test
+
+import (
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/matryer/is"
+	"github.com/quii/todo-eisenhower/adapters/file"
+	"github.com/quii/todo-eisenhower/adapters/memory"
+	"github.com/quii/todo-eisenhower/adapters/remote"
+	"github.com/quii/todo-eisenhower/adapters/ui"
+	"github.com/quii/todo-eisenhower/domain/todotxt"
+	"github.com/quii/todo-eisenhower/usecases"
+)
+
+// Story 039: Save Piped Todos to a File
+
+// newStdinModel loads todos as if they were piped in, with save-as creating files in dir
+func newStdinModel(t *testing.T, input, dir string) ui.Model {
+	t.Helper()
+	todos, err := todotxt.Unmarshal(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("failed to parse input: %v", err)
+	}
+
+	repository := memory.NewRepository()
+	if err := repository.SaveAll(todos); err != nil {
+		t.Fatalf("failed to load todos: %v", err)
+	}
+	m, err := usecases.LoadMatrix(repository)
+	if err != nil {
+		t.Fatalf("failed to load matrix: %v", err)
+	}
+
+	saveAs := func(path string) (usecases.TodoRepository, string, error) {
+		path = filepath.Join(dir, path)
+		return file.NewRepository(path), path, nil
+	}
+
+	model := ui.NewModel(m, "(stdin)").SetRepository(repository).SetReadOnly(true).SetSaveAs(saveAs)
+	return updateModel(model, tea.WindowSizeMsg{Width: 160, Height: 40})
+}
+
+func TestStory039_SaveAsMakesTheSessionWritable(t *testing.T) {
+	// Scenario: Save piped todos from the app
+	is := is.New(t)
+	dir := t.TempDir()
+	model := newStdinModel(t, "(A) Fix prod +ops\n(B) Plan roadmap\n", dir)
+
+	is.True(strings.Contains(stripANSI(model.View()), "S to save as"))
+
+	model = updateModel(model, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'S'}})
+	is.True(strings.Contains(stripANSI(model.View()), "Save As"))
+	for _, r := range "merged.txt" {
+		model = updateModel(model, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	}
+	model = updateModel(model, tea.KeyMsg{Type: tea.KeyEnter})
+
+	path := filepath.Join(dir, "merged.txt")
+	view := stripANSI(model.View())
+	is.True(strings.Contains(view, "File: "+path))
+	is.True(!strings.Contains(view, "(read-only)"))
+
+	saved, err := os.ReadFile(path) //nolint:gosec // G304: test reads its own temp file
+	is.NoErr(err)
+	is.Equal(string(saved), "(A) Fix prod +ops\n(B) Plan roadmap\n")
+
+	// Scenario: Edits after saving are kept
+	model = updateModel(model, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'1'}})
+	model = updateModel(model, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'a'}})
+	for _, r := range "Call vendor" {
+		model = updateModel(model, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	}
+	_ = updateModel(model, tea.KeyMsg{Type: tea.KeyEnter})
+
+	saved, err = os.ReadFile(path) //nolint:gosec // G304: test reads its own temp file
+	is.NoErr(err)
+	is.True(strings.Contains(string(saved), "Call vendor"))
+}
+
+func TestStory039_SavingARemoteListStopsRefreshing(t *testing.T) {
+	is := is.New(t)
+	dir := t.TempDir()
+	list := &publishedList{content: "(A) Ship release +team\n(B) Plan offsite +team\n"}
+	server := httptest.NewServer(list)
+	defer server.Close()
+
+	source, err := remote.NewSource(server.URL + "/team.txt")
+	is.NoErr(err)
+	model := newStdinModel(t, "(A) Ship release +team\n", dir).SetRefresh(time.Millisecond, source.Fetch)
+
+	// A refresh is scheduled, and another is already on its way, when the list is saved
+	tick := model.Init()
+	is.True(tick != nil)
+	tickMsg := tick()
+	_, fetch := model.Update(tickMsg)
+	is.True(fetch != nil)
+	inFlight := fetch()
+
+	model = updateModel(model, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'S'}})
+	for _, r := range "team.txt" {
+		model = updateModel(model, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	}
+	model = updateModel(model, tea.KeyMsg{Type: tea.KeyEnter})
+
+	updated, cmd := model.Update(tickMsg)
+	is.True(cmd == nil) // nothing left to fetch
+	updated, cmd = updated.Update(inFlight)
+	is.True(cmd == nil) // dropped rather than written over the saved file
+	model = updated.(ui.Model)
+
+	is.Equal(len(model.GetMatrix().AllTodos()), 1)
+	saved, err := os.ReadFile(filepath.Join(dir, "team.txt")) //nolint:gosec // G304: test reads its own temp file
+	is.NoErr(err)
+	is.Equal(string(saved), "(A) Ship release +team\n")
+}
+
+func TestStory039_CancellingSaveAsStaysReadOnly(t *testing.T) {
+	// Scenario: Cancelling save as
+	is := is.New(t)
+	dir := t.TempDir()
+	model := newStdinModel(t, "(A) Fix prod\n", dir)
+
+	model = updateModel(model, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'S'}})
+	model = updateModel(model, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'x'}})
+	model = updateModel(model, tea.KeyMsg{Type: tea.KeyEsc})
+
+	view := stripANSI(model.View())
+	is.True(strings.Contains(view, "(read-only)"))
+
+	entries, err := os.ReadDir(dir)
+	is.NoErr(err)
+	is.Equal(len(entries), 0)
+}
@@ -0,0 +1,56 @@
+package acceptance_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/matryer/is"
+	"github.com/quii/todo-eisenhower/adapters/file"
+	"github.com/quii/todo-eisenhower/adapters/ui"
+	"github.com/quii/todo-eisenhower/usecases"
+)
+
+// Story 041: Project-local Todo Files
+
+func TestStory041_ProjectTodoFileIsFoundAndShownInHeader(t *testing.T) {
+	// Scenario: Running from a subdirectory of a project
+	is := is.New(t)
+	home := t.TempDir()
+	project := filepath.Join(home, "code", "app")
+	workDir := filepath.Join(project, "internal", "api")
+	is.NoErr(os.MkdirAll(workDir, 0o750))
+	//nolint:gosec // G306: test file permissions intentionally match production (0o644)
+	is.NoErr(os.WriteFile(filepath.Join(project, "todo.txt"), []byte("(A) Fix flaky test +app\n"), 0o644))
+
+	path, ok := file.FindProjectFile(workDir, file.DefaultProjectFile, home)
+	is.True(ok)
+	is.Equal(path, filepath.Join(project, "todo.txt"))
+
+	repository := file.NewRepository(path)
+	m, err := usecases.LoadMatrix(repository)
+	is.NoErr(err)
+
+	model := ui.NewModelWithRepository(m, path+" (project)", repository)
+	model = updateModel(model, tea.WindowSizeMsg{Width: 160, Height: 40})
+
+	view := stripANSI(model.View())
+	is.True(strings.Contains(view, "File: "+path+" (project)"))
+	is.True(strings.Contains(view, "Fix flaky test"))
+}
+
+func TestStory041_FallsBackWhenNoProjectFile(t *testing.T) {
+	// Scenario: Outside any project
+	is := is.New(t)
+	home := t.TempDir()
+	workDir := filepath.Join(home, "scratch")
+	is.NoErr(os.MkdirAll(workDir, 0o750))
+	//nolint:gosec // G306: test file permissions intentionally match production (0o644)
+	is.NoErr(os.WriteFile(filepath.Join(home, "todo.txt"), []byte(""), 0o644))
+
+	// ~/todo.txt is the fallback, not a project file
+	_, ok := file.FindProjectFile(workDir, file.DefaultProjectFile, home)
+	is.True(!ok)
+}
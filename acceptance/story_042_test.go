@@ -0,0 +1,88 @@
+package acceptance_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/matryer/is"
+	"github.com/quii/todo-eisenhower/adapters/config"
+	"github.com/quii/todo-eisenhower/adapters/memory"
+	"github.com/quii/todo-eisenhower/adapters/ui"
+	"github.com/quii/todo-eisenhower/domain/matrix"
+	"github.com/quii/todo-eisenhower/domain/todotxt"
+	"github.com/quii/todo-eisenhower/usecases"
+)
+
+// Story 042: Configuration File
+
+// configuredModel loads todos into a model using the settings from a config file's contents
+func configuredModel(t *testing.T, configFile, todoFile string) ui.Model {
+	t.Helper()
+	is := is.New(t)
+
+	cfg, err := config.Parse(strings.NewReader(configFile))
+	is.NoErr(err)
+
+	todos, err := todotxt.Unmarshal(strings.NewReader(todoFile))
+	is.NoErr(err)
+	repository := memory.NewRepository()
+	is.NoErr(repository.SaveAll(todos))
+	m, err := usecases.LoadMatrix(repository)
+	is.NoErr(err)
+
+	quadrants := make(map[matrix.QuadrantType]ui.QuadrantStyle)
+	for quadrant, style := range cfg.QuadrantStyles() {
+		quadrants[quadrant] = ui.QuadrantStyle{Title: style.Title, Color: lipgloss.Color(style.Color)}
+	}
+
+	model := ui.NewModelWithRepository(m, "todo.txt", repository).SetSettings(ui.Settings{
+		Quadrants:    quadrants,
+		CharLimit:    cfg.CharLimit,
+		WIPThreshold: cfg.WIPThreshold,
+		StalePolicy:  cfg.StalePolicy(),
+	})
+	return updateModel(model, tea.WindowSizeMsg{Width: 160, Height: 40})
+}
+
+func TestStory042_QuadrantTitlesComeFromConfig(t *testing.T) {
+	// Scenario: Renaming and recoloring a quadrant
+	is := is.New(t)
+	model := configuredModel(t, `{"quadrants": {"do-first": {"title": "Now", "color": "#FF0000"}}}`, "(A) Fix prod\n")
+
+	view := stripANSI(model.View())
+	is.True(strings.Contains(view, "Now"))
+	is.True(!strings.Contains(view, "Do First"))
+	is.True(strings.Contains(view, "Schedule")) // other quadrants keep their titles
+
+	model = updateModel(model, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'1'}})
+	model = updateModel(model, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'m'}})
+	is.True(strings.Contains(stripANSI(model.View()), "1. Now"))
+}
+
+func TestStory042_ThresholdsComeFromConfig(t *testing.T) {
+	// Scenario: Changing the thresholds
+	is := is.New(t)
+	created := time.Now().AddDate(0, 0, -7).Format("2006-01-02")
+	todoFile := "(B) " + created + " Write docs +app\n(B) Review PR +app\n(C) Book venue +app\n"
+
+	model := configuredModel(t, `{}`, todoFile)
+	view := stripANSI(model.View())
+	is.True(!strings.Contains(view, "!!! +app")) // 3 todos is fine by default
+	is.True(!strings.Contains(view, "! Write docs"))
+
+	model = configuredModel(t, `{"wip_threshold": 2, "stale_after_business_days": {"other": 1}}`, todoFile)
+	view = stripANSI(model.View())
+	is.True(strings.Contains(view, "!!! +app"))
+	is.True(strings.Contains(view, "! Write docs")) // a week old is stale after one business day
+}
+
+func TestStory042_InvalidConfigIsReported(t *testing.T) {
+	// Scenario: A mistake in the config
+	is := is.New(t)
+	_, err := config.Parse(strings.NewReader(`{"wip_treshold": 8}`))
+	is.True(err != nil)
+	is.Equal(err.Error(), `unknown setting "wip_treshold"`)
+}
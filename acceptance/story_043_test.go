@@ -0,0 +1,66 @@
+package acceptance_test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/matryer/is"
+	"github.com/quii/todo-eisenhower/adapters/cli"
+	"github.com/quii/todo-eisenhower/adapters/file"
+)
+
+// Story 043: Command Line Subcommands
+
+func TestStory043_ScriptingTheMatrix(t *testing.T) {
+	// Scenario: Adding a todo from a script
+	// Scenario: Listing a quadrant
+	// Scenario: Completing, moving and archiving by id
+	is := is.New(t)
+	todoPath := filepath.Join(t.TempDir(), "todo.txt")
+	repository := file.NewRepository(todoPath)
+
+	var stdout, stderr bytes.Buffer
+	app := cli.New(repository, cli.WithOutput(&stdout, &stderr))
+
+	is.Equal(app.Run([]string{"add", "(A) Fix prod +ops due:tomorrow"}), cli.ExitOK)
+	is.Equal(app.Run([]string{"add", "--quadrant", "schedule", "Plan roadmap +ops"}), cli.ExitOK)
+	is.Equal(app.Run([]string{"add", "(C) Book venue +offsite"}), cli.ExitOK)
+
+	content, err := os.ReadFile(todoPath)
+	is.NoErr(err)
+	is.True(strings.Contains(string(content), "Fix prod +ops due:"))
+	is.True(!strings.Contains(string(content), "due:tomorrow")) // shortcut expanded to a date
+
+	stdout.Reset()
+	is.Equal(app.Run([]string{"list", "--filter", "+ops", "--quadrant", "schedule"}), cli.ExitOK)
+	is.True(strings.HasPrefix(stdout.String(), "2 (B) "))
+	is.True(strings.Contains(stdout.String(), "Plan roadmap +ops"))
+
+	is.Equal(app.Run([]string{"done", "1"}), cli.ExitOK)
+	is.Equal(app.Run([]string{"move", "3", "do-first"}), cli.ExitOK)
+	is.Equal(app.Run([]string{"archive"}), cli.ExitOK)
+
+	stdout.Reset()
+	is.Equal(app.Run([]string{"list"}), cli.ExitOK)
+	is.True(!strings.Contains(stdout.String(), "Fix prod")) // archived
+	is.True(strings.HasPrefix(stdout.String(), "1 (A) "))   // Book venue is now Do First
+	is.True(strings.Contains(stdout.String(), "Book venue +offsite"))
+}
+
+func TestStory043_ExitCodes(t *testing.T) {
+	// Scenario: Mistakes are reported with exit codes
+	is := is.New(t)
+	repository := file.NewRepository(filepath.Join(t.TempDir(), "todo.txt"))
+
+	var stdout, stderr bytes.Buffer
+	app := cli.New(repository, cli.WithOutput(&stdout, &stderr))
+
+	is.Equal(app.Run([]string{"done", "42"}), cli.ExitFailure)
+	is.True(strings.Contains(stderr.String(), "no todo with id 42"))
+
+	is.Equal(app.Run([]string{"move", "1"}), cli.ExitUsage)
+	is.Equal(app.Run([]string{"list", "--colour"}), cli.ExitUsage)
+}
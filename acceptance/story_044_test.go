@@ -0,0 +1,69 @@
+package acceptance_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/matryer/is"
+	"github.com/quii/todo-eisenhower/adapters/cli"
+	"github.com/quii/todo-eisenhower/adapters/file"
+	"github.com/quii/todo-eisenhower/adapters/jsonexport"
+)
+
+// Story 044: JSON Export
+
+func TestStory044_ExportingTheMatrix(t *testing.T) {
+	// Scenario: Exporting the matrix
+	// Scenario: Flags for stale and overdue todos
+	// Scenario: Exporting the inventory
+	is := is.New(t)
+	todoPath := filepath.Join(t.TempDir(), "todo.txt")
+	is.NoErr(os.WriteFile(todoPath, []byte(
+		"(C) 2026-01-12 Book venue +offsite @phone due:2026-01-19\n"+
+			"(A) 2026-01-02 Fix prod +ops prioritised:2026-01-02\n"+
+			"x 2026-01-19 (B) 2026-01-10 Plan roadmap +ops\n"), 0o600))
+
+	var stdout, stderr bytes.Buffer
+	app := cli.New(file.NewRepository(todoPath),
+		cli.WithOutput(&stdout, &stderr),
+		cli.WithClock(func() time.Time { return time.Date(2026, 1, 20, 9, 0, 0, 0, time.UTC) }),
+	)
+	is.Equal(app.Run([]string{"export", "json"}), cli.ExitOK)
+
+	var doc jsonexport.Document
+	is.NoErr(json.Unmarshal(stdout.Bytes(), &doc))
+	is.Equal(doc.SchemaVersion, 1)
+	is.Equal(len(doc.Todos), 3)
+
+	fix, plan, venue := doc.Todos[0], doc.Todos[1], doc.Todos[2]
+	is.Equal(fix.ID, 1)
+	is.Equal(fix.Quadrant, "do-first")
+	is.True(fix.Stale) // prioritised 12 business days ago
+
+	is.Equal(plan.Quadrant, "schedule")
+	is.True(plan.Completed)
+	is.Equal(plan.CompletionDate, "2026-01-19")
+
+	is.Equal(venue.Quadrant, "delegate")
+	is.Equal(venue.Contexts, []string{"phone"})
+	is.True(venue.Overdue) // due yesterday
+
+	is.Equal(doc.Inventory.TotalActive, 2)
+	is.Equal(doc.Inventory.Quadrants["do-first"].Active, 1)
+	is.Equal(doc.Inventory.Throughput.CompletedLast7Days, 1)
+	is.Equal(len(doc.Inventory.Projects), 2) // +offsite and +ops
+}
+
+func TestStory044_UnknownFormat(t *testing.T) {
+	// Scenario: Unknown formats
+	is := is.New(t)
+	var stdout, stderr bytes.Buffer
+	app := cli.New(file.NewRepository(filepath.Join(t.TempDir(), "todo.txt")), cli.WithOutput(&stdout, &stderr))
+
+	is.Equal(app.Run([]string{"export", "yaml"}), cli.ExitUsage)
+	is.True(bytes.Contains(stderr.Bytes(), []byte("Usage: eisenhower export")))
+}
@@ -0,0 +1,94 @@
+package acceptance_test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/matryer/is"
+	"github.com/quii/todo-eisenhower/adapters/cli"
+	"github.com/quii/todo-eisenhower/adapters/file"
+	"github.com/quii/todo-eisenhower/adapters/report"
+	"github.com/quii/todo-eisenhower/domain/matrix"
+)
+
+// Story 045: Markdown and HTML Reports
+
+func reportApp(t *testing.T, lines string, opts ...cli.Option) (*cli.App, *bytes.Buffer) {
+	t.Helper()
+	todoPath := filepath.Join(t.TempDir(), "todo.txt")
+	if err := os.WriteFile(todoPath, []byte(lines), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	var stdout, stderr bytes.Buffer
+	opts = append([]cli.Option{
+		cli.WithOutput(&stdout, &stderr),
+		cli.WithClock(func() time.Time { return time.Date(2026, 1, 20, 9, 0, 0, 0, time.UTC) }),
+	}, opts...)
+	return cli.New(file.NewRepository(todoPath), opts...), &stdout
+}
+
+const reportTodos = "(A) 2026-01-02 Fix prod +ops prioritised:2026-01-02\n" +
+	"(B) 2026-01-15 Plan roadmap +ops due:2026-01-19\n" +
+	"x 2026-01-19 (C) 2026-01-14 Book venue +offsite @phone\n"
+
+func TestStory045_MarkdownReport(t *testing.T) {
+	// Scenario: A Markdown report
+	is := is.New(t)
+	app, stdout := reportApp(t, reportTodos)
+
+	is.Equal(app.Run([]string{"report"}), cli.ExitOK)
+	out := stdout.String()
+	is.True(strings.Contains(out, "| Do First | 1 | 18 |"))
+	is.True(strings.Contains(out, "In the last 7 days: 1 completed, 2 added."))
+	is.True(strings.Contains(out, "## Delegate\n\n- [x] Book venue +offsite @phone"))
+	is.True(strings.Contains(out, "## Overdue\n\n- Plan roadmap (due 2026-01-19, Schedule)"))
+	is.True(strings.Contains(out, "## Stale\n\n- Fix prod (Do First)"))
+	is.True(strings.Contains(out, "- Projects (+): ops (2)"))
+}
+
+func TestStory045_HTMLReport(t *testing.T) {
+	// Scenario: An HTML report
+	is := is.New(t)
+	app, stdout := reportApp(t, reportTodos, cli.WithReportOptions(
+		report.WithQuadrantStyles(map[matrix.QuadrantType]report.Style{
+			matrix.DoFirstQuadrant: {Title: "Fires", Color: "#FF0000"},
+		}),
+		report.WithWIPThreshold(1),
+	))
+
+	is.Equal(app.Run([]string{"report", "--format", "html"}), cli.ExitOK)
+	out := stdout.String()
+	is.True(strings.HasPrefix(out, "<!DOCTYPE html>"))
+	is.True(strings.Contains(out, "<style>"))
+	is.True(strings.Contains(out, `<section class="quadrant do-first" style="border-color: #FF0000">`))
+	is.True(strings.Contains(out, "<h2>Fires</h2>"))
+	is.True(strings.Contains(out, `<span class="tag high-wip">ops (2)</span>`))
+}
+
+func TestStory045_CustomTemplate(t *testing.T) {
+	// Scenario: A custom template
+	is := is.New(t)
+	app, stdout := reportApp(t, reportTodos)
+
+	is.Equal(app.Run([]string{"report", "--format", "html", "--print-template"}), cli.ExitOK)
+	tmpl := strings.Replace(stdout.String(), "<h1>Eisenhower Matrix Report</h1>", "<h1>Team Update</h1>", 1)
+	templatePath := filepath.Join(t.TempDir(), "team.tmpl")
+	is.NoErr(os.WriteFile(templatePath, []byte(tmpl), 0o600))
+
+	stdout.Reset()
+	is.Equal(app.Run([]string{"report", "--format", "html", "--template", templatePath}), cli.ExitOK)
+	is.True(strings.Contains(stdout.String(), "<h1>Team Update</h1>"))
+	is.True(strings.Contains(stdout.String(), "Plan roadmap"))
+}
+
+func TestStory045_UnknownFormat(t *testing.T) {
+	// Scenario: Unknown formats
+	is := is.New(t)
+	app, _ := reportApp(t, "")
+	is.Equal(app.Run([]string{"report", "--format", "pdf"}), cli.ExitUsage)
+}
@@ -0,0 +1,74 @@
+package acceptance_test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/matryer/is"
+	"github.com/quii/todo-eisenhower/adapters/cli"
+	"github.com/quii/todo-eisenhower/adapters/file"
+)
+
+// Story 046: iCalendar Export and Import
+
+func TestStory046_ExportingTodosAsCalendarTasks(t *testing.T) {
+	// Scenario: Exporting todos as calendar tasks
+	// Scenario: UIDs are stable
+	is := is.New(t)
+	todoPath := filepath.Join(t.TempDir(), "todo.txt")
+	is.NoErr(os.WriteFile(todoPath, []byte("(A) 2026-01-02 Fix prod +ops @laptop due:2026-01-21\n"), 0o600))
+
+	var stdout, stderr bytes.Buffer
+	app := cli.New(file.NewRepository(todoPath), cli.WithOutput(&stdout, &stderr),
+		cli.WithClock(func() time.Time { return time.Date(2026, 1, 20, 9, 0, 0, 0, time.UTC) }))
+
+	is.Equal(app.Run([]string{"export", "ics"}), cli.ExitOK)
+	exported := stdout.String()
+	is.True(strings.Contains(exported, "SUMMARY:Fix prod\r\nPRIORITY:1\r\nCATEGORIES:+ops,@laptop\r\nDUE;VALUE=DATE:20260121\r\n"))
+	uid := exported[strings.Index(exported, "UID:"):]
+	uid = uid[:strings.Index(uid, "\r\n")]
+
+	is.Equal(app.Run([]string{"move", "1", "schedule"}), cli.ExitOK)
+	is.Equal(app.Run([]string{"done", "1"}), cli.ExitOK)
+
+	stdout.Reset()
+	is.Equal(app.Run([]string{"export", "ics"}), cli.ExitOK)
+	is.True(strings.Contains(stdout.String(), uid+"\r\n"))
+	is.True(strings.Contains(stdout.String(), "PRIORITY:3\r\n"))
+	is.True(strings.Contains(stdout.String(), "STATUS:COMPLETED\r\n"))
+}
+
+func TestStory046_ImportingCalendarTasks(t *testing.T) {
+	// Scenario: Importing calendar tasks
+	// Scenario: Importing the same calendar again
+	is := is.New(t)
+	dir := t.TempDir()
+	todoPath := filepath.Join(dir, "todo.txt")
+	calendarPath := filepath.Join(dir, "calendar.ics")
+	is.NoErr(os.WriteFile(calendarPath, []byte("BEGIN:VCALENDAR\r\nVERSION:2.0\r\n"+
+		"BEGIN:VTODO\r\nUID:0a1b2c@phone\r\nSUMMARY:Renew passport\r\nPRIORITY:2\r\nDUE;VALUE=DATE:20260130\r\nEND:VTODO\r\n"+
+		"END:VCALENDAR\r\n"), 0o600))
+
+	var stdout, stderr bytes.Buffer
+	app := cli.New(file.NewRepository(todoPath), cli.WithOutput(&stdout, &stderr))
+
+	is.Equal(app.Run([]string{"import", "ics", calendarPath}), cli.ExitOK)
+	is.Equal(stdout.String(), "Imported 1 todo\n")
+
+	stdout.Reset()
+	is.Equal(app.Run([]string{"list", "--quadrant", "do-first"}), cli.ExitOK)
+	is.True(strings.HasPrefix(stdout.String(), "1 (A) Renew passport"))
+	is.True(strings.Contains(stdout.String(), "due:2026-01-30"))
+
+	stdout.Reset()
+	is.Equal(app.Run([]string{"import", "ics", calendarPath}), cli.ExitOK)
+	is.Equal(stdout.String(), "Nothing new to import\n")
+
+	content, err := os.ReadFile(todoPath)
+	is.NoErr(err)
+	is.Equal(strings.Count(string(content), "Renew passport"), 1)
+}
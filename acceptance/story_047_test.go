@@ -0,0 +1,78 @@
+package acceptance_test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/matryer/is"
+	"github.com/quii/todo-eisenhower/adapters/cli"
+	"github.com/quii/todo-eisenhower/adapters/file"
+)
+
+// Story 047: CSV Import and Export
+
+func TestStory047_ImportingASpreadsheet(t *testing.T) {
+	// Scenario: Importing a spreadsheet
+	// Scenario: Importing the same spreadsheet again
+	// Scenario: Choosing and naming the exported columns
+	is := is.New(t)
+	dir := t.TempDir()
+	todoPath := filepath.Join(dir, "todo.txt")
+	sheetPath := filepath.Join(dir, "sheet.csv")
+	is.NoErr(os.WriteFile(sheetPath, []byte("Title,Owner,Urgent,Important\n"+
+		"Fix prod,Sam,yes,yes\n"+
+		"Plan roadmap,Sam,no,yes\n"+
+		"Answer vendor,Ana,yes,no\n"+
+		"Tidy wiki,Ana,no,no\n"), 0o600))
+
+	var stdout, stderr bytes.Buffer
+	app := cli.New(file.NewRepository(todoPath), cli.WithOutput(&stdout, &stderr),
+		cli.WithClock(func() time.Time { return time.Date(2026, 1, 20, 9, 0, 0, 0, time.UTC) }))
+	mapping := "Title=description,Urgent=urgent,Important=important"
+
+	is.Equal(app.Run([]string{"import", "csv", "--map", mapping, sheetPath}), cli.ExitOK)
+	is.Equal(stdout.String(), "Imported 4 todos\n")
+
+	content, err := os.ReadFile(todoPath)
+	is.NoErr(err)
+	is.Equal(string(content), "(A) 2026-01-20 Fix prod prioritised:2026-01-20\n"+
+		"(B) 2026-01-20 Plan roadmap\n"+
+		"(C) 2026-01-20 Answer vendor\n"+
+		"(D) 2026-01-20 Tidy wiki\n")
+
+	stdout.Reset()
+	is.Equal(app.Run([]string{"import", "csv", "--map", mapping, sheetPath}), cli.ExitOK)
+	is.Equal(stdout.String(), "Nothing new to import\n")
+
+	stdout.Reset()
+	is.Equal(app.Run([]string{"export", "csv", "--map", "Title=description,Urgent=urgent,Important=important"}), cli.ExitOK)
+	is.Equal(stdout.String(), "Title,Urgent,Important\n"+
+		"Fix prod,yes,yes\n"+
+		"Plan roadmap,no,yes\n"+
+		"Answer vendor,yes,no\n"+
+		"Tidy wiki,no,no\n")
+}
+
+func TestStory047_ExportingToCSV(t *testing.T) {
+	// Scenario: Exporting to CSV
+	// Scenario: Mistakes in the mapping
+	is := is.New(t)
+	todoPath := filepath.Join(t.TempDir(), "todo.txt")
+	is.NoErr(os.WriteFile(todoPath, []byte("(B) 2026-01-02 Plan roadmap +ops @office due:2026-02-01\n"), 0o600))
+
+	var stdout, stderr bytes.Buffer
+	app := cli.New(file.NewRepository(todoPath), cli.WithOutput(&stdout, &stderr))
+
+	is.Equal(app.Run([]string{"export", "csv"}), cli.ExitOK)
+	is.Equal(stdout.String(), "description,quadrant,priority,urgent,important,due,projects,contexts,completed,created,completed_on\n"+
+		"Plan roadmap,schedule,B,no,yes,2026-02-01,ops,office,no,2026-01-02,\n")
+
+	exported := filepath.Join(t.TempDir(), "todos.csv")
+	is.NoErr(os.WriteFile(exported, stdout.Bytes(), 0o600))
+	is.Equal(app.Run([]string{"import", "csv", "--map", "Task=description", exported}), cli.ExitFailure)
+	is.True(strings.Contains(stderr.String(), `no "Task" column in the header`))
+}
@@ -0,0 +1,87 @@
+package acceptance_test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/matryer/is"
+	"github.com/quii/todo-eisenhower/adapters/cli"
+	"github.com/quii/todo-eisenhower/adapters/file"
+)
+
+// Story 048: Import from Taskwarrior and Todoist
+
+func importApp(t *testing.T, name, export string) (app *cli.App, stdout *bytes.Buffer, dir, exportPath string) {
+	t.Helper()
+	dir = t.TempDir()
+	exportPath = filepath.Join(dir, name)
+	if err := os.WriteFile(exportPath, []byte(export), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	stdout = &bytes.Buffer{}
+	app = cli.New(file.NewRepository(filepath.Join(dir, "todo.txt")), cli.WithOutput(stdout, &bytes.Buffer{}),
+		cli.WithClock(func() time.Time { return time.Date(2026, 1, 20, 9, 0, 0, 0, time.UTC) }))
+	return app, stdout, dir, exportPath
+}
+
+func TestStory048_ImportingATaskwarriorExport(t *testing.T) {
+	// Scenario: Importing a Taskwarrior export
+	// Scenario: Completed history goes to done.txt
+	// Scenario: Importing the same export again
+	is := is.New(t)
+	app, stdout, dir, exportPath := importApp(t, "tasks.json", `[
+		{"uuid":"5f1c2a9e-0d3b-4c55-9a7e-1b2c3d4e5f60","description":"Fix prod","entry":"20260102T090000Z","due":"20260121T000000Z","priority":"H","project":"ops","status":"pending","tags":["laptop"],"urgency":14.2},
+		{"uuid":"a3b4c5d6-e7f8-4a9b-8c7d-6e5f4a3b2c1d","description":"Plan roadmap","entry":"20260110T090000Z","priority":"H","project":"product","status":"pending","urgency":6.5},
+		{"description":"Reply to vendor","entry":"20260115T090000Z","status":"pending","urgency":9.1},
+		{"description":"Tidy wiki","entry":"20260115T090000Z","status":"waiting","urgency":1.2},
+		{"description":"Book venue","entry":"20260105T090000Z","end":"20260112T160000Z","project":"offsite","status":"completed"},
+		{"description":"Old idea","entry":"20260105T090000Z","status":"deleted"}
+	]`)
+
+	is.Equal(app.Run([]string{"import", "taskwarrior", exportPath}), cli.ExitOK)
+	is.Equal(stdout.String(), "Imported 5 todos\n")
+
+	content, err := os.ReadFile(filepath.Join(dir, "todo.txt"))
+	is.NoErr(err)
+	is.Equal(string(content), "(A) 2026-01-02 Fix prod tw:5f1c2a9e-0d3b-4c55-9a7e-1b2c3d4e5f60 +ops @laptop due:2026-01-21 prioritised:2026-01-20\n"+
+		"(B) 2026-01-10 Plan roadmap tw:a3b4c5d6-e7f8-4a9b-8c7d-6e5f4a3b2c1d +product\n"+
+		"(C) 2026-01-15 Reply to vendor\n"+
+		"(D) 2026-01-15 Tidy wiki\n")
+
+	done, err := os.ReadFile(filepath.Join(dir, "done.txt"))
+	is.NoErr(err)
+	is.Equal(string(done), "x 2026-01-12 2026-01-05 (D) Book venue +offsite\n")
+
+	// Tasks are recognised by their Taskwarrior uuid, even after being edited here
+	edited := strings.Replace(string(content), "Plan roadmap", "Plan Q3 roadmap", 1)
+	is.NoErr(os.WriteFile(filepath.Join(dir, "todo.txt"), []byte(edited), 0o600))
+
+	stdout.Reset()
+	is.Equal(app.Run([]string{"import", "taskwarrior", exportPath}), cli.ExitOK)
+	is.Equal(stdout.String(), "Nothing new to import\n")
+}
+
+func TestStory048_ImportingATodoistExport(t *testing.T) {
+	// Scenario: Importing a Todoist export
+	// Scenario: Choosing how tasks map to quadrants
+	is := is.New(t)
+	app, _, dir, exportPath := importApp(t, "todoist.json", `{
+		"projects": [{"id": "2203", "name": "Home Admin"}],
+		"items": [
+			{"id": "6Jf8VQXxpwv56VQ7", "content": "Renew passport", "project_id": "2203", "priority": 4, "due": {"date": "2026-01-24"}, "added_at": "2026-01-02T09:00:00Z"},
+			{"id": 2995104339, "content": "Plan trip", "project_id": "2203", "priority": 2, "labels": ["family"], "added_at": "2026-01-10T09:00:00Z"}
+		]
+	}`)
+
+	is.Equal(app.Run([]string{"import", "todoist", "--important", "p1,p3", "--urgent-within", "5", exportPath}), cli.ExitOK)
+
+	content, err := os.ReadFile(filepath.Join(dir, "todo.txt"))
+	is.NoErr(err)
+	is.Equal(string(content), "(A) 2026-01-02 Renew passport todoist:6Jf8VQXxpwv56VQ7 +Home_Admin due:2026-01-24 prioritised:2026-01-20\n"+
+		"(B) 2026-01-10 Plan trip todoist:2995104339 +Home_Admin @family\n")
+}
@@ -0,0 +1,81 @@
+package acceptance_test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/matryer/is"
+	"github.com/quii/todo-eisenhower/adapters/cli"
+	"github.com/quii/todo-eisenhower/adapters/file"
+)
+
+// Story 049: Todos from Code Comments
+
+func TestStory049_ScanningARepository(t *testing.T) {
+	// Scenario: Scanning a repository
+	// Scenario: Scanning again
+	// Scenario: Comments removed from the code
+	//nolint:gocritic // importShadow: is := is.New(t) is idiomatic for github.com/matryer/is
+	is := is.New(t)
+	dir := t.TempDir()
+	t.Chdir(dir)
+	is.NoErr(os.MkdirAll(filepath.Join("repo", "scripts"), 0o755))
+	is.NoErr(os.WriteFile(filepath.Join("repo", "main.go"), []byte("package main\n\n// FIXME: nil map panic\nfunc main() {}\n"), 0o644))
+	is.NoErr(os.WriteFile(filepath.Join("repo", "scripts", "deploy.sh"), []byte("# TODO retry with backoff\n/* HACK */\n"), 0o644))
+
+	var stdout bytes.Buffer
+	app := cli.New(file.NewRepository(filepath.Join(dir, "todo.txt")), cli.WithOutput(&stdout, &bytes.Buffer{}),
+		cli.WithClock(func() time.Time { return time.Date(2026, 1, 20, 9, 0, 0, 0, time.UTC) }))
+
+	is.Equal(app.Run([]string{"scan", "./repo"}), cli.ExitOK)
+	is.Equal(stdout.String(), "Comments: 3 found, 3 added, 0 moved, 0 resolved\n")
+
+	content, err := os.ReadFile(filepath.Join(dir, "todo.txt"))
+	is.NoErr(err)
+	is.Equal(string(content), "(A) 2026-01-20 nil map panic file:"+filepath.ToSlash(dir)+"/repo/main.go:3 +code prioritised:2026-01-20\n"+
+		"(B) 2026-01-20 retry with backoff file:"+filepath.ToSlash(dir)+"/repo/scripts/deploy.sh:1 +code\n"+
+		"(C) 2026-01-20 HACK file:"+filepath.ToSlash(dir)+"/repo/scripts/deploy.sh:2 +code\n")
+
+	is.NoErr(os.WriteFile(filepath.Join("repo", "scripts", "deploy.sh"), []byte("#!/bin/sh\n# TODO retry with backoff\n"), 0o644))
+	stdout.Reset()
+	is.Equal(app.Run([]string{"scan", "./repo"}), cli.ExitOK)
+	is.Equal(stdout.String(), "Comments: 2 found, 0 added, 1 moved, 1 resolved\n")
+
+	content, err = os.ReadFile(filepath.Join(dir, "todo.txt"))
+	is.NoErr(err)
+	is.Equal(string(content), "(A) 2026-01-20 nil map panic file:"+filepath.ToSlash(dir)+"/repo/main.go:3 +code prioritised:2026-01-20\n"+
+		"(B) 2026-01-20 retry with backoff file:"+filepath.ToSlash(dir)+"/repo/scripts/deploy.sh:2 +code\n"+
+		"x 2026-01-20 2026-01-20 (C) HACK file:"+filepath.ToSlash(dir)+"/repo/scripts/deploy.sh:2 +code\n")
+
+	// The same files are recognised when the scan runs from inside the repository
+	t.Chdir("repo")
+	stdout.Reset()
+	is.Equal(app.Run([]string{"scan", "."}), cli.ExitOK)
+	is.Equal(stdout.String(), "Comments: 2 found, 0 added, 0 moved, 0 resolved\n")
+}
+
+func TestStory049_CompletingACodeTodo(t *testing.T) {
+	// Scenario: Completing a code todo
+	is := is.New(t)
+	dir := t.TempDir()
+	source := filepath.Join(dir, "main.go")
+	code := "package main\n\n// TODO retry with backoff\n"
+	is.NoErr(os.WriteFile(source, []byte(code), 0o644))
+
+	app := cli.New(file.NewRepository(filepath.Join(dir, "todo.txt")), cli.WithOutput(&bytes.Buffer{}, &bytes.Buffer{}))
+	is.Equal(app.Run([]string{"scan", dir}), cli.ExitOK)
+	is.Equal(app.Run([]string{"done", "1"}), cli.ExitOK)
+	is.Equal(app.Run([]string{"archive"}), cli.ExitOK)
+	is.Equal(app.Run([]string{"scan", dir}), cli.ExitOK)
+
+	content, err := os.ReadFile(filepath.Join(dir, "todo.txt"))
+	is.NoErr(err)
+	is.Equal(string(content), "")
+
+	after, err := os.ReadFile(source)
+	is.NoErr(err)
+	is.Equal(string(after), code)
+}
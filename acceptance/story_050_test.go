@@ -0,0 +1,76 @@
+package acceptance_test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/matryer/is"
+	"github.com/quii/todo-eisenhower/adapters/cli"
+	"github.com/quii/todo-eisenhower/adapters/file"
+	"github.com/quii/todo-eisenhower/adapters/notes"
+)
+
+// Story 050: Sync Markdown Checklists
+
+func TestStory050_SyncingNotes(t *testing.T) {
+	// Scenario: Syncing notes
+	// Scenario: Items without a priority
+	// Scenario: Completing a todo ticks its box
+	// Scenario: Ticking a box completes its todo
+	// Scenario: Notes change
+	//nolint:gocritic // importShadow: is := is.New(t) is idiomatic for github.com/matryer/is
+	is := is.New(t)
+	dir := t.TempDir()
+	vault := filepath.Join(dir, "Notes")
+	is.NoErr(os.MkdirAll(filepath.Join(vault, "Daily"), 0o755))
+	inbox := filepath.Join(vault, "Daily", "2026-01-20.md")
+	is.NoErr(os.WriteFile(inbox, []byte("# Tuesday\n\n"+
+		"- [ ] (A) Renew passport #admin #context/town 📅 2026-01-30\n"+
+		"- [ ] Plan roadmap ⏫\n"+
+		"- [x] Book venue\n"+
+		"- [ ] Call mum\n"), 0o644))
+
+	var stdout bytes.Buffer
+	repo := notes.NewRepository(file.NewRepository(filepath.Join(dir, "todo.txt")), vault)
+	app := cli.New(repo, cli.WithOutput(&stdout, &bytes.Buffer{}), cli.WithNotesDir(vault),
+		cli.WithClock(func() time.Time { return time.Date(2026, 1, 20, 9, 0, 0, 0, time.UTC) }))
+
+	is.Equal(app.Run([]string{"notes"}), cli.ExitOK)
+	is.Equal(stdout.String(), "Checklist items: 4 found, 3 added, 0 moved, 0 completed, 0 reopened, 0 removed\n")
+
+	content, err := os.ReadFile(filepath.Join(dir, "todo.txt"))
+	is.NoErr(err)
+	is.Equal(string(content), "(A) 2026-01-20 Renew passport note:Daily/2026-01-20.md:3 +admin @town due:2026-01-30 prioritised:2026-01-20\n"+
+		"(B) 2026-01-20 Plan roadmap note:Daily/2026-01-20.md:4\n"+
+		"(E) 2026-01-20 Call mum note:Daily/2026-01-20.md:6\n")
+
+	// Completing a todo ticks its box
+	is.Equal(app.Run([]string{"done", "1"}), cli.ExitOK)
+	note, err := os.ReadFile(inbox)
+	is.NoErr(err)
+	is.Equal(string(note), "# Tuesday\n\n"+
+		"- [x] (A) Renew passport #admin #context/town 📅 2026-01-30\n"+
+		"- [ ] Plan roadmap ⏫\n"+
+		"- [x] Book venue\n"+
+		"- [ ] Call mum\n")
+
+	// Ticking a box, moving an item and deleting another
+	is.NoErr(os.WriteFile(inbox, []byte("# Tuesday\n\nMorning\n\n"+
+		"- [x] (A) Renew passport #admin #context/town 📅 2026-01-30\n"+
+		"- [x] Plan roadmap ⏫\n"+
+		"- [x] Book venue\n"), 0o644))
+	stdout.Reset()
+	is.Equal(app.Run([]string{"notes"}), cli.ExitOK)
+	is.Equal(stdout.String(), "Checklist items: 3 found, 0 added, 2 moved, 1 completed, 0 reopened, 1 removed\n")
+
+	content, err = os.ReadFile(filepath.Join(dir, "todo.txt"))
+	is.NoErr(err)
+	lines := strings.SplitAfterN(string(content), "\n", 2)
+	is.True(strings.HasSuffix(lines[0], " 2026-01-20 (A) Renew passport note:Daily/2026-01-20.md:5 +admin @town due:2026-01-30 prioritised:2026-01-20\n")) // done uses the real clock
+	is.Equal(lines[1], "x 2026-01-20 2026-01-20 (B) Plan roadmap note:Daily/2026-01-20.md:6\n"+
+		"x 2026-01-20 2026-01-20 (E) Call mum note:Daily/2026-01-20.md:6\n")
+}
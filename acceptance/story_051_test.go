@@ -0,0 +1,70 @@
+package acceptance_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/matryer/is"
+	"github.com/quii/todo-eisenhower/adapters/file"
+	"github.com/quii/todo-eisenhower/adapters/ui"
+	"github.com/quii/todo-eisenhower/domain/matrix"
+	"github.com/quii/todo-eisenhower/usecases"
+)
+
+// Story 051: Org-mode and TaskPaper Files
+
+func TestStory051_OpeningAnOrgFile(t *testing.T) {
+	// Scenario: Opening an Org file
+	// Scenario: Changes are written back as Org
+	is := is.New(t)
+	path := filepath.Join(t.TempDir(), "notes.org")
+	is.NoErr(os.WriteFile(path, []byte("* Errands\n"+
+		"Things to do in town.\n"+
+		"** TODO [#A] Renew passport :admin:@town:\n"+
+		"   DEADLINE: <2026-01-30 Fri>\n"+
+		"   Bring the old one.\n"+
+		"** DONE [#B] Book venue\n"+
+		"   CLOSED: [2026-01-12 Mon]\n"), 0o644))
+
+	repository := file.NewRepository(path)
+	m, err := usecases.LoadMatrix(repository)
+	is.NoErr(err)
+	is.Equal(len(m.DoFirst()), 1)
+	is.Equal(m.DoFirst()[0].Projects(), []string{"admin"})
+	is.Equal(m.DoFirst()[0].Contexts(), []string{"town"})
+	is.Equal(m.DoFirst()[0].DueDate().Format("2006-01-02"), "2026-01-30")
+	is.True(m.Schedule()[0].IsCompleted())
+
+	model := ui.NewModelWithRepository(m, path, repository)
+	model = updateModel(model, tea.WindowSizeMsg{Width: 120, Height: 40})
+	is.True(strings.Contains(stripANSI(model.View()), "Renew passport"))
+	model = updateModel(model, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'1'}})
+	_ = updateModel(model, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{' '}})
+
+	content, err := os.ReadFile(path)
+	is.NoErr(err)
+	is.True(strings.HasPrefix(string(content), "* Errands\nThings to do in town.\n** DONE [#A] Renew passport :admin:@town:\n  CLOSED: ["))
+	is.True(strings.HasSuffix(string(content), "   Bring the old one.\n** DONE [#B] Book venue\n   CLOSED: [2026-01-12 Mon]\n"))
+}
+
+func TestStory051_OpeningATaskPaperFile(t *testing.T) {
+	// Scenario: Opening a TaskPaper file
+	is := is.New(t)
+	path := filepath.Join(t.TempDir(), "tasks.taskpaper")
+	is.NoErr(os.WriteFile(path, []byte("Home:\n\t- Pay council tax @priority(B) @due(2026-02-01)\n"), 0o644))
+
+	repository := file.NewRepository(path)
+	m, err := usecases.LoadMatrix(repository)
+	is.NoErr(err)
+	is.Equal(m.Schedule()[0].String(), "(B) Pay council tax +Home due:2026-02-01\n")
+
+	_, err = usecases.ToggleCompletion(repository, m, matrix.ScheduleQuadrant, 0)
+	is.NoErr(err)
+
+	content, err := os.ReadFile(path)
+	is.NoErr(err)
+	is.True(strings.HasPrefix(string(content), "Home:\n\t- Pay council tax @priority(B) @due(2026-02-01) @done("))
+}
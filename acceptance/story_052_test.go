@@ -0,0 +1,108 @@
+package acceptance_test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/matryer/is"
+	"github.com/quii/todo-eisenhower/adapters/cli"
+	"github.com/quii/todo-eisenhower/adapters/file"
+	"github.com/quii/todo-eisenhower/usecases"
+)
+
+// Story 052: Todos from Email
+
+func mailApp(dir string, stdout *bytes.Buffer) *cli.App {
+	todoPath := filepath.Join(dir, "todo.txt")
+	return cli.New(file.NewRepository(todoPath), cli.WithOutput(stdout, &bytes.Buffer{}),
+		cli.WithMailLog(file.NewMailLog(file.MailLogPath(todoPath))),
+		cli.WithClock(func() time.Time { return time.Date(2026, 1, 20, 9, 0, 0, 0, time.UTC) }))
+}
+
+func writeMaildir(t *testing.T, dir string, messages map[string]string) {
+	t.Helper()
+	for name, message := range messages {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(message), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestStory052_ImportingFlaggedMail(t *testing.T) {
+	// Scenario: Importing flagged mail
+	// Scenario: Importing again
+	//nolint:gocritic // importShadow: is := is.New(t) is idiomatic for github.com/matryer/is
+	is := is.New(t)
+	dir := t.TempDir()
+	maildir := filepath.Join(dir, "Mail", "Flagged")
+	writeMaildir(t, maildir, map[string]string{
+		"cur/1768899600.M1P1.host:2,FS": "From: Alice <alice@example.com>\nSubject: Contract review\nMessage-ID: <a1@example.com>\n\nPlease look\n",
+		"cur/1768899700.M2P1.host:2,S":  "From: carol@example.com\nSubject: Hello\nMessage-ID: <c3@example.com>\n\nHi\n",
+	})
+
+	var stdout bytes.Buffer
+	app := mailApp(dir, &stdout)
+	is.Equal(app.Run([]string{"mail", maildir}), cli.ExitOK)
+	is.Equal(stdout.String(), "Imported 1 todo\n")
+
+	content, err := os.ReadFile(filepath.Join(dir, "todo.txt"))
+	is.NoErr(err)
+	is.Equal(string(content), "(B) 2026-01-20 Contract review msgid:a1%40example.com @alice_example_com\n")
+
+	is.Equal(app.Run([]string{"done", "1"}), cli.ExitOK)
+	is.Equal(app.Run([]string{"archive"}), cli.ExitOK)
+	stdout.Reset()
+	is.Equal(app.Run([]string{"mail", maildir}), cli.ExitOK)
+	is.Equal(stdout.String(), "Nothing new to import\n")
+}
+
+func TestStory052_ImportingLabelledMail(t *testing.T) {
+	// Scenario: Importing labelled mail
+	is := is.New(t)
+	dir := t.TempDir()
+	mbox := filepath.Join(dir, "Inbox")
+	is.NoErr(os.WriteFile(mbox, []byte("From bob@example.com Mon Jan 19 10:00:00 2026\n"+
+		"From: bob@example.com\nSubject: Invoice 42\nMessage-ID: <b2@example.com>\nX-Keywords: todo\n\nBody\n"), 0o644))
+
+	var stdout bytes.Buffer
+	is.Equal(mailApp(dir, &stdout).Run([]string{"mail", "--label", "todo", mbox}), cli.ExitOK)
+	is.Equal(stdout.String(), "Imported 1 todo\n")
+
+	content, err := os.ReadFile(filepath.Join(dir, "todo.txt"))
+	is.NoErr(err)
+	is.Equal(string(content), "(B) 2026-01-20 Invoice 42 msgid:b2%40example.com @bob_example_com\n")
+}
+
+func TestStory052_DeletedTodosStayDeleted(t *testing.T) {
+	// Scenario: Deleted todos stay deleted
+	is := is.New(t)
+	dir := t.TempDir()
+	maildir := filepath.Join(dir, "Flagged")
+	writeMaildir(t, maildir, map[string]string{
+		"new/1768899600.M1P1.host:2,F": "From: alice@example.com\nSubject: Contract review\nMessage-ID: <a1@example.com>\n\nPlease look\n",
+	})
+
+	var stdout bytes.Buffer
+	app := mailApp(dir, &stdout)
+	is.Equal(app.Run([]string{"mail", maildir}), cli.ExitOK)
+
+	repo := file.NewRepository(filepath.Join(dir, "todo.txt"))
+	m, err := usecases.LoadMatrix(repo)
+	is.NoErr(err)
+	_, err = usecases.DeleteTodo(repo, m, m.Schedule()[0])
+	is.NoErr(err)
+
+	stdout.Reset()
+	is.Equal(app.Run([]string{"mail", maildir}), cli.ExitOK)
+	is.Equal(stdout.String(), "Nothing new to import\n")
+	m, err = usecases.LoadMatrix(repo)
+	is.NoErr(err)
+	is.Equal(len(m.AllTodosIncludingBacklog()), 0)
+}
@@ -0,0 +1,177 @@
+package acceptance_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/matryer/is"
+	"github.com/quii/todo-eisenhower/adapters/file"
+	"github.com/quii/todo-eisenhower/adapters/httpapi"
+	"github.com/quii/todo-eisenhower/adapters/jsonexport"
+	"github.com/quii/todo-eisenhower/domain/todo"
+	"github.com/quii/todo-eisenhower/usecases"
+)
+
+// Story 053: REST API
+
+// apiToken is the bearer token apiServer requires, as eisenhower serve does
+const apiToken = "s3cret"
+
+// apiServer serves the todo file in dir
+func apiServer(t *testing.T, dir, content string) *httptest.Server {
+	t.Helper()
+	todoPath := filepath.Join(dir, "todo.txt")
+	if err := os.WriteFile(todoPath, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	server := httptest.NewServer(httpapi.NewServer(file.NewRepository(todoPath),
+		httpapi.WithClock(func() time.Time { return time.Date(2026, 1, 20, 9, 0, 0, 0, time.UTC) }), httpapi.WithToken(apiToken)))
+	t.Cleanup(server.Close)
+	return server
+}
+
+// apiCall sends a request with the token, and a JSON body when there is one, and decodes any JSON response into out
+func apiCall(t *testing.T, server *httptest.Server, method, path, body string, out any) int {
+	t.Helper()
+	req, err := http.NewRequest(method, server.URL+path, strings.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("Authorization", "Bearer "+apiToken)
+	if body != "" {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	res, err := server.Client().Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = res.Body.Close() }()
+	if out != nil {
+		if err := json.NewDecoder(res.Body).Decode(out); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return res.StatusCode
+}
+
+func TestStory053_ListingAndAdding(t *testing.T) {
+	// Scenario: Listing the matrix
+	// Scenario: Adding a todo
+	//nolint:gocritic // importShadow: is := is.New(t) is idiomatic for github.com/matryer/is
+	is := is.New(t)
+	dir := t.TempDir()
+	server := apiServer(t, dir, "(B) Plan roadmap\n(A) Fix prod +ops\n")
+
+	var doc jsonexport.Document
+	is.Equal(apiCall(t, server, http.MethodGet, "/todos", "", &doc), http.StatusOK)
+	is.Equal(len(doc.Todos), 2)
+	is.Equal(doc.Todos[0].Line, "(A) Fix prod +ops")
+	is.Equal(doc.Todos[1].Quadrant, "schedule")
+	is.Equal(doc.Inventory.TotalActive, 2)
+
+	is.Equal(apiCall(t, server, http.MethodGet, "/todos?quadrant=schedule", "", &doc), http.StatusOK)
+	is.Equal(len(doc.Todos), 1)
+	is.Equal(doc.Todos[0].Description, "Plan roadmap")
+
+	var added jsonexport.Todo
+	is.Equal(apiCall(t, server, http.MethodPost, "/todos", `{"todo": "(B) Renew passport due:tomorrow"}`, &added), http.StatusCreated)
+	is.Equal(added.ID, 3)
+	is.Equal(added.DueDate, "2026-01-21")
+
+	content, err := os.ReadFile(filepath.Join(dir, "todo.txt"))
+	is.NoErr(err)
+	is.True(strings.HasPrefix(string(content), "(A) Fix prod +ops\n(B) Plan roadmap\n(B) "))
+	is.True(strings.HasSuffix(string(content), " Renew passport due:2026-01-21\n"))
+}
+
+func TestStory053_ChangingArchivingAndDeleting(t *testing.T) {
+	// Scenario: Changing a todo
+	// Scenario: Archiving and deleting
+	is := is.New(t)
+	dir := t.TempDir()
+	server := apiServer(t, dir, "(A) Fix prod\n(B) Plan roadmap\n")
+	read := func(name string) string {
+		content, err := os.ReadFile(filepath.Join(dir, name))
+		is.NoErr(err)
+		return string(content)
+	}
+
+	var changed jsonexport.Todo
+	is.Equal(apiCall(t, server, http.MethodPut, "/todos/1", `{"todo": "Fix prod database +ops"}`, &changed), http.StatusOK)
+	is.Equal(read("todo.txt"), "(A) Fix prod database +ops\n(B) Plan roadmap\n")
+
+	is.Equal(apiCall(t, server, http.MethodPost, "/todos/2/move", `{"quadrant": "delegate"}`, &changed), http.StatusOK)
+	is.Equal(read("todo.txt"), "(A) Fix prod database +ops\n(C) Plan roadmap\n")
+
+	is.Equal(apiCall(t, server, http.MethodPost, "/todos/1/toggle", "", &changed), http.StatusOK)
+	is.True(changed.Completed)
+
+	is.Equal(apiCall(t, server, http.MethodPost, "/todos/1/archive", "", nil), http.StatusNoContent)
+	is.True(strings.HasSuffix(read("done.txt"), "(A) Fix prod database +ops\n"))
+
+	is.Equal(apiCall(t, server, http.MethodDelete, "/todos/1", "", nil), http.StatusNoContent)
+	is.Equal(read("todo.txt"), "")
+	is.True(strings.Contains(read("deleted.txt"), "Plan roadmap"))
+}
+
+func TestStory053_Mistakes(t *testing.T) {
+	// Scenario: Mistakes
+	is := is.New(t)
+	server := apiServer(t, t.TempDir(), "(A) Fix prod\n")
+
+	var body map[string]string
+	is.Equal(apiCall(t, server, http.MethodGet, "/todos/9", "", &body), http.StatusNotFound)
+	is.Equal(body["error"], "no todo with id 9")
+	is.Equal(apiCall(t, server, http.MethodPost, "/todos/1/move", `{"quadrant": "someday"}`, &body), http.StatusBadRequest)
+	is.Equal(apiCall(t, server, http.MethodPost, "/todos", `(A) Fix prod`, &body), http.StatusBadRequest)
+}
+
+func TestStory053_ChangesMadeElsewhere(t *testing.T) {
+	// Scenario: Changes made elsewhere
+	is := is.New(t)
+	dir := t.TempDir()
+	server := apiServer(t, dir, "(A) Fix prod\n")
+
+	repo := file.NewRepository(filepath.Join(dir, "todo.txt"))
+	m, err := usecases.LoadMatrix(repo)
+	is.NoErr(err)
+	_, err = usecases.AddTodo(repo, m, "Plan roadmap", todo.PriorityB)
+	is.NoErr(err)
+
+	var added jsonexport.Todo
+	is.Equal(apiCall(t, server, http.MethodPost, "/todos", `{"todo": "(C) Reply to emails"}`, &added), http.StatusCreated)
+	is.Equal(added.ID, 3)
+
+	m, err = usecases.LoadMatrix(repo)
+	is.NoErr(err)
+	is.Equal(len(m.AllTodosIncludingBacklog()), 3)
+}
+
+func TestStory053_KeepingTheAPIPrivate(t *testing.T) {
+	// Scenario: Keeping the API private
+	is := is.New(t)
+	dir := t.TempDir()
+	server := apiServer(t, dir, "(A) Fix prod\n")
+
+	forged := func(header http.Header) int {
+		req, err := http.NewRequest(http.MethodPost, server.URL+"/todos/1/toggle", nil)
+		is.NoErr(err)
+		req.Header = header
+		res, err := server.Client().Do(req)
+		is.NoErr(err)
+		_ = res.Body.Close()
+		return res.StatusCode
+	}
+	is.Equal(forged(http.Header{}), http.StatusUnauthorized)
+	is.Equal(forged(http.Header{"Authorization": {"Bearer " + apiToken}, "Origin": {"https://attacker.example"}}), http.StatusForbidden)
+
+	content, err := os.ReadFile(filepath.Join(dir, "todo.txt"))
+	is.NoErr(err)
+	is.Equal(string(content), "(A) Fix prod\n")
+}
@@ -2,6 +2,9 @@ package acceptance_test
 
 import (
 	"regexp"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/quii/todo-eisenhower/adapters/ui"
 )
 
 // stripANSI removes ANSI escape codes from a string for easier testing
@@ -9,3 +12,9 @@ func stripANSI(s string) string {
 	ansiRegex := regexp.MustCompile(`\x1b\[[0-9;]*[a-zA-Z]`)
 	return ansiRegex.ReplaceAllString(s, "")
 }
+
+// updateModel sends a message to the model and returns the updated ui.Model
+func updateModel(model ui.Model, msg tea.Msg) ui.Model {
+	updatedModel, _ := model.Update(msg)
+	return updatedModel.(ui.Model)
+}
@@ -0,0 +1,284 @@
+// Package cli runs eisenhower's non-interactive subcommands, for scripts, cron jobs, git hooks and aliases.
+//
+// Each subcommand loads the matrix, applies one use case and exits. Todos are identified by the
+// number list prints next to them: their position in the matrix, counting from the first Do First todo
+// through Schedule, Delegate, Eliminate and the backlog (not their line number in the todo file).
+package cli
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/quii/todo-eisenhower/adapters/report"
+	"github.com/quii/todo-eisenhower/domain/matrix"
+	"github.com/quii/todo-eisenhower/domain/todo"
+	"github.com/quii/todo-eisenhower/usecases"
+)
+
+// Exit codes returned by Run
+const (
+	ExitOK      = 0
+	ExitFailure = 1 // the command couldn't be carried out (unknown id, unreadable file, ...)
+	ExitUsage   = 2 // the command line was wrong
+)
+
+// command runs a subcommand with its arguments
+type command struct {
+	usage string
+	run   func(a *App, args []string) error
+}
+
+// commands are the subcommands, by name
+var commands = map[string]command{
+	"add":     {usage: `add [--quadrant QUADRANT] "(A) description +project @context due:tomorrow"`, run: (*App).add},
+	"list":    {usage: "list [--quadrant QUADRANT] [--filter +project|@context]", run: (*App).list},
+	"done":    {usage: "done ID", run: (*App).done},
+	"move":    {usage: "move ID QUADRANT", run: (*App).move},
+	"archive": {usage: "archive", run: (*App).archive},
+	"edit":    {usage: `edit ID "(B) new description +project"`, run: (*App).edit},
+	"export":  {usage: "export [--map COLUMN=field,...] json|ics|csv|org|taskpaper", run: (*App).export},
+	"import":  {usage: "import [--map COLUMN=field,...] [--important H,M] [--urgent-within DAYS] [--urgent-above N] ics|csv|org|taskpaper|taskwarrior|todoist FILE|-", run: (*App).importFile},
+	"report":  {usage: "report [--format markdown|html] [--template FILE] [--print-template]", run: (*App).report},
+	"scan":    {usage: "scan [DIR]", run: (*App).scan},
+	"notes":   {usage: "notes", run: (*App).syncNotes},
+	"mail":    {usage: "mail [--label LABEL,...] MAILDIR|MBOX", run: (*App).importMail},
+	"serve":   {usage: "serve [--addr 127.0.0.1:8080] [--token TOKEN]", run: (*App).serve},
+}
+
+// IsCommand returns true if name is a subcommand
+func IsCommand(name string) bool {
+	_, ok := commands[name]
+	return ok
+}
+
+// App runs subcommands against a todo repository
+type App struct {
+	repo          usecases.TodoRepository
+	stdin         io.Reader
+	stdout        io.Writer
+	stderr        io.Writer
+	now           func() time.Time
+	stalePolicy   todo.StalePolicy
+	reportOptions []report.Option
+	notesDir      string
+	mailLog       usecases.MailLog
+	serveToken    string
+}
+
+// Option configures optional behaviour of an App
+type Option func(*App)
+
+// WithOutput sets where results and errors are written (os.Stdout and os.Stderr by default)
+func WithOutput(stdout, stderr io.Writer) Option {
+	return func(a *App) {
+		a.stdout = stdout
+		a.stderr = stderr
+	}
+}
+
+// WithInput sets where import reads "-" from (os.Stdin by default)
+func WithInput(stdin io.Reader) Option {
+	return func(a *App) {
+		a.stdin = stdin
+	}
+}
+
+// WithClock sets the clock used to expand date shortcuts such as due:tomorrow (time.Now by default)
+func WithClock(now func() time.Time) Option {
+	return func(a *App) {
+		a.now = now
+	}
+}
+
+// WithStalePolicy sets when exported and reported todos are flagged as stale (todo.DefaultStalePolicy by default)
+func WithStalePolicy(policy todo.StalePolicy) Option {
+	return func(a *App) {
+		a.stalePolicy = policy
+	}
+}
+
+// WithReportOptions sets the quadrant titles, colors and WIP threshold used by report
+func WithReportOptions(opts ...report.Option) Option {
+	return func(a *App) {
+		a.reportOptions = opts
+	}
+}
+
+// WithNotesDir sets the directory of Markdown notes whose checklists notes syncs
+func WithNotesDir(dir string) Option {
+	return func(a *App) {
+		a.notesDir = dir
+	}
+}
+
+// WithMailLog sets where mail remembers the messages it has imported, so deleting a todo doesn't
+// bring it back on the next run (by default, only messages with a todo are skipped)
+func WithMailLog(log usecases.MailLog) Option {
+	return func(a *App) {
+		a.mailLog = log
+	}
+}
+
+// WithServeToken sets the bearer token serve requires when --token isn't given
+// (by default serve makes a new one each run)
+func WithServeToken(token string) Option {
+	return func(a *App) {
+		a.serveToken = token
+	}
+}
+
+// New creates an App that reads and writes todos through repo
+func New(repo usecases.TodoRepository, opts ...Option) *App {
+	a := &App{repo: repo, stdin: os.Stdin, stdout: os.Stdout, stderr: os.Stderr, now: time.Now, stalePolicy: todo.DefaultStalePolicy}
+	for _, opt := range opts {
+		opt(a)
+	}
+	return a
+}
+
+// usageError is a mistake on the command line, reported with the command's usage
+type usageError struct {
+	message string
+}
+
+func (e usageError) Error() string {
+	return e.message
+}
+
+// errUsage reports a command line mistake
+func errUsage(format string, args ...any) error {
+	return usageError{message: fmt.Sprintf(format, args...)}
+}
+
+// Run runs the subcommand named by args[0] and returns the process exit code
+func (a *App) Run(args []string) int {
+	if len(args) == 0 || !IsCommand(args[0]) {
+		a.printUsage()
+		return ExitUsage
+	}
+
+	cmd := commands[args[0]]
+	err := cmd.run(a, args[1:])
+
+	var usage usageError
+	switch {
+	case err == nil:
+		return ExitOK
+	case errors.Is(err, flag.ErrHelp):
+		_, _ = fmt.Fprintf(a.stdout, "Usage: eisenhower %s\n", cmd.usage)
+		return ExitOK
+	case errors.As(err, &usage):
+		_, _ = fmt.Fprintf(a.stderr, "Error: %v\nUsage: eisenhower %s\n", err, cmd.usage)
+		return ExitUsage
+	default:
+		_, _ = fmt.Fprintf(a.stderr, "Error: %v\n", err)
+		return ExitFailure
+	}
+}
+
+// printUsage lists the subcommands
+func (a *App) printUsage() {
+	names := make([]string, 0, len(commands))
+	for name := range commands {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	_, _ = fmt.Fprintln(a.stderr, "Usage:")
+	for _, name := range names {
+		_, _ = fmt.Fprintf(a.stderr, "  eisenhower %s\n", commands[name].usage)
+	}
+	_, _ = fmt.Fprintln(a.stderr, "\nQuadrants: do-first, schedule, delegate, eliminate, backlog")
+	_, _ = fmt.Fprintln(a.stderr, "--file PATH picks the todo file or workspace directory, before or after the subcommand")
+}
+
+// SplitFileFlag takes the --file flag out of a subcommand's arguments, so the todo file can be given
+// after the subcommand as well as before it. Arguments after "--" are left alone.
+func SplitFileFlag(args []string) (file string, rest []string, err error) {
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		if arg == "--" {
+			return file, append(rest, args[i:]...), nil
+		}
+		name, value, hasValue := strings.Cut(strings.TrimPrefix(strings.TrimPrefix(arg, "-"), "-"), "=")
+		if !strings.HasPrefix(arg, "-") || name != "file" {
+			rest = append(rest, arg)
+			continue
+		}
+		if !hasValue {
+			if i+1 == len(args) {
+				return "", nil, errUsage("flag needs an argument: -file")
+			}
+			i++
+			value = args[i]
+		}
+		file = value
+	}
+	return file, rest, nil
+}
+
+// flags creates a flag set for a subcommand that reports mistakes as usage errors rather than exiting
+func (a *App) flags(name string) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	return fs
+}
+
+// parseFlags parses a subcommand's flags, allowing them before or after its other arguments
+func parseFlags(fs *flag.FlagSet, args []string) ([]string, error) {
+	var positional []string
+	for {
+		if err := fs.Parse(args); err != nil {
+			if errors.Is(err, flag.ErrHelp) {
+				return nil, err
+			}
+			return nil, errUsage("%v", err)
+		}
+		args = fs.Args()
+		if len(args) == 0 {
+			return positional, nil
+		}
+		positional = append(positional, args[0])
+		args = args[1:]
+	}
+}
+
+// parseQuadrant reads a quadrant name given on the command line
+func parseQuadrant(name string) (matrix.QuadrantType, error) {
+	quadrant, ok := matrix.ParseQuadrant(name)
+	if !ok {
+		return quadrant, errUsage("unknown quadrant %q (expected do-first, schedule, delegate, eliminate or backlog)", name)
+	}
+	return quadrant, nil
+}
+
+// locate finds the todo with the id shown by list
+func locate(m matrix.Matrix, arg string) (quadrant matrix.QuadrantType, index int, err error) {
+	id, err := strconv.Atoi(arg)
+	if err != nil {
+		return quadrant, 0, errUsage("invalid todo id %q: expected a number from list", arg)
+	}
+	quadrant, index, ok := m.Locate(id)
+	if !ok {
+		return quadrant, 0, fmt.Errorf("no todo with id %d", id)
+	}
+	return quadrant, index, nil
+}
+
+// printTodo writes a todo in todo.txt format with its id
+func (a *App) printTodo(prefix string, id int, m matrix.Matrix, quadrant matrix.QuadrantType, index int) {
+	t := m.GetTodosForQuadrant(quadrant)[index]
+	_, _ = fmt.Fprintf(a.stdout, "%s%d %s", prefix, id, t.String())
+}
+
+// joinArgs joins the words of a description that wasn't quoted
+func joinArgs(args []string) string {
+	return strings.TrimSpace(strings.Join(args, " "))
+}
@@ -0,0 +1,548 @@
+package cli_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/matryer/is"
+	"github.com/quii/todo-eisenhower/adapters/cli"
+	"github.com/quii/todo-eisenhower/adapters/jsonexport"
+	"github.com/quii/todo-eisenhower/adapters/memory"
+	"github.com/quii/todo-eisenhower/domain/todo"
+	"github.com/quii/todo-eisenhower/domain/todotxt"
+)
+
+// today is a Tuesday, so due:tomorrow is 2026-01-21
+var today = time.Date(2026, 1, 20, 9, 0, 0, 0, time.UTC)
+
+// run runs a subcommand against repo and returns its exit code and output
+func run(repo *memory.Repository, args ...string) (code int, stdout, stderr string) {
+	var out, errOut bytes.Buffer
+	app := cli.New(repo,
+		cli.WithOutput(&out, &errOut),
+		cli.WithClock(func() time.Time { return today }),
+	)
+	code = app.Run(args)
+	return code, out.String(), errOut.String()
+}
+
+// repoWith returns a repository holding the given todo.txt lines
+func repoWith(t *testing.T, lines string) *memory.Repository {
+	t.Helper()
+	todos, err := todotxt.Unmarshal(strings.NewReader(lines))
+	if err != nil {
+		t.Fatal(err)
+	}
+	repo := memory.NewRepository()
+	if err := repo.SaveAll(todos); err != nil {
+		t.Fatal(err)
+	}
+	return repo
+}
+
+func TestAdd(t *testing.T) {
+	t.Run("adds a todo with its priority and expands date shortcuts", func(t *testing.T) {
+		//nolint:gocritic // importShadow: is := is.New(t) is idiomatic for github.com/matryer/is
+		is := is.New(t)
+		repo := memory.NewRepository()
+
+		code, stdout, _ := run(repo, "add", "(A) Fix prod +ops due:tomorrow")
+		is.Equal(code, cli.ExitOK)
+		is.True(strings.HasPrefix(stdout, "Added 1 (A) "))
+
+		todos, err := repo.LoadAll()
+		is.NoErr(err)
+		is.Equal(len(todos), 1)
+		is.Equal(todos[0].Priority(), todo.PriorityA)
+		is.Equal(todos[0].Description(), "Fix prod")
+		is.Equal(todos[0].Projects(), []string{"ops"})
+		is.Equal(todos[0].DueDate().Format(todotxt.DateFormat), "2026-01-21")
+	})
+
+	t.Run("adds to a quadrant given as a flag", func(t *testing.T) {
+		is := is.New(t)
+		repo := memory.NewRepository()
+
+		code, _, _ := run(repo, "add", "--quadrant", "schedule", "Plan", "roadmap")
+		is.Equal(code, cli.ExitOK)
+
+		todos, err := repo.LoadAll()
+		is.NoErr(err)
+		is.Equal(todos[0].Priority(), todo.PriorityB)
+		is.Equal(todos[0].Description(), "Plan roadmap")
+	})
+
+	t.Run("rejects a missing description", func(t *testing.T) {
+		is := is.New(t)
+		code, _, stderr := run(memory.NewRepository(), "add")
+		is.Equal(code, cli.ExitUsage)
+		is.True(strings.Contains(stderr, "missing todo description"))
+	})
+}
+
+func TestList(t *testing.T) {
+	repo := repoWith(t, "(C) Book venue +offsite\n(A) Fix prod +ops\n(B) Plan roadmap +ops\n")
+
+	t.Run("lists todos by quadrant with their ids", func(t *testing.T) {
+		is := is.New(t)
+		code, stdout, _ := run(repo, "list")
+		is.Equal(code, cli.ExitOK)
+		is.Equal(stdout, "1 (A) Fix prod +ops\n2 (B) Plan roadmap +ops\n3 (C) Book venue +offsite\n")
+	})
+
+	t.Run("lists one quadrant", func(t *testing.T) {
+		is := is.New(t)
+		_, stdout, _ := run(repo, "list", "--quadrant", "do-first")
+		is.Equal(stdout, "1 (A) Fix prod +ops\n")
+	})
+
+	t.Run("filters by tag, keeping ids", func(t *testing.T) {
+		is := is.New(t)
+		_, stdout, _ := run(repo, "list", "--filter", "+offsite")
+		is.Equal(stdout, "3 (C) Book venue +offsite\n")
+	})
+
+	t.Run("rejects an unknown quadrant", func(t *testing.T) {
+		is := is.New(t)
+		code, _, stderr := run(repo, "list", "--quadrant", "someday")
+		is.Equal(code, cli.ExitUsage)
+		is.True(strings.Contains(stderr, `unknown quadrant "someday"`))
+	})
+}
+
+func TestDone(t *testing.T) {
+	t.Run("completes a todo", func(t *testing.T) {
+		is := is.New(t)
+		repo := repoWith(t, "(A) Fix prod\n(B) Plan roadmap\n")
+
+		code, stdout, _ := run(repo, "done", "2")
+		is.Equal(code, cli.ExitOK)
+		is.True(strings.HasPrefix(stdout, "Completed 2 x "))
+
+		todos, err := repo.LoadAll()
+		is.NoErr(err)
+		is.True(!todos[0].IsCompleted())
+		is.True(todos[1].IsCompleted())
+	})
+
+	t.Run("fails for unknown and completed todos", func(t *testing.T) {
+		is := is.New(t)
+		repo := repoWith(t, "x 2026-01-19 (A) Fix prod\n")
+
+		code, _, stderr := run(repo, "done", "7")
+		is.Equal(code, cli.ExitFailure)
+		is.True(strings.Contains(stderr, "no todo with id 7"))
+
+		code, _, stderr = run(repo, "done", "1")
+		is.Equal(code, cli.ExitFailure)
+		is.True(strings.Contains(stderr, "already done"))
+
+		code, _, _ = run(repo, "done", "first")
+		is.Equal(code, cli.ExitUsage)
+	})
+}
+
+func TestMove(t *testing.T) {
+	is := is.New(t)
+	repo := repoWith(t, "(A) Fix prod\n(B) Plan roadmap\n")
+
+	code, stdout, _ := run(repo, "move", "1", "schedule")
+	is.Equal(code, cli.ExitOK)
+	is.Equal(stdout, "Moved to Schedule: 2 (B) Fix prod\n")
+
+	todos, err := repo.LoadAll()
+	is.NoErr(err)
+	is.Equal(todos[1].Description(), "Fix prod")
+	is.Equal(todos[1].Priority(), todo.PriorityB)
+}
+
+func TestArchive(t *testing.T) {
+	is := is.New(t)
+	repo := repoWith(t, "x 2026-01-19 (A) Fix prod\nx 2026-01-19 (B) Plan roadmap\n(C) Book venue\n")
+
+	code, stdout, _ := run(repo, "archive")
+	is.Equal(code, cli.ExitOK)
+	is.Equal(stdout, "Archived 2 completed todos\n")
+
+	archived, err := repo.LoadArchive()
+	is.NoErr(err)
+	is.Equal(len(archived), 2)
+
+	_, stdout, _ = run(repo, "archive")
+	is.Equal(stdout, "No completed todos to archive\n")
+}
+
+func TestEdit(t *testing.T) {
+	t.Run("replaces the description and tags", func(t *testing.T) {
+		is := is.New(t)
+		repo := repoWith(t, "(B) 2026-01-02 Plan roadmap\n")
+
+		code, _, _ := run(repo, "edit", "1", "Plan 2026 roadmap +product due:friday")
+		is.Equal(code, cli.ExitOK)
+
+		todos, err := repo.LoadAll()
+		is.NoErr(err)
+		is.Equal(todos[0].Description(), "Plan 2026 roadmap")
+		is.Equal(todos[0].Projects(), []string{"product"})
+		is.Equal(todos[0].DueDate().Format(todotxt.DateFormat), "2026-01-23")
+		is.Equal(todos[0].CreationDate().Format(todotxt.DateFormat), "2026-01-02") // dates are kept
+	})
+
+	t.Run("a new priority moves the todo", func(t *testing.T) {
+		is := is.New(t)
+		repo := repoWith(t, "(B) Plan roadmap\n")
+
+		code, stdout, _ := run(repo, "edit", "1", "(A) Plan roadmap today")
+		is.Equal(code, cli.ExitOK)
+		is.True(strings.HasPrefix(stdout, "Updated 1 (A) Plan roadmap today"))
+	})
+}
+
+func TestRun(t *testing.T) {
+	is := is.New(t)
+	code, _, stderr := run(memory.NewRepository(), "frobnicate")
+	is.Equal(code, cli.ExitUsage)
+	is.True(strings.Contains(stderr, "eisenhower add"))
+
+	is.True(cli.IsCommand("list"))
+	is.True(!cli.IsCommand("todo.txt"))
+}
+
+func TestExport(t *testing.T) {
+	t.Run("writes the matrix as JSON", func(t *testing.T) {
+		is := is.New(t)
+		repo := repoWith(t, "(A) 2026-01-02 Fix prod +ops prioritised:2026-01-02\n")
+
+		code, stdout, _ := run(repo, "export", "json")
+		is.Equal(code, cli.ExitOK)
+
+		var doc jsonexport.Document
+		is.NoErr(json.Unmarshal([]byte(stdout), &doc))
+		is.Equal(doc.SchemaVersion, jsonexport.SchemaVersion)
+		is.Equal(doc.Todos[0].Description, "Fix prod")
+		is.True(doc.Todos[0].Stale) // Do First for 12 business days
+	})
+
+	t.Run("writes the matrix as iCalendar", func(t *testing.T) {
+		is := is.New(t)
+		repo := repoWith(t, "(A) Fix prod due:2026-01-21\n")
+
+		code, stdout, _ := run(repo, "export", "ics")
+		is.Equal(code, cli.ExitOK)
+		is.True(strings.Contains(stdout, "SUMMARY:Fix prod\r\nPRIORITY:1\r\nDUE;VALUE=DATE:20260121\r\n"))
+
+		_, again, _ := run(repo, "export", "ics")
+		is.Equal(again, stdout) // the same UID, now kept in the todo
+
+		_, stdout, _ = run(repo, "list")
+		is.True(strings.Contains(stdout, "uid:"))
+	})
+
+	t.Run("writes the mapped csv columns", func(t *testing.T) {
+		is := is.New(t)
+		repo := repoWith(t, "(C) Book venue\n(A) Fix prod\n")
+
+		code, stdout, _ := run(repo, "export", "csv", "--map", "Title=description,Urgent=urgent,Important=important")
+		is.Equal(code, cli.ExitOK)
+		is.Equal(stdout, "Title,Urgent,Important\nFix prod,yes,yes\nBook venue,yes,no\n")
+
+		code, _, stderr := run(repo, "export", "csv", "--map", "Title=summary")
+		is.Equal(code, cli.ExitUsage)
+		is.True(strings.Contains(stderr, `unknown field "summary"`))
+	})
+
+	t.Run("writes org-mode and taskpaper", func(t *testing.T) {
+		is := is.New(t)
+		repo := repoWith(t, "(A) Fix prod +ops due:2026-01-21\n")
+
+		code, stdout, _ := run(repo, "export", "org")
+		is.Equal(code, cli.ExitOK)
+		is.Equal(stdout, "* TODO [#A] Fix prod :ops:\n  DEADLINE: <2026-01-21 Wed>\n")
+
+		_, stdout, _ = run(repo, "export", "taskpaper")
+		is.Equal(stdout, "ops:\n\t- Fix prod @priority(A) @due(2026-01-21)\n")
+	})
+
+	t.Run("rejects unknown formats", func(t *testing.T) {
+		is := is.New(t)
+		code, _, stderr := run(memory.NewRepository(), "export", "yaml")
+		is.Equal(code, cli.ExitUsage)
+		is.True(strings.Contains(stderr, `unknown export format "yaml"`))
+	})
+}
+
+func TestReport(t *testing.T) {
+	t.Run("renders markdown by default", func(t *testing.T) {
+		is := is.New(t)
+		repo := repoWith(t, "(A) Fix prod +ops due:2026-01-19\n")
+
+		code, stdout, _ := run(repo, "report")
+		is.Equal(code, cli.ExitOK)
+		is.True(strings.HasPrefix(stdout, "# Eisenhower Matrix Report"))
+		is.True(strings.Contains(stdout, "- Fix prod (due 2026-01-19, Do First)"))
+	})
+
+	t.Run("renders html with a custom template", func(t *testing.T) {
+		is := is.New(t)
+		repo := repoWith(t, "(A) Fix <prod>\n")
+		path := filepath.Join(t.TempDir(), "report.tmpl")
+		is.NoErr(os.WriteFile(path, []byte(`{{range .Quadrants}}{{range .Todos}}<p>{{.Description}}</p>{{end}}{{end}}`), 0o600))
+
+		code, stdout, _ := run(repo, "report", "--format", "html", "--template", path)
+		is.Equal(code, cli.ExitOK)
+		is.Equal(stdout, "<p>Fix &lt;prod&gt;</p>")
+	})
+
+	t.Run("prints the built-in template", func(t *testing.T) {
+		is := is.New(t)
+		code, stdout, _ := run(memory.NewRepository(), "report", "--format", "html", "--print-template")
+		is.Equal(code, cli.ExitOK)
+		is.True(strings.Contains(stdout, "range .Quadrants"))
+	})
+
+	t.Run("rejects unknown formats", func(t *testing.T) {
+		is := is.New(t)
+		code, _, stderr := run(memory.NewRepository(), "report", "--format", "pdf")
+		is.Equal(code, cli.ExitUsage)
+		is.True(strings.Contains(stderr, `unknown report format "pdf"`))
+	})
+}
+
+func TestImport(t *testing.T) {
+	calendar := "BEGIN:VCALENDAR\r\nBEGIN:VTODO\r\nUID:passport@example.com\r\nSUMMARY:Renew passport\r\n" +
+		"PRIORITY:1\r\nDUE;VALUE=DATE:20260130\r\nEND:VTODO\r\nEND:VCALENDAR\r\n"
+
+	t.Run("imports calendar tasks once", func(t *testing.T) {
+		is := is.New(t)
+		repo := memory.NewRepository()
+		importICS := func() (int, string) {
+			var out, errOut bytes.Buffer
+			app := cli.New(repo, cli.WithOutput(&out, &errOut), cli.WithInput(strings.NewReader(calendar)),
+				cli.WithClock(func() time.Time { return today }))
+			return app.Run([]string{"import", "ics", "-"}), out.String()
+		}
+
+		code, stdout := importICS()
+		is.Equal(code, cli.ExitOK)
+		is.Equal(stdout, "Imported 1 todo\n")
+
+		_, stdout = importICS()
+		is.Equal(stdout, "Nothing new to import\n")
+
+		_, stdout, _ = run(repo, "list")
+		is.Equal(stdout, "1 (A) Renew passport uid:passport%40example.com due:2026-01-30 prioritised:2026-01-20\n")
+	})
+
+	t.Run("imports spreadsheet rows with a column mapping", func(t *testing.T) {
+		is := is.New(t)
+		repo := memory.NewRepository()
+		sheet := "Title,Urgent,Important\nFix prod,yes,yes\nPlan roadmap,no,yes\n"
+
+		var out, errOut bytes.Buffer
+		app := cli.New(repo, cli.WithOutput(&out, &errOut), cli.WithInput(strings.NewReader(sheet)),
+			cli.WithClock(func() time.Time { return today }))
+		code := app.Run([]string{"import", "csv", "--map", "Title=description,Urgent=urgent,Important=important", "-"})
+		is.Equal(code, cli.ExitOK)
+		is.Equal(out.String(), "Imported 2 todos\n")
+
+		_, stdout, _ := run(repo, "list")
+		is.Equal(stdout, "1 (A) 2026-01-20 Fix prod prioritised:2026-01-20\n2 (B) 2026-01-20 Plan roadmap\n")
+	})
+
+	t.Run("imports taskwarrior tasks with quadrant rules", func(t *testing.T) {
+		is := is.New(t)
+		repo := memory.NewRepository()
+		export := `[{"description":"Plan roadmap","entry":"20260110T090000Z","priority":"M","status":"pending"},` +
+			`{"description":"Book venue","entry":"20260105T090000Z","end":"20260112T160000Z","status":"completed"}]`
+
+		var out, errOut bytes.Buffer
+		app := cli.New(repo, cli.WithOutput(&out, &errOut), cli.WithInput(strings.NewReader(export)),
+			cli.WithClock(func() time.Time { return today }))
+		is.Equal(app.Run([]string{"import", "--important", "H,M", "taskwarrior", "-"}), cli.ExitOK)
+		is.Equal(out.String(), "Imported 2 todos\n")
+
+		_, stdout, _ := run(repo, "list")
+		is.Equal(stdout, "1 (B) 2026-01-10 Plan roadmap\n")
+		is.True(strings.Contains(repo.ArchiveString(), "x 2026-01-12 2026-01-05 (D) Book venue"))
+
+		code, _, stderr := run(repo, "import", "--important", "H", "csv", "sheet.csv")
+		is.Equal(code, cli.ExitUsage)
+		is.True(strings.Contains(stderr, "--important doesn't apply to csv"))
+	})
+
+	t.Run("imports org-mode and taskpaper files", func(t *testing.T) {
+		is := is.New(t)
+		repo := memory.NewRepository()
+		dir := t.TempDir()
+		is.NoErr(os.WriteFile(filepath.Join(dir, "notes.org"), []byte("* TODO [#A] Renew passport :admin:\n"), 0o644))
+		is.NoErr(os.WriteFile(filepath.Join(dir, "tasks.taskpaper"), []byte("Home:\n\t- Pay council tax @priority(B)\n"), 0o644))
+
+		code, stdout, _ := run(repo, "import", "org", filepath.Join(dir, "notes.org"))
+		is.Equal(code, cli.ExitOK)
+		is.Equal(stdout, "Imported 1 todo\n")
+		code, _, _ = run(repo, "import", "taskpaper", filepath.Join(dir, "tasks.taskpaper"))
+		is.Equal(code, cli.ExitOK)
+
+		_, stdout, _ = run(repo, "list")
+		is.Equal(stdout, "1 (A) Renew passport +admin prioritised:2026-01-20\n2 (B) Pay council tax +Home\n")
+	})
+
+	t.Run("reports unreadable files and unknown formats", func(t *testing.T) {
+		is := is.New(t)
+		code, _, stderr := run(memory.NewRepository(), "import", "ics", filepath.Join(t.TempDir(), "missing.ics"))
+		is.Equal(code, cli.ExitFailure)
+		is.True(strings.Contains(stderr, "missing.ics"))
+
+		code, _, stderr = run(memory.NewRepository(), "import", "vcard", "contacts.vcf")
+		is.Equal(code, cli.ExitUsage)
+		is.True(strings.Contains(stderr, `unknown import format "vcard" (expected csv, ics, org, taskpaper, taskwarrior, todoist)`))
+
+		code, _, stderr = run(memory.NewRepository(), "import", "--map", "Title=description", "ics", "calendar.ics")
+		is.Equal(code, cli.ExitUsage)
+		is.True(strings.Contains(stderr, "--map doesn't apply to ics"))
+	})
+}
+
+func TestScan(t *testing.T) {
+	t.Run("turns code comments into todos and keeps them in step with the code", func(t *testing.T) {
+		is := is.New(t)
+		repo := memory.NewRepository()
+		dir := t.TempDir()
+		source := filepath.Join(dir, "main.go")
+		is.NoErr(os.WriteFile(source, []byte("package main\n\n// FIXME: nil map panic\n// TODO retry with backoff\n"), 0o644))
+
+		code, stdout, _ := run(repo, "scan", dir)
+		is.Equal(code, cli.ExitOK)
+		is.Equal(stdout, "Comments: 2 found, 2 added, 0 moved, 0 resolved\n")
+
+		_, stdout, _ = run(repo, "list", "--filter", "+code")
+		path := filepath.ToSlash(source)
+		is.Equal(stdout, "1 (A) 2026-01-20 nil map panic file:"+path+":3 +code prioritised:2026-01-20\n"+
+			"2 (B) 2026-01-20 retry with backoff file:"+path+":4 +code\n")
+
+		is.NoErr(os.WriteFile(source, []byte("package main\n\n// TODO retry with backoff\n"), 0o644))
+		_, stdout, _ = run(repo, "scan", dir)
+		is.Equal(stdout, "Comments: 1 found, 0 added, 1 moved, 1 resolved\n")
+
+		content, err := os.ReadFile(source)
+		is.NoErr(err)
+		is.Equal(string(content), "package main\n\n// TODO retry with backoff\n") // the code is never changed
+	})
+
+	t.Run("reports a missing directory", func(t *testing.T) {
+		is := is.New(t)
+		code, _, stderr := run(memory.NewRepository(), "scan", filepath.Join(t.TempDir(), "missing"))
+		is.Equal(code, cli.ExitFailure)
+		is.True(strings.Contains(stderr, "missing"))
+	})
+}
+
+func TestNotes(t *testing.T) {
+	t.Run("syncs the checklists in the notes directory", func(t *testing.T) {
+		is := is.New(t)
+		repo := memory.NewRepository()
+		dir := t.TempDir()
+		is.NoErr(os.WriteFile(filepath.Join(dir, "Inbox.md"), []byte("- [ ] Renew passport ⏫ #admin\n- [x] Book venue\n"), 0o644))
+
+		var out, errOut bytes.Buffer
+		app := cli.New(repo, cli.WithOutput(&out, &errOut), cli.WithNotesDir(dir),
+			cli.WithClock(func() time.Time { return today }))
+		is.Equal(app.Run([]string{"notes"}), cli.ExitOK)
+		is.Equal(out.String(), "Checklist items: 2 found, 1 added, 0 moved, 0 completed, 0 reopened, 0 removed\n")
+
+		_, stdout, _ := run(repo, "list")
+		is.Equal(stdout, "1 (B) 2026-01-20 Renew passport note:Inbox.md:1 +admin\n")
+	})
+
+	t.Run("needs a notes directory", func(t *testing.T) {
+		is := is.New(t)
+		code, _, stderr := run(memory.NewRepository(), "notes")
+		is.Equal(code, cli.ExitFailure)
+		is.True(strings.Contains(stderr, "set notes_dir in the config file"))
+	})
+}
+
+func TestMail(t *testing.T) {
+	mbox := "From alice@example.com Mon Jan 19 09:00:00 2026\n" +
+		"From: Alice <alice@example.com>\nSubject: Contract review\nMessage-ID: <a1@example.com>\nX-Status: F\n\nBody\n\n" +
+		"From bob@example.com Mon Jan 19 10:00:00 2026\n" +
+		"From: bob@example.com\nSubject: Invoice 42\nMessage-ID: <b2@example.com>\nX-Keywords: todo\n\nBody\n\n" +
+		"From carol@example.com Mon Jan 19 11:00:00 2026\n" +
+		"From: carol@example.com\nSubject: Hello\nMessage-ID: <c3@example.com>\n\nBody\n"
+
+	t.Run("imports flagged and labelled messages once", func(t *testing.T) {
+		is := is.New(t)
+		path := filepath.Join(t.TempDir(), "Flagged.mbox")
+		is.NoErr(os.WriteFile(path, []byte(mbox), 0o644))
+		repo := memory.NewRepository()
+
+		code, stdout, _ := run(repo, "mail", "--label", "todo", path)
+		is.Equal(code, cli.ExitOK)
+		is.Equal(stdout, "Imported 2 todos\n")
+
+		_, stdout, _ = run(repo, "list")
+		is.Equal(stdout, "1 (B) 2026-01-20 Contract review msgid:a1%40example.com @alice_example_com\n"+
+			"2 (B) 2026-01-20 Invoice 42 msgid:b2%40example.com @bob_example_com\n")
+
+		_, stdout, _ = run(repo, "mail", "--label", "todo", path)
+		is.Equal(stdout, "Nothing new to import\n")
+	})
+
+	t.Run("needs a mailbox", func(t *testing.T) {
+		is := is.New(t)
+		code, _, stderr := run(memory.NewRepository(), "mail")
+		is.Equal(code, cli.ExitUsage)
+		is.True(strings.Contains(stderr, "Usage: eisenhower mail"))
+	})
+}
+
+func TestServe(t *testing.T) {
+	t.Run("rejects extra arguments", func(t *testing.T) {
+		is := is.New(t)
+		code, _, stderr := run(memory.NewRepository(), "serve", "now")
+		is.Equal(code, cli.ExitUsage)
+		is.True(strings.Contains(stderr, "Usage: eisenhower serve"))
+	})
+
+	t.Run("fails when it can't listen", func(t *testing.T) {
+		is := is.New(t)
+		code, _, stderr := run(memory.NewRepository(), "serve", "--addr", "not-an-address")
+		is.Equal(code, cli.ExitFailure)
+		is.True(strings.Contains(stderr, "not-an-address"))
+	})
+}
+
+func TestSplitFileFlag(t *testing.T) {
+	t.Run("finds --file after the subcommand", func(t *testing.T) {
+		is := is.New(t)
+		for _, args := range [][]string{
+			{"--file", "work.txt", "--quadrant", "do-first"},
+			{"--quadrant", "do-first", "-file=work.txt"},
+			{"--quadrant", "do-first", "--file", "work.txt"},
+		} {
+			file, rest, err := cli.SplitFileFlag(args)
+			is.NoErr(err)
+			is.Equal(file, "work.txt")
+			is.Equal(rest, []string{"--quadrant", "do-first"})
+		}
+	})
+
+	t.Run("leaves other arguments and everything after -- alone", func(t *testing.T) {
+		is := is.New(t)
+		file, rest, err := cli.SplitFileFlag([]string{"Renew passport", "--", "--file", "x"})
+		is.NoErr(err)
+		is.Equal(file, "")
+		is.Equal(rest, []string{"Renew passport", "--", "--file", "x"})
+	})
+
+	t.Run("needs a value", func(t *testing.T) {
+		is := is.New(t)
+		_, _, err := cli.SplitFileFlag([]string{"--file"})
+		is.True(err != nil)
+	})
+}
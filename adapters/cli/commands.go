@@ -0,0 +1,218 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/quii/todo-eisenhower/domain/matrix"
+	"github.com/quii/todo-eisenhower/domain/todotxt"
+	"github.com/quii/todo-eisenhower/usecases"
+)
+
+// add creates a todo; a leading "(A)" priority wins over --quadrant, and without either the todo goes to Eliminate
+func (a *App) add(args []string) error {
+	fs := a.flags("add")
+	quadrantName := fs.String("quadrant", "", "quadrant to add the todo to")
+	args, err := parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+
+	description := joinArgs(args)
+	priority, description, hasPriority := todotxt.SplitPriority(description)
+	if description == "" {
+		return errUsage("missing todo description")
+	}
+	if !hasPriority && *quadrantName != "" {
+		quadrant, err := parseQuadrant(*quadrantName)
+		if err != nil {
+			return err
+		}
+		priority = matrix.PriorityFor(quadrant)
+	}
+
+	m, err := usecases.LoadMatrix(a.repo)
+	if err != nil {
+		return err
+	}
+
+	description = todotxt.ExpandDateShortcuts(description, a.now())
+	m, err = usecases.AddTodo(a.repo, m, description, priority)
+	if err != nil {
+		return err
+	}
+
+	// The new todo is the last in its quadrant
+	quadrant := matrix.QuadrantFor(priority)
+	index := len(m.GetTodosForQuadrant(quadrant)) - 1
+	a.printTodo("Added ", m.Position(quadrant, index), m, quadrant, index)
+	return nil
+}
+
+// list prints todos with their ids, optionally only one quadrant or those matching a filter
+func (a *App) list(args []string) error {
+	fs := a.flags("list")
+	quadrantName := fs.String("quadrant", "", "only list todos in this quadrant")
+	filter := fs.String("filter", "", "only list todos with this +project, @context or source:file")
+	args, err := parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(args) > 0 {
+		return errUsage("unexpected argument %q", args[0])
+	}
+
+	quadrants := []matrix.QuadrantType{
+		matrix.DoFirstQuadrant, matrix.ScheduleQuadrant, matrix.DelegateQuadrant, matrix.EliminateQuadrant, matrix.BacklogQuadrant,
+	}
+	if *quadrantName != "" {
+		quadrant, err := parseQuadrant(*quadrantName)
+		if err != nil {
+			return err
+		}
+		quadrants = []matrix.QuadrantType{quadrant}
+	}
+
+	m, err := usecases.LoadMatrix(a.repo)
+	if err != nil {
+		return err
+	}
+
+	for _, quadrant := range quadrants {
+		for index, t := range m.GetTodosForQuadrant(quadrant) {
+			if *filter != "" && !matrix.MatchesFilter(t, *filter) {
+				continue
+			}
+			a.printTodo("", m.Position(quadrant, index), m, quadrant, index)
+		}
+	}
+	return nil
+}
+
+// done marks a todo as completed
+func (a *App) done(args []string) error {
+	if len(args) != 1 {
+		return errUsage("expected one todo id")
+	}
+
+	m, err := usecases.LoadMatrix(a.repo)
+	if err != nil {
+		return err
+	}
+	quadrant, index, err := locate(m, args[0])
+	if err != nil {
+		return err
+	}
+	if m.GetTodosForQuadrant(quadrant)[index].IsCompleted() {
+		return fmt.Errorf("todo %s is already done", args[0])
+	}
+
+	m, err = usecases.ToggleCompletion(a.repo, m, quadrant, index)
+	if err != nil {
+		return err
+	}
+	a.printTodo("Completed ", m.Position(quadrant, index), m, quadrant, index)
+	return nil
+}
+
+// move moves a todo to another quadrant
+func (a *App) move(args []string) error {
+	if len(args) != 2 {
+		return errUsage("expected a todo id and a quadrant")
+	}
+	target, err := parseQuadrant(args[1])
+	if err != nil {
+		return err
+	}
+
+	m, err := usecases.LoadMatrix(a.repo)
+	if err != nil {
+		return err
+	}
+	quadrant, index, err := locate(m, args[0])
+	if err != nil {
+		return err
+	}
+
+	m, err = a.moveTo(m, quadrant, index, target)
+	if err != nil {
+		return err
+	}
+	index = len(m.GetTodosForQuadrant(target)) - 1
+	a.printTodo(fmt.Sprintf("Moved to %s: ", target.Title()), m.Position(target, index), m, target, index)
+	return nil
+}
+
+// moveTo moves a todo to the target quadrant; moving it to the quadrant it is in leaves it alone
+func (a *App) moveTo(m matrix.Matrix, quadrant matrix.QuadrantType, index int, target matrix.QuadrantType) (matrix.Matrix, error) {
+	if target == quadrant {
+		return m, nil
+	}
+	return usecases.ChangePriority(a.repo, m, quadrant, index, matrix.PriorityFor(target))
+}
+
+// archive moves every completed todo to the archive
+func (a *App) archive(args []string) error {
+	if len(args) > 0 {
+		return errUsage("unexpected argument %q", args[0])
+	}
+
+	m, err := usecases.LoadMatrix(a.repo)
+	if err != nil {
+		return err
+	}
+	before := len(m.AllTodosIncludingBacklog())
+
+	m, err = usecases.ArchiveAllCompleted(a.repo, m)
+	if err != nil {
+		return err
+	}
+
+	archived := before - len(m.AllTodosIncludingBacklog())
+	switch archived {
+	case 0:
+		_, _ = fmt.Fprintln(a.stdout, "No completed todos to archive")
+	case 1:
+		_, _ = fmt.Fprintln(a.stdout, "Archived 1 completed todo")
+	default:
+		_, _ = fmt.Fprintf(a.stdout, "Archived %d completed todos\n", archived)
+	}
+	return nil
+}
+
+// edit replaces a todo's description and tags, keeping its dates; a leading "(B)" also moves it
+func (a *App) edit(args []string) error {
+	if len(args) < 2 {
+		return errUsage("expected a todo id and a description")
+	}
+
+	priority, description, hasPriority := todotxt.SplitPriority(joinArgs(args[1:]))
+	if description == "" {
+		return errUsage("missing todo description")
+	}
+
+	m, err := usecases.LoadMatrix(a.repo)
+	if err != nil {
+		return err
+	}
+	quadrant, index, err := locate(m, args[0])
+	if err != nil {
+		return err
+	}
+
+	description = todotxt.ExpandDateShortcuts(description, a.now())
+	m, err = usecases.EditTodo(a.repo, m, quadrant, index, description)
+	if err != nil {
+		return err
+	}
+
+	if hasPriority && matrix.QuadrantFor(priority) != quadrant {
+		target := matrix.QuadrantFor(priority)
+		if m, err = a.moveTo(m, quadrant, index, target); err != nil {
+			return err
+		}
+		quadrant, index = target, len(m.GetTodosForQuadrant(target))-1
+	}
+
+	a.printTodo("Updated ", m.Position(quadrant, index), m, quadrant, index)
+	return nil
+}
@@ -0,0 +1,72 @@
+package cli
+
+import (
+	"slices"
+
+	"github.com/quii/todo-eisenhower/adapters/jsonexport"
+	"github.com/quii/todo-eisenhower/domain/ics"
+	"github.com/quii/todo-eisenhower/domain/org"
+	"github.com/quii/todo-eisenhower/domain/taskpaper"
+	"github.com/quii/todo-eisenhower/domain/todocsv"
+	"github.com/quii/todo-eisenhower/usecases"
+)
+
+// export writes the whole matrix in a machine-readable format
+func (a *App) export(args []string) error {
+	fs := a.flags("export")
+	mapSpec := fs.String("map", "", "csv columns to write, e.g. Title=description,Urgent=urgent")
+	args, err := parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(args) != 1 {
+		return errUsage("expected a format")
+	}
+
+	format := args[0]
+	if !slices.Contains([]string{"json", "ics", "csv", "org", "taskpaper"}, format) {
+		return errUsage("unknown export format %q (expected json, ics, csv, org or taskpaper)", format)
+	}
+	if *mapSpec != "" && format != "csv" {
+		return errUsage("--map only applies to csv")
+	}
+	mapping, err := parseMapping(*mapSpec)
+	if err != nil {
+		return err
+	}
+
+	m, err := usecases.LoadMatrix(a.repo)
+	if err != nil {
+		return err
+	}
+
+	switch format {
+	case "json":
+		return jsonexport.Write(a.stdout, m, a.stalePolicy, a.now())
+	case "ics":
+		// Calendars know a todo by its UID, so it is kept in the todo before the first export
+		m, err = usecases.KeepCalendarUIDs(a.repo, m)
+		if err != nil {
+			return err
+		}
+		return ics.Marshal(a.stdout, m.AllTodosIncludingBacklog(), a.now())
+	case "org":
+		return org.Marshal(a.stdout, m.AllTodosIncludingBacklog())
+	case "taskpaper":
+		return taskpaper.Marshal(a.stdout, m.AllTodosIncludingBacklog())
+	default:
+		return todocsv.Marshal(a.stdout, m.AllTodosIncludingBacklog(), mapping)
+	}
+}
+
+// parseMapping reads a --map column mapping; an empty spec is the default mapping
+func parseMapping(spec string) (todocsv.Mapping, error) {
+	if spec == "" {
+		return nil, nil
+	}
+	mapping, err := todocsv.ParseMapping(spec)
+	if err != nil {
+		return nil, errUsage("%v", err)
+	}
+	return mapping, nil
+}
@@ -0,0 +1,192 @@
+package cli
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/quii/todo-eisenhower/domain/ics"
+	"github.com/quii/todo-eisenhower/domain/org"
+	"github.com/quii/todo-eisenhower/domain/taskimport"
+	"github.com/quii/todo-eisenhower/domain/taskpaper"
+	"github.com/quii/todo-eisenhower/domain/todo"
+	"github.com/quii/todo-eisenhower/domain/todocsv"
+	"github.com/quii/todo-eisenhower/domain/todotxt"
+	"github.com/quii/todo-eisenhower/usecases"
+)
+
+// importOptions are the settings an importer reads with
+type importOptions struct {
+	now          time.Time
+	set          map[string]bool // the flags given on the command line
+	mapping      todocsv.Mapping
+	important    string
+	urgentWithin int
+	urgentAbove  float64
+}
+
+// rules overrides the quadrant rules given on the command line
+func (o importOptions) rules(rules taskimport.Rules) taskimport.Rules {
+	if o.set["important"] {
+		rules.Important = strings.Split(o.important, ",")
+	}
+	if o.set["urgent-within"] {
+		rules.UrgentWithinDays = o.urgentWithin
+	}
+	if o.set["urgent-above"] {
+		rules.UrgentAbove = o.urgentAbove
+	}
+	return rules
+}
+
+// importer reads todos in another format and says which todos are the same
+type importer struct {
+	read  func(r io.Reader, opts importOptions) ([]todo.Todo, error)
+	key   func(t todo.Todo) string
+	flags []string // the flags that apply to the format
+}
+
+// sameTodo is the key of todos with the same description, tags and due date
+var sameTodo = todotxt.FormatForInput
+
+// ruleFlags choose the quadrants of tasks imported from other task managers
+var ruleFlags = []string{"important", "urgent-within", "urgent-above"}
+
+// importers are the formats import reads, by name
+var importers = map[string]importer{
+	"ics": {
+		read: func(r io.Reader, _ importOptions) ([]todo.Todo, error) { return ics.Unmarshal(r) },
+		key:  ics.UID,
+	},
+	"csv": {
+		read: func(r io.Reader, opts importOptions) ([]todo.Todo, error) {
+			return todocsv.Unmarshal(r, opts.mapping, opts.now)
+		},
+		key:   sameTodo,
+		flags: []string{"map"},
+	},
+	"org": {
+		read: func(r io.Reader, _ importOptions) ([]todo.Todo, error) { return org.Unmarshal(r) },
+		key:  sameTodo,
+	},
+	"taskpaper": {
+		read: func(r io.Reader, _ importOptions) ([]todo.Todo, error) { return taskpaper.Unmarshal(r) },
+		key:  sameTodo,
+	},
+	"taskwarrior": {
+		read: func(r io.Reader, opts importOptions) ([]todo.Todo, error) {
+			return taskimport.Taskwarrior(r, opts.rules(taskimport.DefaultTaskwarriorRules(opts.now)))
+		},
+		key:   taskimport.Key,
+		flags: ruleFlags,
+	},
+	"todoist": {
+		read: func(r io.Reader, opts importOptions) ([]todo.Todo, error) {
+			return taskimport.Todoist(r, opts.rules(taskimport.DefaultTodoistRules(opts.now)))
+		},
+		key:   taskimport.Key,
+		flags: ruleFlags,
+	},
+}
+
+// importFile adds the todos in a file to the matrix, skipping any that were imported before
+func (a *App) importFile(args []string) error {
+	opts := importOptions{now: a.now(), set: make(map[string]bool)}
+	fs := a.flags("import")
+	mapSpec := fs.String("map", "", "csv columns to read, e.g. Title=description,Urgent=urgent")
+	fs.StringVar(&opts.important, "important", "", "priorities that make a task important, e.g. H,M or p1,p2")
+	fs.IntVar(&opts.urgentWithin, "urgent-within", 0, "tasks due within this many days are urgent")
+	fs.Float64Var(&opts.urgentAbove, "urgent-above", 0, "Taskwarrior tasks with at least this urgency are urgent")
+	args, err := parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(args) != 2 {
+		return errUsage("expected a format and a file")
+	}
+	format, ok := importers[args[0]]
+	if !ok {
+		return errUsage("unknown import format %q (expected %s)", args[0], importFormats())
+	}
+
+	var unsupported error
+	fs.Visit(func(f *flag.Flag) {
+		opts.set[f.Name] = true
+		if unsupported == nil && !slices.Contains(format.flags, f.Name) {
+			unsupported = errUsage("--%s doesn't apply to %s", f.Name, args[0])
+		}
+	})
+	if unsupported != nil {
+		return unsupported
+	}
+	if opts.mapping, err = parseMapping(*mapSpec); err != nil {
+		return err
+	}
+
+	todos, err := a.readImport(args[1], format, opts)
+	if err != nil {
+		return err
+	}
+
+	m, err := usecases.LoadMatrix(a.repo)
+	if err != nil {
+		return err
+	}
+	_, added, err := usecases.ImportTodos(a.repo, m, todos, format.key, opts.now)
+	if err != nil {
+		return err
+	}
+	a.printImported(added, len(todos))
+	return nil
+}
+
+// readImport reads todos from a file, or from standard input when the path is "-"
+func (a *App) readImport(path string, format importer, opts importOptions) ([]todo.Todo, error) {
+	if path == "-" {
+		return format.read(a.stdin, opts)
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = f.Close() }()
+
+	todos, err := format.read(f, opts)
+	if err != nil {
+		return nil, fmt.Errorf("reading %s: %w", path, err)
+	}
+	return todos, nil
+}
+
+// printImported reports how many of the todos read were new
+func (a *App) printImported(added, read int) {
+	skipped := read - added
+	switch {
+	case added == 0:
+		_, _ = fmt.Fprintln(a.stdout, "Nothing new to import")
+		return
+	case added == 1:
+		_, _ = fmt.Fprint(a.stdout, "Imported 1 todo")
+	default:
+		_, _ = fmt.Fprintf(a.stdout, "Imported %d todos", added)
+	}
+	if skipped > 0 {
+		_, _ = fmt.Fprintf(a.stdout, " (%d already imported)", skipped)
+	}
+	_, _ = fmt.Fprintln(a.stdout)
+}
+
+// importFormats lists the formats import reads
+func importFormats() string {
+	names := make([]string, 0, len(importers))
+	for name := range importers {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return strings.Join(names, ", ")
+}
@@ -0,0 +1,51 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/quii/todo-eisenhower/adapters/mailbox"
+	"github.com/quii/todo-eisenhower/usecases"
+)
+
+// importMail adds a Schedule todo for each flagged or labelled message in a Maildir or mbox
+func (a *App) importMail(args []string) error {
+	fs := a.flags("mail")
+	labelSpec := fs.String("label", "", "also import messages with one of these labels, e.g. todo,follow-up")
+	args, err := parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(args) != 1 {
+		return errUsage("expected a Maildir directory or mbox file")
+	}
+
+	var labels []string
+	for _, label := range strings.Split(*labelSpec, ",") {
+		if label = strings.TrimSpace(label); label != "" {
+			labels = append(labels, label)
+		}
+	}
+
+	box, err := mailbox.Read(args[0])
+	if err != nil {
+		return fmt.Errorf("reading %s: %w", args[0], err)
+	}
+
+	m, err := usecases.LoadMatrix(a.repo)
+	if err != nil {
+		return err
+	}
+	_, result, err := usecases.ImportMail(a.repo, m, box, labels, a.mailLog, a.now())
+	if err != nil {
+		return err
+	}
+	a.printImported(result.Added, result.Selected)
+	switch {
+	case result.Unreadable == 1:
+		_, _ = fmt.Fprintln(a.stderr, "Skipped 1 message that couldn't be read")
+	case result.Unreadable > 1:
+		_, _ = fmt.Fprintf(a.stderr, "Skipped %d messages that couldn't be read\n", result.Unreadable)
+	}
+	return nil
+}
@@ -0,0 +1,42 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/quii/todo-eisenhower/adapters/notes"
+	"github.com/quii/todo-eisenhower/usecases"
+)
+
+// syncNotes syncs the checklist items in the notes directory with the todo list
+func (a *App) syncNotes(args []string) error {
+	fs := a.flags("notes")
+	args, err := parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(args) > 0 {
+		return errUsage("unexpected argument %q", args[0])
+	}
+	if a.notesDir == "" {
+		return errors.New("no notes directory: set notes_dir in the config file")
+	}
+
+	items, err := notes.Scan(a.notesDir, a.now())
+	if err != nil {
+		return err
+	}
+
+	m, err := usecases.LoadMatrix(a.repo)
+	if err != nil {
+		return err
+	}
+	_, result, err := usecases.SyncNotes(a.repo, m, items, a.now())
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintf(a.stdout, "Checklist items: %d found, %d added, %d moved, %d completed, %d reopened, %d removed\n",
+		len(items), result.Added, result.Moved, result.Completed, result.Reopened, result.Removed)
+	return err
+}
@@ -0,0 +1,50 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/quii/todo-eisenhower/adapters/report"
+	"github.com/quii/todo-eisenhower/usecases"
+)
+
+// report renders the matrix as a Markdown or HTML document for status updates
+func (a *App) report(args []string) error {
+	fs := a.flags("report")
+	formatName := fs.String("format", string(report.Markdown), "markdown or html")
+	templatePath := fs.String("template", "", "render with this template instead of the built-in one")
+	printTemplate := fs.Bool("print-template", false, "print the built-in template for the format, to customise")
+	args, err := parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(args) > 0 {
+		return errUsage("unexpected argument %q", args[0])
+	}
+
+	format, ok := report.ParseFormat(*formatName)
+	if !ok {
+		return errUsage("unknown report format %q (expected markdown or html)", *formatName)
+	}
+	if *printTemplate {
+		_, err := fmt.Fprint(a.stdout, report.DefaultTemplate(format))
+		return err
+	}
+
+	tmpl := ""
+	if *templatePath != "" {
+		content, err := os.ReadFile(*templatePath)
+		if err != nil {
+			return fmt.Errorf("reading template: %w", err)
+		}
+		tmpl = string(content)
+	}
+
+	m, err := usecases.LoadMatrix(a.repo)
+	if err != nil {
+		return err
+	}
+
+	opts := append([]report.Option{report.WithStalePolicy(a.stalePolicy)}, a.reportOptions...)
+	return report.Render(a.stdout, format, tmpl, report.Build(m, a.now(), opts...))
+}
@@ -0,0 +1,54 @@
+package cli
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/quii/todo-eisenhower/adapters/codescan"
+	"github.com/quii/todo-eisenhower/usecases"
+)
+
+// scan turns TODO, FIXME and HACK comments under a directory into +code todos
+func (a *App) scan(args []string) error {
+	fs := a.flags("scan")
+	args, err := parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(args) > 1 {
+		return errUsage("unexpected argument %q", args[1])
+	}
+	root := "."
+	if len(args) == 1 {
+		root = args[0]
+	}
+	// Absolute paths name the same file however the directory was given, so scanning ./repo and
+	// then . from inside it doesn't add every comment twice
+	root, err = filepath.Abs(root)
+	if err != nil {
+		return err
+	}
+
+	comments, unreadable, err := codescan.Scan(root)
+	if err != nil {
+		return err
+	}
+	unread := make([]string, len(unreadable))
+	for i, u := range unreadable {
+		unread[i] = u.Path
+		_, _ = fmt.Fprintf(a.stderr, "Skipped %s: %v\n", u.Path, u.Err)
+	}
+
+	m, err := usecases.LoadMatrix(a.repo)
+	if err != nil {
+		return err
+	}
+	_, result, err := usecases.SyncCodeComments(a.repo, m, comments, filepath.ToSlash(root), unread, a.now())
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintf(a.stdout, "Comments: %d found, %d added, %d moved, %d resolved\n",
+		len(comments), result.Added, result.Moved, result.Resolved)
+	return err
+}
@@ -0,0 +1,80 @@
+package cli
+
+import (
+	"cmp"
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/quii/todo-eisenhower/adapters/httpapi"
+)
+
+// serve runs the REST API until interrupted, so pending changes are flushed when it stops
+// Requests need a bearer token: --token, the one set with WithServeToken, or a new one printed at startup.
+func (a *App) serve(args []string) error {
+	fs := a.flags("serve")
+	addr := fs.String("addr", "127.0.0.1:8080", "address to listen on")
+	token := fs.String("token", "", "bearer token requests must send (EISENHOWER_API_TOKEN, api_token in the config file, or a new one each run)")
+	args, err := parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(args) > 0 {
+		return errUsage("unexpected argument %q", args[0])
+	}
+
+	*token = cmp.Or(*token, a.serveToken)
+	generated := *token == ""
+	if generated {
+		random := make([]byte, 24)
+		if _, err := rand.Read(random); err != nil {
+			return err
+		}
+		*token = base64.RawURLEncoding.EncodeToString(random)
+	}
+
+	listener, err := net.Listen("tcp", *addr)
+	if err != nil {
+		return err
+	}
+	opts := []httpapi.Option{httpapi.WithClock(a.now), httpapi.WithStalePolicy(a.stalePolicy), httpapi.WithToken(*token)}
+	// Loopback hosts are always allowed; a named address is too, so the API can be reached by it
+	if host, _, err := net.SplitHostPort(*addr); err == nil && host != "" {
+		if ip := net.ParseIP(host); ip == nil || !ip.IsUnspecified() {
+			opts = append(opts, httpapi.WithAllowedHosts(host))
+		}
+	}
+	server := &http.Server{
+		Handler:           httpapi.NewServer(a.repo, opts...),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	go func() {
+		<-ctx.Done()
+		shutdown, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		_ = server.Shutdown(shutdown)
+	}()
+
+	_, _ = fmt.Fprintf(a.stdout, "Serving todos on http://%s\n", listener.Addr())
+	// A token the user chose is kept out of the output, which may end up in logs
+	if generated {
+		_, _ = fmt.Fprintf(a.stdout, "Send \"Authorization: Bearer %s\" with each request\n", *token)
+	} else {
+		_, _ = fmt.Fprintln(a.stdout, "Send \"Authorization: Bearer <token>\" with your token with each request")
+	}
+	if err := server.Serve(listener); !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,120 @@
+// Package codescan finds TODO, FIXME and HACK comments in source code.
+//
+// A marker only counts when it follows a comment leader (//, #, /*, *, --, ;, % or <!--), so
+// identifiers and strings that happen to contain the word aren't picked up. Hidden directories,
+// vendored dependencies and binary files are skipped, as are files and directories that can't be
+// read, which are reported alongside the comments.
+package codescan
+
+import (
+	"bufio"
+	"bytes"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/quii/todo-eisenhower/usecases"
+)
+
+// maxFileSize skips generated bundles and data files nobody writes comments in
+const maxFileSize = 1 << 20
+
+// skippedDirs hold code that isn't the project's own
+var skippedDirs = map[string]bool{
+	"node_modules": true,
+	"vendor":       true,
+}
+
+// comment matches a marker after a comment leader, with an optional (owner) and separator
+var comment = regexp.MustCompile(`(?://+|#+|/\*+|\*|--|;+|%+|<!--)\s*(TODO|FIXME|HACK)\b(?:\([^)]*\))?\s*[:\-]?\s*(.*)$`)
+
+// closers end block comments on the same line as the marker
+var closers = regexp.MustCompile(`\s*(?:\*/|-->)\s*$`)
+
+// Unreadable is a file or directory Scan skipped because it couldn't be read
+type Unreadable struct {
+	Path string // slash-separated, like usecases.CodeComment.Path
+	Err  error
+}
+
+// Scan walks root and returns its comments in file then line order, and the files and directories
+// it couldn't read. Paths start with root, as given. Only a root that can't be read is an error.
+func Scan(root string) ([]usecases.CodeComment, []Unreadable, error) {
+	var comments []usecases.CodeComment
+	var unreadable []Unreadable
+	err := filepath.WalkDir(root, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			if path == root {
+				return err
+			}
+			unreadable = append(unreadable, Unreadable{Path: filepath.ToSlash(filepath.Clean(path)), Err: err})
+			if entry != nil && entry.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if entry.IsDir() {
+			if path != root && (strings.HasPrefix(entry.Name(), ".") || skippedDirs[entry.Name()]) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !entry.Type().IsRegular() {
+			return nil
+		}
+
+		found, err := scanFile(path)
+		if err != nil {
+			unreadable = append(unreadable, Unreadable{Path: filepath.ToSlash(filepath.Clean(path)), Err: err})
+			return nil
+		}
+		comments = append(comments, found...)
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return comments, unreadable, nil
+}
+
+func scanFile(path string) ([]usecases.CodeComment, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	if info.Size() > maxFileSize {
+		return nil, nil
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	if isBinary(content) {
+		return nil, nil
+	}
+
+	var comments []usecases.CodeComment
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	scanner.Buffer(make([]byte, 0, 64*1024), maxFileSize)
+	for line := 1; scanner.Scan(); line++ {
+		matches := comment.FindStringSubmatch(scanner.Text())
+		if matches == nil {
+			continue
+		}
+		comments = append(comments, usecases.CodeComment{
+			Path:   filepath.ToSlash(filepath.Clean(path)),
+			Line:   line,
+			Marker: matches[1],
+			Text:   strings.TrimSpace(closers.ReplaceAllString(matches[2], "")),
+		})
+	}
+	return comments, scanner.Err()
+}
+
+// isBinary uses git's rule: a NUL byte in the first 8000 bytes
+func isBinary(content []byte) bool {
+	return bytes.IndexByte(content[:min(len(content), 8000)], 0) >= 0
+}
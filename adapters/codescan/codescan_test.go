@@ -0,0 +1,61 @@
+package codescan_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/matryer/is"
+	"github.com/quii/todo-eisenhower/adapters/codescan"
+	"github.com/quii/todo-eisenhower/usecases"
+)
+
+func TestScan(t *testing.T) {
+	//nolint:gocritic // importShadow: is := is.New(t) is idiomatic for github.com/matryer/is
+	is := is.New(t)
+	root := t.TempDir()
+	write := func(name, content string) {
+		path := filepath.Join(root, name)
+		is.NoErr(os.MkdirAll(filepath.Dir(path), 0o755))
+		is.NoErr(os.WriteFile(path, []byte(content), 0o644))
+	}
+
+	write("main.go", "package main\n\n// TODO: retry with backoff\nfunc main() {\n\tx := 1 // FIXME(chris) off by one\n\ttodoList := \"TODO\"\n}\n")
+	write("scripts/deploy.sh", "#!/bin/sh\n# HACK - sleep until the queue drains\nsleep 10\n")
+	write("web/page.html", "<p>\n<!-- TODO: translate -->\n</p>\n")
+	write("db.sql", "/* FIXME */\nSELECT 1;\n-- TODO index this\n")
+	write(".git/HEAD", "# TODO not ours\n")
+	write("vendor/lib/lib.go", "// TODO not ours either\n")
+	write("logo.png", "\x89PNG\x00\x00// TODO binary\n")
+
+	comments, unreadable, err := codescan.Scan(root)
+	is.NoErr(err)
+	is.Equal(len(unreadable), 0)
+
+	at := func(name string) string { return filepath.ToSlash(filepath.Join(root, name)) }
+	is.Equal(comments, []usecases.CodeComment{
+		{Path: at("db.sql"), Line: 1, Marker: "FIXME", Text: ""},
+		{Path: at("db.sql"), Line: 3, Marker: "TODO", Text: "index this"},
+		{Path: at("main.go"), Line: 3, Marker: "TODO", Text: "retry with backoff"},
+		{Path: at("main.go"), Line: 5, Marker: "FIXME", Text: "off by one"},
+		{Path: at("scripts/deploy.sh"), Line: 2, Marker: "HACK", Text: "sleep until the queue drains"},
+		{Path: at("web/page.html"), Line: 2, Marker: "TODO", Text: "translate"},
+	})
+}
+
+func TestScanSkipsUnreadableFiles(t *testing.T) {
+	//nolint:gocritic // importShadow: is := is.New(t) is idiomatic for github.com/matryer/is
+	is := is.New(t)
+	if os.Geteuid() == 0 {
+		t.Skip("root can read any file")
+	}
+	root := t.TempDir()
+	is.NoErr(os.WriteFile(filepath.Join(root, "main.go"), []byte("// TODO retry\n"), 0o644))
+	is.NoErr(os.WriteFile(filepath.Join(root, "secret.go"), []byte("// TODO rotate keys\n"), 0o000))
+
+	comments, unreadable, err := codescan.Scan(root)
+	is.NoErr(err)
+	is.Equal(len(comments), 1)
+	is.Equal(len(unreadable), 1)
+	is.Equal(unreadable[0].Path, filepath.ToSlash(filepath.Join(root, "secret.go")))
+}
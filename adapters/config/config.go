@@ -0,0 +1,305 @@
+// Package config reads the user's eisenhower settings from ~/.config/eisenhower/config.
+//
+// The file is JSON. Every setting is optional; anything left out keeps its built-in default:
+//
+//	{
+//	  "default_path": "~/Dropbox/todo.txt",
+//	  "project_file": "todo.txt",
+//	  "notes_dir": "~/Notes",
+//	  "char_limit": 300,
+//	  "wip_threshold": 8,
+//	  "stale_after_business_days": {"do_first": 3, "other": 10},
+//	  "trash_retention_days": 60,
+//	  "done_file": "~/Dropbox/done.txt",
+//	  "archive_rotation": "monthly",
+//	  "archive_after_days": 7,
+//	  "archive_on_quit": true,
+//	  "journal": "~/Dropbox/journal.jsonl",
+//	  "git": true,
+//	  "git_pull": true,
+//	  "api_token": "a long random string",
+//	  "quadrants": {
+//	    "do-first": {"title": "Now", "color": "#FF0000"}
+//	  }
+//	}
+//
+// The archive, journal, git and API token settings can also be set for one run with EISENHOWER_* environment
+// variables, which take precedence over the file.
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/quii/todo-eisenhower/adapters/file"
+	"github.com/quii/todo-eisenhower/domain/matrix"
+	"github.com/quii/todo-eisenhower/domain/todo"
+	"github.com/quii/todo-eisenhower/usecases"
+)
+
+// Quadrant is how a quadrant is labelled on screen; empty fields keep the default
+type Quadrant struct {
+	Title string `json:"title,omitempty"`
+	Color string `json:"color,omitempty"`
+}
+
+// StaleThresholds are how many business days a todo can wait before it is highlighted as stale
+type StaleThresholds struct {
+	DoFirst int `json:"do_first,omitempty"` // counted from when the todo was prioritised
+	Other   int `json:"other,omitempty"`    // counted from when the todo was created
+}
+
+// Config holds the user's settings
+type Config struct {
+	File         string              `json:"-"` // the file that was read, empty when there isn't one
+	DefaultPath  string              `json:"default_path,omitempty"`
+	ProjectFile  string              `json:"project_file,omitempty"`
+	NotesDir     string              `json:"notes_dir,omitempty"` // Markdown notes whose checklists are synced
+	CharLimit    int                 `json:"char_limit,omitempty"`
+	WIPThreshold int                 `json:"wip_threshold,omitempty"`
+	Stale        StaleThresholds     `json:"stale_after_business_days"`
+	Quadrants    map[string]Quadrant `json:"quadrants,omitempty"` // keyed by quadrant name, e.g. "do-first"
+	// TrashRetentionDays is how long deleted todos stay in the trash before they are purged
+	TrashRetentionDays int `json:"trash_retention_days,omitempty"`
+
+	DoneFile        string `json:"done_file,omitempty"`        // archive file, instead of done.txt next to todo.txt
+	ArchiveRotation string `json:"archive_rotation,omitempty"` // "none" or "monthly" (done-YYYY-MM.txt files)
+	// ArchiveAfterDays archives todos completed more than this many days ago on startup (0 archives them all)
+	// It is a pointer because 0 is a setting of its own; nil leaves completed todos where they are.
+	ArchiveAfterDays *int   `json:"archive_after_days,omitempty"`
+	ArchiveOnQuit    bool   `json:"archive_on_quit,omitempty"` // archive every completed todo on exit
+	Journal          string `json:"journal,omitempty"`         // journal file, instead of journal.jsonl next to todo.txt, or "off"
+	Git              bool   `json:"git,omitempty"`             // commit every change to the git repository todo.txt is in
+	GitPull          bool   `json:"git_pull,omitempty"`        // pull --rebase before loading todos
+	APIToken         string `json:"api_token,omitempty"`       // bearer token for serve, instead of a new one each run
+}
+
+// JournalOff turns the journal off when used as the journal setting
+const JournalOff = "off"
+
+// Default returns the settings used when there is no config file
+func Default() Config {
+	return Config{
+		CharLimit:    todo.DefaultCharLimit,
+		WIPThreshold: todo.DefaultWIPThreshold,
+		Stale: StaleThresholds{
+			DoFirst: todo.DefaultStalePolicy.DoFirstDays,
+			Other:   todo.DefaultStalePolicy.OtherDays,
+		},
+		TrashRetentionDays: usecases.DefaultTrashRetentionDays,
+		ArchiveRotation:    "none",
+	}
+}
+
+// Path returns where the config file lives
+// EISENHOWER_CONFIG overrides it, otherwise it is eisenhower/config in the XDG config directory
+func Path(home string, getenv func(string) string) string {
+	if path := getenv("EISENHOWER_CONFIG"); path != "" {
+		return expandHome(path, home)
+	}
+
+	xdgConfig := getenv("XDG_CONFIG_HOME")
+	if xdgConfig == "" {
+		xdgConfig = filepath.Join(home, ".config")
+	}
+	return filepath.Join(xdgConfig, "eisenhower", "config")
+}
+
+// Load reads and validates the config file at path
+// A missing file isn't an error: the defaults are returned
+func Load(path, home string) (Config, error) {
+	//nolint:gosec // G304: the config path comes from the user's environment
+	f, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return Default(), nil
+	}
+	if err != nil {
+		return Config{}, err
+	}
+	defer func() {
+		_ = f.Close()
+	}()
+
+	cfg, err := Parse(f)
+	if err != nil {
+		return Config{}, fmt.Errorf("config %s: %w", path, err)
+	}
+	cfg.File = path
+	cfg.DefaultPath = expandHome(cfg.DefaultPath, home)
+	cfg.NotesDir = expandHome(cfg.NotesDir, home)
+	cfg.DoneFile = expandHome(cfg.DoneFile, home)
+	cfg.Journal = expandHome(cfg.Journal, home)
+	return cfg, nil
+}
+
+// Parse reads settings from r over the defaults and validates them
+func Parse(r io.Reader) (Config, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return Config{}, err
+	}
+
+	cfg := Default()
+	if len(bytes.TrimSpace(data)) == 0 {
+		return cfg, nil
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&cfg); err != nil {
+		return Config{}, describeDecodeError(data, err)
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return Config{}, err
+	}
+	return cfg, nil
+}
+
+// Validate checks that every setting has a usable value
+func (c Config) Validate() error {
+	var problems []string
+
+	if c.CharLimit <= 0 {
+		problems = append(problems, fmt.Sprintf("char_limit must be a positive number, got %d", c.CharLimit))
+	}
+	if c.WIPThreshold <= 0 {
+		problems = append(problems, fmt.Sprintf("wip_threshold must be a positive number, got %d", c.WIPThreshold))
+	}
+	if c.Stale.DoFirst <= 0 {
+		problems = append(problems, fmt.Sprintf("stale_after_business_days.do_first must be a positive number, got %d", c.Stale.DoFirst))
+	}
+	if c.Stale.Other <= 0 {
+		problems = append(problems, fmt.Sprintf("stale_after_business_days.other must be a positive number, got %d", c.Stale.Other))
+	}
+	if c.TrashRetentionDays <= 0 {
+		problems = append(problems, fmt.Sprintf("trash_retention_days must be a positive number, got %d", c.TrashRetentionDays))
+	}
+	if _, err := file.ParseArchiveRotation(c.ArchiveRotation); err != nil {
+		problems = append(problems, "archive_rotation: "+err.Error())
+	}
+	if c.ArchiveAfterDays != nil && *c.ArchiveAfterDays < 0 {
+		problems = append(problems, fmt.Sprintf("archive_after_days must be zero or more, got %d", *c.ArchiveAfterDays))
+	}
+	if strings.ContainsRune(c.ProjectFile, filepath.Separator) {
+		problems = append(problems, fmt.Sprintf("project_file must be a file name, not a path: %q", c.ProjectFile))
+	}
+
+	names := make([]string, 0, len(c.Quadrants))
+	for name := range c.Quadrants {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	for _, name := range names {
+		q := c.Quadrants[name]
+		if _, ok := matrix.ParseQuadrant(name); !ok {
+			problems = append(problems, fmt.Sprintf("unknown quadrant %q (expected do-first, schedule, delegate, eliminate or backlog)", name))
+			continue
+		}
+		if q.Color != "" && !hexColor.MatchString(q.Color) {
+			problems = append(problems, fmt.Sprintf("quadrants.%s.color must be a hex color like \"#FF6B6B\", got %q", name, q.Color))
+		}
+	}
+
+	if len(problems) > 0 {
+		return errors.New(strings.Join(problems, "; "))
+	}
+	return nil
+}
+
+// StalePolicy returns the configured stale thresholds as a domain policy
+func (c Config) StalePolicy() todo.StalePolicy {
+	return todo.StalePolicy{DoFirstDays: c.Stale.DoFirst, OtherDays: c.Stale.Other}
+}
+
+// ArchivePolicy returns the configured automatic archiving as a use case policy
+func (c Config) ArchivePolicy() usecases.ArchivePolicy {
+	policy := usecases.ArchivePolicy{OnQuit: c.ArchiveOnQuit}
+	if c.ArchiveAfterDays != nil {
+		policy.OnStartup = true
+		policy.OlderThanDays = *c.ArchiveAfterDays
+	}
+	return policy
+}
+
+// QuadrantStyles returns the configured quadrant titles and colors keyed by quadrant
+func (c Config) QuadrantStyles() map[matrix.QuadrantType]Quadrant {
+	styles := make(map[matrix.QuadrantType]Quadrant, len(c.Quadrants))
+	for name, q := range c.Quadrants {
+		if quadrant, ok := matrix.ParseQuadrant(name); ok {
+			styles[quadrant] = q
+		}
+	}
+	return styles
+}
+
+// hexColor matches colors like #F00 and #FF6B6B
+var hexColor = regexp.MustCompile(`^#([0-9A-Fa-f]{3}|[0-9A-Fa-f]{6})$`)
+
+// describeDecodeError turns JSON decoding errors into messages that point at the problem
+func describeDecodeError(data []byte, err error) error {
+	var syntaxErr *json.SyntaxError
+	if errors.As(err, &syntaxErr) {
+		line, col := position(data, syntaxErr.Offset)
+		return fmt.Errorf("invalid JSON at line %d, column %d: %w", line, col, err)
+	}
+
+	var typeErr *json.UnmarshalTypeError
+	if errors.As(err, &typeErr) {
+		line, _ := position(data, typeErr.Offset)
+		return fmt.Errorf("%s must be %s, got a JSON %s (line %d)", typeErr.Field, describeKind(typeErr.Type.Kind()), typeErr.Value, line)
+	}
+
+	if errors.Is(err, io.ErrUnexpectedEOF) {
+		return errors.New("invalid JSON: the file ends before the settings are closed")
+	}
+
+	// DisallowUnknownFields reports: json: unknown field "name"
+	if field, ok := strings.CutPrefix(err.Error(), "json: unknown field "); ok {
+		return fmt.Errorf("unknown setting %s", field)
+	}
+	return err
+}
+
+// describeKind names the JSON value a Go kind is read from
+func describeKind(kind reflect.Kind) string {
+	switch kind {
+	case reflect.Int:
+		return "a whole number"
+	case reflect.String:
+		return "text"
+	case reflect.Bool:
+		return "true or false"
+	default:
+		return "an object"
+	}
+}
+
+// position converts a byte offset into a 1-based line and column
+func position(data []byte, offset int64) (line, col int) {
+	offset = min(offset, int64(len(data)))
+	before := data[:offset]
+	line = bytes.Count(before, []byte("\n")) + 1
+	col = int(offset) - bytes.LastIndexByte(before, '\n')
+	return line, col
+}
+
+// expandHome replaces a leading ~ with the home directory
+func expandHome(path, home string) string {
+	if path == "~" {
+		return home
+	}
+	if strings.HasPrefix(path, "~/") {
+		return filepath.Join(home, path[2:])
+	}
+	return path
+}
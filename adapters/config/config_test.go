@@ -0,0 +1,170 @@
+package config_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/matryer/is"
+	"github.com/quii/todo-eisenhower/adapters/config"
+	"github.com/quii/todo-eisenhower/domain/matrix"
+	"github.com/quii/todo-eisenhower/domain/todo"
+	"github.com/quii/todo-eisenhower/usecases"
+)
+
+func TestParse(t *testing.T) {
+	t.Run("settings left out keep their defaults", func(t *testing.T) {
+		//nolint:gocritic // importShadow: is := is.New(t) is idiomatic for github.com/matryer/is
+		is := is.New(t)
+
+		cfg, err := config.Parse(strings.NewReader(`{
+			"wip_threshold": 8,
+			"stale_after_business_days": {"other": 10},
+			"quadrants": {"do-first": {"title": "Now"}}
+		}`))
+		is.NoErr(err)
+
+		is.Equal(cfg.WIPThreshold, 8)
+		is.Equal(cfg.CharLimit, 200)
+		is.Equal(cfg.StalePolicy(), todo.StalePolicy{DoFirstDays: 2, OtherDays: 10})
+		is.Equal(cfg.QuadrantStyles()[matrix.DoFirstQuadrant], config.Quadrant{Title: "Now"})
+		is.Equal(cfg.TrashRetentionDays, 30)
+	})
+
+	t.Run("reads the trash retention", func(t *testing.T) {
+		is := is.New(t)
+		cfg, err := config.Parse(strings.NewReader(`{"trash_retention_days": 60}`))
+		is.NoErr(err)
+		is.Equal(cfg.TrashRetentionDays, 60)
+	})
+
+	t.Run("reads the archive, journal and git settings", func(t *testing.T) {
+		is := is.New(t)
+		cfg, err := config.Parse(strings.NewReader(`{
+			"done_file": "archive/done.txt",
+			"archive_rotation": "monthly",
+			"archive_after_days": 0,
+			"archive_on_quit": true,
+			"journal": "off",
+			"git": true,
+			"git_pull": true,
+			"api_token": "s3cret"
+		}`))
+		is.NoErr(err)
+
+		is.Equal(cfg.DoneFile, "archive/done.txt")
+		is.Equal(cfg.ArchiveRotation, "monthly")
+		is.Equal(cfg.ArchivePolicy(), usecases.ArchivePolicy{OnStartup: true, OlderThanDays: 0, OnQuit: true})
+		is.Equal(cfg.Journal, config.JournalOff)
+		is.True(cfg.Git)
+		is.True(cfg.GitPull)
+		is.Equal(cfg.APIToken, "s3cret")
+	})
+
+	t.Run("completed todos stay put unless archiving is configured", func(t *testing.T) {
+		is := is.New(t)
+		is.Equal(config.Default().ArchivePolicy(), usecases.ArchivePolicy{})
+	})
+
+	t.Run("an empty file is the defaults", func(t *testing.T) {
+		is := is.New(t)
+		cfg, err := config.Parse(strings.NewReader("\n"))
+		is.NoErr(err)
+		is.Equal(cfg, config.Default())
+	})
+
+	t.Run("reports where the JSON is broken", func(t *testing.T) {
+		is := is.New(t)
+		_, err := config.Parse(strings.NewReader("{\n  \"char_limit\": 300,\n  \"wip_threshold\" 8\n}"))
+		is.True(err != nil)
+		is.True(strings.Contains(err.Error(), "line 3")) // points at the missing colon
+	})
+
+	t.Run("rejects unknown settings", func(t *testing.T) {
+		is := is.New(t)
+		_, err := config.Parse(strings.NewReader(`{"wip_treshold": 8}`))
+		is.True(err != nil)
+		is.Equal(err.Error(), `unknown setting "wip_treshold"`)
+	})
+
+	t.Run("rejects settings of the wrong type", func(t *testing.T) {
+		is := is.New(t)
+		_, err := config.Parse(strings.NewReader(`{"char_limit": "lots"}`))
+		is.True(err != nil)
+		is.True(strings.Contains(err.Error(), "char_limit must be a whole number"))
+
+		_, err = config.Parse(strings.NewReader(`{"git": "yes"}`))
+		is.True(err != nil)
+		is.True(strings.Contains(err.Error(), "git must be true or false"))
+	})
+
+	t.Run("validates every setting", func(t *testing.T) {
+		is := is.New(t)
+		_, err := config.Parse(strings.NewReader(`{
+			"char_limit": 0,
+			"stale_after_business_days": {"do_first": -1},
+			"trash_retention_days": -7,
+			"archive_rotation": "weekly",
+			"archive_after_days": -1,
+			"quadrants": {"someday": {}, "schedule": {"color": "teal"}}
+		}`))
+		is.True(err != nil)
+
+		message := err.Error()
+		is.True(strings.Contains(message, "char_limit must be a positive number"))
+		is.True(strings.Contains(message, "stale_after_business_days.do_first must be a positive number"))
+		is.True(strings.Contains(message, "trash_retention_days must be a positive number"))
+		is.True(strings.Contains(message, `archive_rotation: unknown archive rotation "weekly"`))
+		is.True(strings.Contains(message, "archive_after_days must be zero or more"))
+		is.True(strings.Contains(message, `unknown quadrant "someday"`))
+		is.True(strings.Contains(message, `quadrants.schedule.color must be a hex color`))
+	})
+}
+
+func TestLoad(t *testing.T) {
+	t.Run("a missing file is the defaults", func(t *testing.T) {
+		is := is.New(t)
+		cfg, err := config.Load(filepath.Join(t.TempDir(), "config"), "/home/alice")
+		is.NoErr(err)
+		is.Equal(cfg, config.Default())
+	})
+
+	t.Run("expands ~ in paths and names the file in errors", func(t *testing.T) {
+		is := is.New(t)
+		path := filepath.Join(t.TempDir(), "config")
+		is.NoErr(os.WriteFile(path, []byte(`{
+			"default_path": "~/Dropbox/todo.txt",
+			"notes_dir": "~/Notes",
+			"done_file": "~/Dropbox/done.txt",
+			"journal": "~/Dropbox/journal.jsonl"
+		}`), 0o600))
+
+		cfg, err := config.Load(path, "/home/alice")
+		is.NoErr(err)
+		is.Equal(cfg.DefaultPath, "/home/alice/Dropbox/todo.txt")
+		is.Equal(cfg.NotesDir, "/home/alice/Notes")
+		is.Equal(cfg.DoneFile, "/home/alice/Dropbox/done.txt")
+		is.Equal(cfg.Journal, "/home/alice/Dropbox/journal.jsonl")
+		is.Equal(cfg.File, path)
+
+		is.NoErr(os.WriteFile(path, []byte(`{"char_limit": -5}`), 0o600))
+		_, err = config.Load(path, "/home/alice")
+		is.True(err != nil)
+		is.True(strings.Contains(err.Error(), path))
+	})
+}
+
+func TestPath(t *testing.T) {
+	is := is.New(t)
+	env := map[string]string{}
+	getenv := func(name string) string { return env[name] }
+
+	is.Equal(config.Path("/home/alice", getenv), "/home/alice/.config/eisenhower/config")
+
+	env["XDG_CONFIG_HOME"] = "/xdg"
+	is.Equal(config.Path("/home/alice", getenv), "/xdg/eisenhower/config")
+
+	env["EISENHOWER_CONFIG"] = "~/eisenhower.json"
+	is.Equal(config.Path("/home/alice", getenv), "/home/alice/eisenhower.json")
+}
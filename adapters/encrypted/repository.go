@@ -0,0 +1,308 @@
+// Package encrypted provides a TodoRepository that keeps todo.txt files encrypted at rest.
+//
+// Files are encrypted with AES-256-GCM using a key derived from a passphrase with PBKDF2-SHA256.
+// Each file is self-contained: a header with the format version, iteration count and salt,
+// followed by the nonce and the sealed todo.txt contents.
+package encrypted
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/pbkdf2"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/quii/todo-eisenhower/domain/todo"
+	"github.com/quii/todo-eisenhower/domain/todotxt"
+)
+
+// Extension marks a todo file as encrypted (todo.txt.enc)
+const Extension = ".enc"
+
+// DefaultKeyIterations is the PBKDF2 iteration count used for newly written files
+const DefaultKeyIterations = 600_000
+
+// MinKeyIterations and MaxKeyIterations bound the iteration count in a file's header, so a damaged
+// or tampered file can't make the key weak or take hours to derive
+const (
+	MinKeyIterations = 100_000
+	MaxKeyIterations = 10_000_000
+)
+
+const (
+	magic     = "EISENHOWER-ENC1"
+	saltSize  = 16
+	keySize   = 32
+	headerLen = len(magic) + 4 + saltSize
+)
+
+// ErrWrongPassphrase is returned when a file can't be decrypted with the passphrase
+var ErrWrongPassphrase = errors.New("wrong passphrase, or the file is damaged")
+
+// Repository is an encrypted, file-based implementation of TodoRepository
+// The archive and trash are encrypted too (done.txt.enc and deleted.txt.enc next to the todo file)
+type Repository struct {
+	path       string
+	passphrase string
+	iterations int
+
+	mu   sync.Mutex
+	salt []byte            // salt for files written by this repository
+	keys map[string][]byte // derived keys by salt and iteration count
+}
+
+// Option configures optional behaviour of an encrypted Repository
+type Option func(*Repository)
+
+// WithKeyIterations sets the PBKDF2 iteration count for newly written files, between
+// MinKeyIterations and MaxKeyIterations
+// Existing files are always read with the count stored in their header
+func WithKeyIterations(iterations int) Option {
+	return func(r *Repository) {
+		r.iterations = iterations
+	}
+}
+
+// IsEncryptedPath returns true if the path names an encrypted todo file
+func IsEncryptedPath(path string) bool {
+	return strings.HasSuffix(path, Extension)
+}
+
+// NewRepository creates a repository for the encrypted todo file at path
+func NewRepository(path, passphrase string, opts ...Option) *Repository {
+	r := &Repository{
+		path:       path,
+		passphrase: passphrase,
+		iterations: DefaultKeyIterations,
+		keys:       make(map[string][]byte),
+	}
+	for _, opt := range opts {
+		opt(r)
+	}
+	return r
+}
+
+// LoadAll decrypts and reads todos from the file
+// A missing file is treated as an empty todo list
+func (r *Repository) LoadAll() ([]todo.Todo, error) {
+	return r.readTodos(r.path)
+}
+
+// SaveAll encrypts and writes todos to the file (full rewrite)
+func (r *Repository) SaveAll(todos []todo.Todo) error {
+	return r.writeTodos(r.path, todos)
+}
+
+// AppendToArchive adds a todo to the encrypted archive
+func (r *Repository) AppendToArchive(t todo.Todo) error {
+	return r.appendTodo(r.sidecarPath("done.txt"), t)
+}
+
+// LoadArchive decrypts and reads the archive
+func (r *Repository) LoadArchive() ([]todo.Todo, error) {
+	return r.readTodos(r.sidecarPath("done.txt"))
+}
+
+// SaveArchive encrypts and writes the archive (full rewrite)
+func (r *Repository) SaveArchive(todos []todo.Todo) error {
+	return r.writeTodos(r.sidecarPath("done.txt"), todos)
+}
+
+// AppendToTrash adds a deleted todo to the encrypted trash
+func (r *Repository) AppendToTrash(t todo.Todo) error {
+	return r.appendTodo(r.sidecarPath("deleted.txt"), t)
+}
+
+// LoadTrash decrypts and reads the trash
+func (r *Repository) LoadTrash() ([]todo.Todo, error) {
+	return r.readTodos(r.sidecarPath("deleted.txt"))
+}
+
+// SaveTrash encrypts and writes the trash (full rewrite)
+func (r *Repository) SaveTrash(todos []todo.Todo) error {
+	return r.writeTodos(r.sidecarPath("deleted.txt"), todos)
+}
+
+// Files returns the encrypted files the repository reads and writes
+func (r *Repository) Files() []string {
+	return []string{r.path, r.sidecarPath("done.txt"), r.sidecarPath("deleted.txt")}
+}
+
+// sidecarPath returns the encrypted path of a file that lives next to the todo file
+func (r *Repository) sidecarPath(name string) string {
+	return filepath.Join(filepath.Dir(r.path), name+Extension)
+}
+
+// appendTodo adds a todo to an encrypted file
+// Encrypted files can't be appended to in place, so the whole file is rewritten
+func (r *Repository) appendTodo(path string, t todo.Todo) error {
+	todos, err := r.readTodos(path)
+	if err != nil {
+		return err
+	}
+	return r.writeTodos(path, append(todos, t))
+}
+
+// readTodos decrypts a file and parses its todos
+func (r *Repository) readTodos(path string) ([]todo.Todo, error) {
+	//nolint:gosec // G304: the path comes from the todo file chosen by the user
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return []todo.Todo{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	plaintext, err := r.decrypt(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", filepath.Base(path), err)
+	}
+	return todotxt.Unmarshal(bytes.NewReader(plaintext))
+}
+
+// writeTodos encrypts todos and replaces the file atomically
+func (r *Repository) writeTodos(path string, todos []todo.Todo) error {
+	var buf bytes.Buffer
+	if err := todotxt.Marshal(&buf, todos); err != nil {
+		return err
+	}
+
+	sealed, err := r.encrypt(buf.Bytes())
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".*")
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = os.Remove(tmp.Name()) // no-op once renamed
+	}()
+
+	if _, err := tmp.Write(sealed); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// encrypt seals plaintext with a fresh nonce, prefixed by the header needed to decrypt it
+func (r *Repository) encrypt(plaintext []byte) ([]byte, error) {
+	if r.iterations < MinKeyIterations || r.iterations > MaxKeyIterations {
+		return nil, fmt.Errorf("key iterations must be between %d and %d", MinKeyIterations, MaxKeyIterations)
+	}
+	salt, err := r.writeSalt()
+	if err != nil {
+		return nil, err
+	}
+
+	header := make([]byte, 0, headerLen)
+	header = append(header, magic...)
+	header = binary.BigEndian.AppendUint32(header, uint32(r.iterations)) //nolint:gosec // G115: iteration counts are small positive numbers
+	header = append(header, salt...)
+
+	aead, err := r.cipher(salt, r.iterations)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+
+	out := append(header, nonce...)
+	return aead.Seal(out, nonce, plaintext, header), nil
+}
+
+// decrypt checks the header and opens the sealed contents
+func (r *Repository) decrypt(data []byte) ([]byte, error) {
+	if len(data) < headerLen || string(data[:len(magic)]) != magic {
+		return nil, errors.New("not an encrypted todo file")
+	}
+
+	header := data[:headerLen]
+	iterations := int(binary.BigEndian.Uint32(data[len(magic):]))
+	if iterations < MinKeyIterations || iterations > MaxKeyIterations {
+		return nil, errors.New("not an encrypted todo file")
+	}
+	salt := data[len(magic)+4 : headerLen]
+
+	aead, err := r.cipher(salt, iterations)
+	if err != nil {
+		return nil, err
+	}
+
+	rest := data[headerLen:]
+	if len(rest) < aead.NonceSize() {
+		return nil, ErrWrongPassphrase
+	}
+
+	plaintext, err := aead.Open(nil, rest[:aead.NonceSize()], rest[aead.NonceSize():], header)
+	if err != nil {
+		return nil, ErrWrongPassphrase
+	}
+	return plaintext, nil
+}
+
+// cipher returns AES-GCM keyed from the passphrase, deriving (and caching) the key for the salt
+func (r *Repository) cipher(salt []byte, iterations int) (cipher.AEAD, error) {
+	r.mu.Lock()
+	cacheKey := fmt.Sprintf("%x/%d", salt, iterations)
+	key, ok := r.keys[cacheKey]
+	r.mu.Unlock()
+
+	if !ok {
+		var err error
+		key, err = pbkdf2.Key(sha256.New, r.passphrase, salt, iterations, keySize)
+		if err != nil {
+			return nil, err
+		}
+		r.mu.Lock()
+		r.keys[cacheKey] = key
+		r.mu.Unlock()
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// writeSalt returns the salt for new writes: the todo file's existing salt, or a fresh random one
+func (r *Repository) writeSalt() ([]byte, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.salt != nil {
+		return r.salt, nil
+	}
+
+	//nolint:gosec // G304: the path comes from the todo file chosen by the user
+	if data, err := os.ReadFile(r.path); err == nil && len(data) >= headerLen && string(data[:len(magic)]) == magic &&
+		int(binary.BigEndian.Uint32(data[len(magic):])) == r.iterations {
+		r.salt = append([]byte{}, data[len(magic)+4:headerLen]...)
+		return r.salt, nil
+	}
+
+	r.salt = make([]byte, saltSize)
+	if _, err := rand.Read(r.salt); err != nil {
+		r.salt = nil
+		return nil, err
+	}
+	return r.salt, nil
+}
@@ -0,0 +1,115 @@
+package encrypted_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/matryer/is"
+	"github.com/quii/todo-eisenhower/adapters/encrypted"
+	"github.com/quii/todo-eisenhower/domain/todo"
+)
+
+// fastKeys keeps key derivation as cheap as the repository allows in tests
+var fastKeys = encrypted.WithKeyIterations(encrypted.MinKeyIterations)
+
+func TestRepository(t *testing.T) {
+	t.Run("round trips todos without writing plaintext", func(t *testing.T) {
+		//nolint:gocritic // importShadow: is := is.New(t) is idiomatic for github.com/matryer/is
+		is := is.New(t)
+		path := filepath.Join(t.TempDir(), "todo.txt.enc")
+		repo := encrypted.NewRepository(path, "correct horse", fastKeys)
+
+		err := repo.SaveAll([]todo.Todo{todo.NewWithTags("Deploy API", todo.PriorityA, []string{"ops"}, nil)})
+		is.NoErr(err)
+
+		data, err := os.ReadFile(path)
+		is.NoErr(err)
+		is.True(!strings.Contains(string(data), "Deploy API"))
+
+		reopened := encrypted.NewRepository(path, "correct horse", fastKeys)
+		todos, err := reopened.LoadAll()
+		is.NoErr(err)
+		is.Equal(len(todos), 1)
+		is.Equal(todos[0].Description(), "Deploy API")
+		is.Equal(todos[0].Projects(), []string{"ops"})
+	})
+
+	t.Run("rejects the wrong passphrase", func(t *testing.T) {
+		is := is.New(t)
+		path := filepath.Join(t.TempDir(), "todo.txt.enc")
+		is.NoErr(encrypted.NewRepository(path, "correct horse", fastKeys).SaveAll([]todo.Todo{todo.New("Secret", todo.PriorityA)}))
+
+		_, err := encrypted.NewRepository(path, "battery staple", fastKeys).LoadAll()
+		is.True(errors.Is(err, encrypted.ErrWrongPassphrase))
+	})
+
+	t.Run("rejects files that are not encrypted", func(t *testing.T) {
+		is := is.New(t)
+		path := filepath.Join(t.TempDir(), "todo.txt.enc")
+		//nolint:gosec // G306: test file permissions intentionally match production (0o644)
+		is.NoErr(os.WriteFile(path, []byte("(A) Plain todo\n"), 0o644))
+
+		_, err := encrypted.NewRepository(path, "correct horse", fastKeys).LoadAll()
+		is.True(err != nil)
+	})
+
+	t.Run("rejects iteration counts out of bounds without deriving a key", func(t *testing.T) {
+		is := is.New(t)
+		path := filepath.Join(t.TempDir(), "todo.txt.enc")
+		is.NoErr(encrypted.NewRepository(path, "correct horse", fastKeys).SaveAll([]todo.Todo{todo.New("Secret", todo.PriorityA)}))
+
+		data, err := os.ReadFile(path)
+		is.NoErr(err)
+		copy(data[len("EISENHOWER-ENC1"):], []byte{0xff, 0xff, 0xff, 0xff}) // four billion iterations
+		is.NoErr(os.WriteFile(path, data, 0o600))
+
+		_, err = encrypted.NewRepository(path, "correct horse", fastKeys).LoadAll()
+		is.True(err != nil)
+		is.True(strings.Contains(err.Error(), "not an encrypted todo file"))
+	})
+
+	t.Run("won't write with an iteration count it couldn't read back", func(t *testing.T) {
+		is := is.New(t)
+		path := filepath.Join(t.TempDir(), "todo.txt.enc")
+		err := encrypted.NewRepository(path, "correct horse", encrypted.WithKeyIterations(1000)).SaveAll([]todo.Todo{todo.New("Secret", todo.PriorityA)})
+		is.True(err != nil)
+	})
+
+	t.Run("missing file loads as empty", func(t *testing.T) {
+		is := is.New(t)
+		repo := encrypted.NewRepository(filepath.Join(t.TempDir(), "todo.txt.enc"), "correct horse", fastKeys)
+
+		todos, err := repo.LoadAll()
+		is.NoErr(err)
+		is.Equal(len(todos), 0)
+	})
+
+	t.Run("encrypts the archive and trash next to the todo file", func(t *testing.T) {
+		is := is.New(t)
+		dir := t.TempDir()
+		repo := encrypted.NewRepository(filepath.Join(dir, "todo.txt.enc"), "correct horse", fastKeys)
+
+		is.NoErr(repo.AppendToArchive(todo.NewCompleted("Shipped", todo.PriorityA, nil)))
+		is.NoErr(repo.AppendToTrash(todo.New("Dropped", todo.PriorityD)))
+
+		for _, name := range []string{"done.txt.enc", "deleted.txt.enc"} {
+			data, err := os.ReadFile(filepath.Join(dir, name))
+			is.NoErr(err)
+			is.True(!strings.Contains(string(data), "Shipped"))
+			is.True(!strings.Contains(string(data), "Dropped"))
+		}
+
+		archived, err := repo.LoadArchive()
+		is.NoErr(err)
+		is.Equal(archived[0].Description(), "Shipped")
+	})
+
+	t.Run("recognises encrypted paths", func(t *testing.T) {
+		is := is.New(t)
+		is.True(encrypted.IsEncryptedPath("todo.txt.enc"))
+		is.True(!encrypted.IsEncryptedPath("todo.txt"))
+	})
+}
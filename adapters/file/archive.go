@@ -0,0 +1,223 @@
+package file
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/quii/todo-eisenhower/domain/todo"
+	"github.com/quii/todo-eisenhower/domain/todotxt"
+)
+
+// ArchiveRotation controls how archived todos are split across files
+type ArchiveRotation int
+
+const (
+	// NoRotation keeps every archived todo in a single done.txt
+	NoRotation ArchiveRotation = iota
+	// MonthlyRotation writes one file per completion month (done-2026-10.txt)
+	MonthlyRotation
+)
+
+// ParseArchiveRotation parses a rotation name ("none" or "monthly")
+func ParseArchiveRotation(s string) (ArchiveRotation, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "", "none":
+		return NoRotation, nil
+	case "monthly":
+		return MonthlyRotation, nil
+	default:
+		return NoRotation, fmt.Errorf("unknown archive rotation %q (expected \"none\" or \"monthly\")", s)
+	}
+}
+
+// WithArchivePath stores archived todos at path instead of done.txt next to todo.txt
+// Relative paths are resolved against the directory containing todo.txt
+func WithArchivePath(path string) Option {
+	return func(r *Repository) {
+		r.archiveFile = path
+	}
+}
+
+// WithArchiveRotation splits the archive across dated files
+func WithArchiveRotation(rotation ArchiveRotation) Option {
+	return func(r *Repository) {
+		r.archiveRotation = rotation
+	}
+}
+
+// rotatedMonthPattern matches the -YYYY-MM suffix of a rotated archive file
+var rotatedMonthPattern = regexp.MustCompile(`^-\d{4}-\d{2}$`)
+
+// AppendToArchive appends a todo to the archive file (done.txt)
+// With monthly rotation the todo goes to the file for its completion month
+func (r *Repository) AppendToArchive(t todo.Todo) error {
+	// Open file in append mode, create if doesn't exist
+	//nolint:gosec // G302,G304: done.txt files are intentionally world-readable (0o644 per todo.txt spec)
+	f, err := os.OpenFile(r.archiveFileFor(t), os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = f.Close()
+	}()
+
+	// Write the todo as a single line
+	return todotxt.Marshal(f, []todo.Todo{t})
+}
+
+// LoadArchive reads archived todos from every archive file as one logical archive
+// The main archive file comes first, followed by rotated files oldest month first
+// A missing archive file simply means nothing has been archived yet
+func (r *Repository) LoadArchive() ([]todo.Todo, error) {
+	paths, err := r.existingArchiveFiles()
+	if err != nil {
+		return nil, err
+	}
+
+	archived := make([]todo.Todo, 0)
+	for _, path := range paths {
+		todos, err := readTodos(path)
+		if err != nil {
+			return nil, err
+		}
+		archived = append(archived, todos...)
+	}
+
+	return archived, nil
+}
+
+// SaveArchive writes archived todos back to the archive (full rewrite)
+// Each todo is written to the file AppendToArchive would choose for it,
+// and archive files left with nothing in them are emptied (rotated ones are removed)
+func (r *Repository) SaveArchive(todos []todo.Todo) error {
+	existing, err := r.existingArchiveFiles()
+	if err != nil {
+		return err
+	}
+
+	grouped := make(map[string][]todo.Todo)
+	for _, t := range todos {
+		path := r.archiveFileFor(t)
+		grouped[path] = append(grouped[path], t)
+	}
+
+	for _, path := range existing {
+		if _, ok := grouped[path]; ok {
+			continue
+		}
+		if path == r.archivePath() {
+			grouped[path] = nil // keep the main archive file, just empty it
+			continue
+		}
+		if err := os.Remove(path); err != nil {
+			return err
+		}
+	}
+
+	for path, group := range grouped {
+		if err := writeTodos(path, group); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// archivePath returns the path to the main archive file
+// Following todo.txt convention, this defaults to done.txt in the same directory as todo.txt
+func (r *Repository) archivePath() string {
+	dir := filepath.Dir(r.path)
+	if r.archiveFile == "" {
+		return filepath.Join(dir, "done.txt")
+	}
+	if filepath.IsAbs(r.archiveFile) {
+		return r.archiveFile
+	}
+	return filepath.Join(dir, r.archiveFile)
+}
+
+// archiveFileFor returns the archive file a todo belongs in
+// With monthly rotation, done.txt becomes done-YYYY-MM.txt for the todo's completion month
+func (r *Repository) archiveFileFor(t todo.Todo) string {
+	if r.archiveRotation != MonthlyRotation {
+		return r.archivePath()
+	}
+
+	month := time.Now()
+	if completed := t.CompletionDate(); completed != nil {
+		month = *completed
+	}
+
+	base := r.archivePath()
+	ext := filepath.Ext(base)
+	return strings.TrimSuffix(base, ext) + "-" + month.Format("2006-01") + ext
+}
+
+// existingArchiveFiles returns the main archive file and any rotated files that exist on disk
+// Rotated files are always included so history survives switching rotation on or off
+func (r *Repository) existingArchiveFiles() ([]string, error) {
+	base := r.archivePath()
+	ext := filepath.Ext(base)
+	stem := strings.TrimSuffix(base, ext)
+
+	var paths []string
+	if _, err := os.Stat(base); err == nil {
+		paths = append(paths, base)
+	} else if !os.IsNotExist(err) {
+		return nil, err
+	}
+
+	rotated, err := filepath.Glob(globEscape(stem) + "-*" + globEscape(ext))
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(rotated) // YYYY-MM sorts chronologically
+
+	for _, path := range rotated {
+		suffix := strings.TrimSuffix(strings.TrimPrefix(path, stem), ext)
+		if rotatedMonthPattern.MatchString(suffix) {
+			paths = append(paths, path)
+		}
+	}
+
+	return paths, nil
+}
+
+// globEscape escapes glob metacharacters so a literal path can be used in a pattern
+func globEscape(path string) string {
+	replacer := strings.NewReplacer(`*`, `\*`, `?`, `\?`, `[`, `\[`, `\`, `\\`)
+	return replacer.Replace(path)
+}
+
+// readTodos reads todos from a todo.txt formatted file
+func readTodos(path string) ([]todo.Todo, error) {
+	//nolint:gosec // G304: archive paths come from the user's own configuration
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = f.Close()
+	}()
+
+	return todotxt.Unmarshal(f)
+}
+
+// writeTodos writes todos to a todo.txt formatted file (full rewrite)
+func writeTodos(path string, todos []todo.Todo) error {
+	//nolint:gosec // G302,G304: done.txt files are intentionally world-readable (0o644 per todo.txt spec)
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = f.Close()
+	}()
+
+	return todotxt.Marshal(f, todos)
+}
@@ -0,0 +1,145 @@
+package file_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/matryer/is"
+	"github.com/quii/todo-eisenhower/adapters/file"
+	"github.com/quii/todo-eisenhower/domain/todo"
+)
+
+func TestRepository_Archive(t *testing.T) {
+	october := time.Date(2026, 10, 3, 0, 0, 0, 0, time.UTC)
+	november := time.Date(2026, 11, 12, 0, 0, 0, 0, time.UTC)
+
+	t.Run("archives to done.txt next to todo.txt by default", func(t *testing.T) {
+		//nolint:gocritic // importShadow: is := is.New(t) is idiomatic for github.com/matryer/is
+		is := is.New(t)
+		tmpDir := t.TempDir()
+
+		repo := file.NewRepository(filepath.Join(tmpDir, "todo.txt"))
+		err := repo.AppendToArchive(todo.NewCompleted("Done", todo.PriorityA, &october))
+		is.NoErr(err)
+
+		content, err := os.ReadFile(filepath.Join(tmpDir, "done.txt"))
+		is.NoErr(err)
+		is.Equal(string(content), "x 2026-10-03 (A) Done\n")
+	})
+
+	t.Run("archives to a configured path", func(t *testing.T) {
+		//nolint:gocritic // importShadow: is := is.New(t) is idiomatic for github.com/matryer/is
+		is := is.New(t)
+		tmpDir := t.TempDir()
+		archivePath := filepath.Join(tmpDir, "history", "archive.txt")
+		is.NoErr(os.MkdirAll(filepath.Dir(archivePath), 0o750))
+
+		repo := file.NewRepository(filepath.Join(tmpDir, "todo.txt"), file.WithArchivePath(archivePath))
+		err := repo.AppendToArchive(todo.NewCompleted("Done", todo.PriorityA, &october))
+		is.NoErr(err)
+
+		content, err := os.ReadFile(archivePath)
+		is.NoErr(err)
+		is.True(strings.Contains(string(content), "Done"))
+
+		_, err = os.Stat(filepath.Join(tmpDir, "done.txt"))
+		is.True(os.IsNotExist(err)) // default archive untouched
+	})
+
+	t.Run("resolves a relative archive path against the todo.txt directory", func(t *testing.T) {
+		//nolint:gocritic // importShadow: is := is.New(t) is idiomatic for github.com/matryer/is
+		is := is.New(t)
+		tmpDir := t.TempDir()
+
+		repo := file.NewRepository(filepath.Join(tmpDir, "todo.txt"), file.WithArchivePath("finished.txt"))
+		err := repo.AppendToArchive(todo.NewCompleted("Done", todo.PriorityA, &october))
+		is.NoErr(err)
+
+		_, err = os.Stat(filepath.Join(tmpDir, "finished.txt"))
+		is.NoErr(err)
+	})
+
+	t.Run("monthly rotation writes one file per completion month", func(t *testing.T) {
+		//nolint:gocritic // importShadow: is := is.New(t) is idiomatic for github.com/matryer/is
+		is := is.New(t)
+		tmpDir := t.TempDir()
+
+		repo := file.NewRepository(filepath.Join(tmpDir, "todo.txt"), file.WithArchiveRotation(file.MonthlyRotation))
+		is.NoErr(repo.AppendToArchive(todo.NewCompleted("October task", todo.PriorityA, &october)))
+		is.NoErr(repo.AppendToArchive(todo.NewCompleted("November task", todo.PriorityB, &november)))
+
+		octoberContent, err := os.ReadFile(filepath.Join(tmpDir, "done-2026-10.txt"))
+		is.NoErr(err)
+		is.True(strings.Contains(string(octoberContent), "October task"))
+
+		novemberContent, err := os.ReadFile(filepath.Join(tmpDir, "done-2026-11.txt"))
+		is.NoErr(err)
+		is.True(strings.Contains(string(novemberContent), "November task"))
+	})
+
+	t.Run("reads done.txt and every rotated file as one archive", func(t *testing.T) {
+		//nolint:gocritic // importShadow: is := is.New(t) is idiomatic for github.com/matryer/is
+		is := is.New(t)
+		tmpDir := t.TempDir()
+
+		writeFile(t, filepath.Join(tmpDir, "done.txt"), "x 2026-09-01 (A) Before rotation\n")
+		writeFile(t, filepath.Join(tmpDir, "done-2026-11.txt"), "x 2026-11-12 (B) November task\n")
+		writeFile(t, filepath.Join(tmpDir, "done-2026-10.txt"), "x 2026-10-03 (A) October task\n")
+		writeFile(t, filepath.Join(tmpDir, "done-notes.txt"), "not an archive\n")
+
+		repo := file.NewRepository(filepath.Join(tmpDir, "todo.txt"), file.WithArchiveRotation(file.MonthlyRotation))
+		archived, err := repo.LoadArchive()
+		is.NoErr(err)
+
+		is.Equal(len(archived), 3)
+		is.Equal(archived[0].Description(), "Before rotation")
+		is.Equal(archived[1].Description(), "October task")
+		is.Equal(archived[2].Description(), "November task")
+	})
+
+	t.Run("saving the archive removes rotated files that are now empty", func(t *testing.T) {
+		//nolint:gocritic // importShadow: is := is.New(t) is idiomatic for github.com/matryer/is
+		is := is.New(t)
+		tmpDir := t.TempDir()
+
+		repo := file.NewRepository(filepath.Join(tmpDir, "todo.txt"), file.WithArchiveRotation(file.MonthlyRotation))
+		is.NoErr(repo.AppendToArchive(todo.NewCompleted("October task", todo.PriorityA, &october)))
+		is.NoErr(repo.AppendToArchive(todo.NewCompleted("November task", todo.PriorityB, &november)))
+
+		is.NoErr(repo.SaveArchive([]todo.Todo{todo.NewCompleted("November task", todo.PriorityB, &november)}))
+
+		_, err := os.Stat(filepath.Join(tmpDir, "done-2026-10.txt"))
+		is.True(os.IsNotExist(err))
+
+		archived, err := repo.LoadArchive()
+		is.NoErr(err)
+		is.Equal(len(archived), 1)
+		is.Equal(archived[0].Description(), "November task")
+	})
+}
+
+func TestParseArchiveRotation(t *testing.T) {
+	is := is.New(t)
+
+	rotation, err := file.ParseArchiveRotation("monthly")
+	is.NoErr(err)
+	is.Equal(rotation, file.MonthlyRotation)
+
+	rotation, err = file.ParseArchiveRotation("")
+	is.NoErr(err)
+	is.Equal(rotation, file.NoRotation)
+
+	_, err = file.ParseArchiveRotation("weekly")
+	is.True(err != nil)
+}
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	//nolint:gosec // G306: test file permissions intentionally match production (0o644)
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("failed to write %s: %v", path, err)
+	}
+}
@@ -0,0 +1,31 @@
+package file
+
+import (
+	"os"
+	"path/filepath"
+)
+
+// DefaultProjectFile is the name of the project-local todo file FindProjectFile looks for
+const DefaultProjectFile = "todo.txt"
+
+// FindProjectFile looks for a file called name in dir and each of its parents, the way git finds .git
+// The search stops before reaching stopDir (usually the home directory), so a todo file there is
+// never mistaken for a project's. It returns the path of the nearest match.
+func FindProjectFile(dir, name, stopDir string) (string, bool) {
+	dir = filepath.Clean(dir)
+	stopDir = filepath.Clean(stopDir)
+
+	for dir != stopDir {
+		candidate := filepath.Join(dir, name)
+		if info, err := os.Stat(candidate); err == nil && !info.IsDir() {
+			return candidate, true
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", false // reached the root
+		}
+		dir = parent
+	}
+	return "", false
+}
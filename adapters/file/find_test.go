@@ -0,0 +1,74 @@
+package file_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/matryer/is"
+	"github.com/quii/todo-eisenhower/adapters/file"
+)
+
+func TestFindProjectFile(t *testing.T) {
+	// home/code/app/src is the working directory in every case
+	setup := func(t *testing.T, files ...string) (home, workDir string) {
+		t.Helper()
+		home = t.TempDir()
+		workDir = filepath.Join(home, "code", "app", "src")
+		if err := os.MkdirAll(workDir, 0o750); err != nil {
+			t.Fatalf("failed to create dirs: %v", err)
+		}
+		for _, f := range files {
+			//nolint:gosec // G306: test file permissions intentionally match production (0o644)
+			if err := os.WriteFile(filepath.Join(home, f), []byte(""), 0o644); err != nil {
+				t.Fatalf("failed to write %s: %v", f, err)
+			}
+		}
+		return home, workDir
+	}
+
+	t.Run("finds todo.txt in a parent directory", func(t *testing.T) {
+		//nolint:gocritic // importShadow: is := is.New(t) is idiomatic for github.com/matryer/is
+		is := is.New(t)
+		home, workDir := setup(t, "code/app/todo.txt")
+
+		path, ok := file.FindProjectFile(workDir, "todo.txt", home)
+		is.True(ok)
+		is.Equal(path, filepath.Join(home, "code", "app", "todo.txt"))
+	})
+
+	t.Run("prefers the nearest file", func(t *testing.T) {
+		is := is.New(t)
+		home, workDir := setup(t, "code/app/todo.txt", "code/app/src/todo.txt")
+
+		path, ok := file.FindProjectFile(workDir, "todo.txt", home)
+		is.True(ok)
+		is.Equal(path, filepath.Join(workDir, "todo.txt"))
+	})
+
+	t.Run("uses a configured file name", func(t *testing.T) {
+		is := is.New(t)
+		home, workDir := setup(t, "code/app/TODO.txt")
+
+		path, ok := file.FindProjectFile(workDir, "TODO.txt", home)
+		is.True(ok)
+		is.Equal(filepath.Base(path), "TODO.txt")
+	})
+
+	t.Run("stops before the home directory", func(t *testing.T) {
+		is := is.New(t)
+		home, workDir := setup(t, "todo.txt")
+
+		_, ok := file.FindProjectFile(workDir, "todo.txt", home)
+		is.True(!ok)
+	})
+
+	t.Run("ignores directories with the same name", func(t *testing.T) {
+		is := is.New(t)
+		home, workDir := setup(t)
+		is.NoErr(os.Mkdir(filepath.Join(home, "code", "todo.txt"), 0o750))
+
+		_, ok := file.FindProjectFile(workDir, "todo.txt", home)
+		is.True(!ok)
+	})
+}
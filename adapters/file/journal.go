@@ -0,0 +1,116 @@
+package file
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/quii/todo-eisenhower/usecases"
+)
+
+// Journal is an append-only JSON Lines file of changes, kept next to todo.txt
+// Each line is one event, so analytics and sync tools can replay history
+type Journal struct {
+	path string
+}
+
+// journalLine is the on-disk shape of a journal event
+type journalLine struct {
+	Timestamp    time.Time `json:"timestamp"`
+	Action       string    `json:"action"`
+	Description  string    `json:"description,omitempty"`
+	Before       string    `json:"before,omitempty"`
+	After        string    `json:"after,omitempty"`
+	FromQuadrant string    `json:"from_quadrant,omitempty"`
+	ToQuadrant   string    `json:"to_quadrant,omitempty"`
+	User         string    `json:"user,omitempty"`
+}
+
+// NewJournal creates a journal that appends to the given file
+func NewJournal(path string) *Journal {
+	return &Journal{path: path}
+}
+
+// JournalPath returns the default journal location for a todo.txt file (journal.jsonl in the same directory)
+func JournalPath(todoPath string) string {
+	return filepath.Join(filepath.Dir(todoPath), "journal.jsonl")
+}
+
+// Path returns the journal file
+func (j *Journal) Path() string {
+	return j.path
+}
+
+// Record appends an event to the journal file
+func (j *Journal) Record(event usecases.Event) error {
+	line, err := json.Marshal(journalLine{
+		Timestamp:    event.Timestamp,
+		Action:       string(event.Action),
+		Description:  event.Description,
+		Before:       event.Before,
+		After:        event.After,
+		FromQuadrant: event.FromQuadrant,
+		ToQuadrant:   event.ToQuadrant,
+		User:         event.User,
+	})
+	if err != nil {
+		return err
+	}
+
+	//nolint:gosec // G302: journal sits alongside todo.txt with the same permissions
+	f, err := os.OpenFile(j.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return err
+	}
+
+	if _, err := f.Write(append(line, '\n')); err != nil {
+		_ = f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// Load reads every event in the journal, oldest first
+// A missing journal file is treated as empty
+func (j *Journal) Load() ([]usecases.Event, error) {
+	//nolint:gosec // G304: the journal path comes from the todo.txt location chosen by the user
+	f, err := os.Open(j.path)
+	if os.IsNotExist(err) {
+		return []usecases.Event{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = f.Close() }()
+
+	events := make([]usecases.Event, 0)
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	lineNumber := 0
+	for scanner.Scan() {
+		lineNumber++
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+
+		var line journalLine
+		if err := json.Unmarshal(scanner.Bytes(), &line); err != nil {
+			return nil, fmt.Errorf("%s line %d: %w", j.path, lineNumber, err)
+		}
+		events = append(events, usecases.Event{
+			Timestamp:    line.Timestamp,
+			Action:       usecases.Action(line.Action),
+			Description:  line.Description,
+			Before:       line.Before,
+			After:        line.After,
+			FromQuadrant: line.FromQuadrant,
+			ToQuadrant:   line.ToQuadrant,
+			User:         line.User,
+		})
+	}
+
+	return events, scanner.Err()
+}
@@ -0,0 +1,69 @@
+package file_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/matryer/is"
+	"github.com/quii/todo-eisenhower/adapters/file"
+	"github.com/quii/todo-eisenhower/usecases"
+)
+
+func TestJournal(t *testing.T) {
+	t.Run("appends one JSON line per event and reads them back", func(t *testing.T) {
+		//nolint:gocritic // importShadow: is := is.New(t) is idiomatic for github.com/matryer/is
+		is := is.New(t)
+		path := filepath.Join(t.TempDir(), "journal.jsonl")
+		journal := file.NewJournal(path)
+
+		when := time.Date(2026, 3, 20, 9, 30, 0, 0, time.UTC)
+		is.NoErr(journal.Record(usecases.Event{
+			Timestamp:   when,
+			Action:      usecases.ActionAdd,
+			Description: "Deploy API",
+			After:       "(B) Deploy API",
+			ToQuadrant:  "schedule",
+			User:        "alice",
+		}))
+		is.NoErr(journal.Record(usecases.Event{
+			Timestamp:    when.Add(time.Hour),
+			Action:       usecases.ActionMove,
+			Before:       "(B) Deploy API",
+			After:        "(A) Deploy API",
+			FromQuadrant: "schedule",
+			ToQuadrant:   "do-first",
+			User:         "alice",
+		}))
+
+		content, err := os.ReadFile(path)
+		is.NoErr(err)
+		lines := strings.Split(strings.TrimSpace(string(content)), "\n")
+		is.Equal(len(lines), 2)
+		is.Equal(lines[0], `{"timestamp":"2026-03-20T09:30:00Z","action":"add","description":"Deploy API","after":"(B) Deploy API","to_quadrant":"schedule","user":"alice"}`)
+
+		events, err := journal.Load()
+		is.NoErr(err)
+		is.Equal(len(events), 2)
+		is.Equal(events[1].Action, usecases.ActionMove)
+		is.Equal(events[1].FromQuadrant, "schedule")
+		is.Equal(events[1].ToQuadrant, "do-first")
+		is.True(events[1].Timestamp.Equal(when.Add(time.Hour)))
+	})
+
+	t.Run("missing journal loads as empty", func(t *testing.T) {
+		is := is.New(t)
+		journal := file.NewJournal(filepath.Join(t.TempDir(), "journal.jsonl"))
+
+		events, err := journal.Load()
+		is.NoErr(err)
+		is.Equal(len(events), 0)
+	})
+
+	t.Run("journal lives next to todo.txt", func(t *testing.T) {
+		is := is.New(t)
+		is.Equal(file.JournalPath(filepath.Join("home", "me", "todo.txt")), filepath.Join("home", "me", "journal.jsonl"))
+	})
+}
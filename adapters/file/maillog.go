@@ -0,0 +1,68 @@
+package file
+
+import (
+	"bufio"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// MailLog is a file listing the IDs of the mail messages that have been imported, one per line
+type MailLog struct {
+	path string
+}
+
+// NewMailLog creates a mail log kept in the given file
+func NewMailLog(path string) *MailLog {
+	return &MailLog{path: path}
+}
+
+// MailLogPath returns the default mail log location for a todo.txt file (imported-mail.log in the same directory)
+// It doesn't end in .txt, so it isn't mistaken for a todo file in a workspace directory.
+func MailLogPath(todoPath string) string {
+	return filepath.Join(filepath.Dir(todoPath), "imported-mail.log")
+}
+
+// Imported reads the IDs of the imported messages
+// A missing log simply means nothing has been imported yet
+func (l *MailLog) Imported() (map[string]bool, error) {
+	ids := make(map[string]bool)
+	//nolint:gosec // G304: the log path comes from the todo.txt location chosen by the user
+	f, err := os.Open(l.path)
+	if os.IsNotExist(err) {
+		return ids, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = f.Close()
+	}()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		if id := strings.TrimSpace(scanner.Text()); id != "" {
+			ids[id] = true
+		}
+	}
+	return ids, scanner.Err()
+}
+
+// Add appends message IDs to the log
+func (l *MailLog) Add(ids []string) error {
+	//nolint:gosec // G302,G304: the log follows the same permissions as todo.txt
+	f, err := os.OpenFile(l.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return err
+	}
+
+	var b strings.Builder
+	for _, id := range ids {
+		b.WriteString(id + "\n")
+	}
+	if _, err := f.WriteString(b.String()); err != nil {
+		_ = f.Close()
+		return err
+	}
+	return f.Close()
+}
@@ -0,0 +1,26 @@
+package file_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/matryer/is"
+	"github.com/quii/todo-eisenhower/adapters/file"
+)
+
+func TestMailLog(t *testing.T) {
+	//nolint:gocritic // importShadow: is := is.New(t) is idiomatic for github.com/matryer/is
+	is := is.New(t)
+	log := file.NewMailLog(file.MailLogPath(filepath.Join(t.TempDir(), "todo.txt")))
+
+	ids, err := log.Imported()
+	is.NoErr(err)
+	is.Equal(len(ids), 0) // a missing log is empty
+
+	is.NoErr(log.Add([]string{"a1@example.com", "b2@example.com"}))
+	is.NoErr(log.Add([]string{"c3@example.com"}))
+
+	ids, err = log.Imported()
+	is.NoErr(err)
+	is.Equal(ids, map[string]bool{"a1@example.com": true, "b2@example.com": true, "c3@example.com": true})
+}
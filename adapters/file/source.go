@@ -2,25 +2,63 @@
 package file
 
 import (
+	"bytes"
+	"io"
 	"os"
 	"path/filepath"
+	"strings"
 
+	"github.com/quii/todo-eisenhower/domain/org"
+	"github.com/quii/todo-eisenhower/domain/taskpaper"
 	"github.com/quii/todo-eisenhower/domain/todo"
 	"github.com/quii/todo-eisenhower/domain/todotxt"
 )
 
+// format reads and writes the todos in a todo file
+type format struct {
+	unmarshal func(io.Reader) ([]todo.Todo, error)
+	marshal   func(io.Writer, []todo.Todo) error
+	rewrite   func(original io.Reader, w io.Writer, todos []todo.Todo) error // used instead of marshal for files with text that isn't todos
+}
+
+// formats are the todo file formats other than todo.txt, by file extension
+// The archive and trash next to these files are todo.txt files.
+var formats = map[string]format{
+	".org":       {unmarshal: org.Unmarshal, rewrite: org.Rewrite},
+	".taskpaper": {unmarshal: taskpaper.Unmarshal, rewrite: taskpaper.Rewrite},
+}
+
+// formatFor returns the format of the todo file at path, from its extension
+func formatFor(path string) format {
+	if f, ok := formats[strings.ToLower(filepath.Ext(path))]; ok {
+		return f
+	}
+	return format{unmarshal: todotxt.Unmarshal, marshal: todotxt.Marshal}
+}
+
 // Repository is a file-based implementation of TodoRepository
 // It handles all the file mechanics and marshaling/unmarshaling
 type Repository struct {
-	path string
+	path            string
+	archiveFile     string          // overrides done.txt next to todo.txt when set
+	archiveRotation ArchiveRotation // how archived todos are split across files
+	trashFile       string          // overrides deleted.txt next to todo.txt when set
 }
 
+// Option configures optional behaviour of a file Repository
+type Option func(*Repository)
+
 // NewRepository creates a new file-based todo repository
-func NewRepository(path string) *Repository {
-	return &Repository{path: path}
+func NewRepository(path string, opts ...Option) *Repository {
+	r := &Repository{path: path}
+	for _, opt := range opts {
+		opt(r)
+	}
+	return r
 }
 
 // LoadAll reads todos from the file
+// Files ending in .org and .taskpaper are read as Org-mode and TaskPaper files, and others as todo.txt.
 func (r *Repository) LoadAll() ([]todo.Todo, error) {
 	// Create file if it doesn't exist
 	if _, err := os.Stat(r.path); os.IsNotExist(err) {
@@ -38,13 +76,43 @@ func (r *Repository) LoadAll() ([]todo.Todo, error) {
 		_ = f.Close()
 	}()
 
-	return todotxt.Unmarshal(f)
+	return formatFor(r.path).unmarshal(f)
 }
 
 // SaveAll writes todos to the file (full rewrite)
+// Org-mode and TaskPaper files keep their headings, notes and other text that isn't a todo.
 func (r *Repository) SaveAll(todos []todo.Todo) error {
+	f := formatFor(r.path)
+	if f.rewrite != nil {
+		original, err := os.ReadFile(r.path)
+		if err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		var b bytes.Buffer
+		if err := f.rewrite(bytes.NewReader(original), &b, todos); err != nil {
+			return err
+		}
+		//nolint:gosec // G306: todo files are intentionally world-readable (0o644 per todo.txt spec)
+		return os.WriteFile(r.path, b.Bytes(), 0o644)
+	}
+
 	//nolint:gosec // G302: todo.txt files are intentionally world-readable (0o644 per todo.txt spec)
-	f, err := os.Create(r.path) // Truncates automatically
+	out, err := os.Create(r.path) // Truncates automatically
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = out.Close()
+	}()
+
+	return f.marshal(out, todos)
+}
+
+// AppendToTrash appends a deleted todo to the trash file (deleted.txt)
+// The trash file lives alongside done.txt in the same directory as todo.txt
+func (r *Repository) AppendToTrash(t todo.Todo) error {
+	//nolint:gosec // G302,G304: deleted.txt follows the same permissions as todo.txt and done.txt
+	f, err := os.OpenFile(r.trashPath(), os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
 	if err != nil {
 		return err
 	}
@@ -52,18 +120,30 @@ func (r *Repository) SaveAll(todos []todo.Todo) error {
 		_ = f.Close()
 	}()
 
-	return todotxt.Marshal(f, todos)
+	return todotxt.Marshal(f, []todo.Todo{t})
 }
 
-// AppendToArchive appends a todo to the archive file (done.txt)
-// The archive file is in the same directory as todo.txt, named done.txt
-func (r *Repository) AppendToArchive(t todo.Todo) error {
-	// Determine archive file path (done.txt in same directory as todo.txt)
-	archivePath := r.archivePath()
+// LoadTrash reads deleted todos from the trash file
+// A missing trash file simply means nothing has been deleted yet
+func (r *Repository) LoadTrash() ([]todo.Todo, error) {
+	f, err := os.Open(r.trashPath())
+	if os.IsNotExist(err) {
+		return []todo.Todo{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = f.Close()
+	}()
 
-	// Open file in append mode, create if doesn't exist
-	//nolint:gosec // G302,G304: done.txt files are intentionally world-readable (0o644 per todo.txt spec)
-	f, err := os.OpenFile(archivePath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	return todotxt.Unmarshal(f)
+}
+
+// SaveTrash writes deleted todos to the trash file (full rewrite)
+func (r *Repository) SaveTrash(todos []todo.Todo) error {
+	//nolint:gosec // G302: deleted.txt follows the same permissions as todo.txt and done.txt
+	f, err := os.Create(r.trashPath())
 	if err != nil {
 		return err
 	}
@@ -71,13 +151,32 @@ func (r *Repository) AppendToArchive(t todo.Todo) error {
 		_ = f.Close()
 	}()
 
-	// Write the todo as a single line
-	return todotxt.Marshal(f, []todo.Todo{t})
+	return todotxt.Marshal(f, todos)
+}
+
+// Files returns the files the repository reads and writes: the todo file, its archive files and its trash
+func (r *Repository) Files() []string {
+	archives, _ := r.existingArchiveFiles() // the rest are still worth listing if the archive can't be read
+	return append(append([]string{r.path}, archives...), r.archivePath(), r.trashPath())
 }
 
-// archivePath returns the path to the archive file (done.txt)
-// Following todo.txt convention: always use done.txt in the same directory
-func (r *Repository) archivePath() string {
+// WithTrashPath stores deleted todos at path instead of deleted.txt next to todo.txt
+// Relative paths are resolved against the directory containing todo.txt
+func WithTrashPath(path string) Option {
+	return func(r *Repository) {
+		r.trashFile = path
+	}
+}
+
+// trashPath returns the path to the trash file (deleted.txt)
+// Uses the same convention as archivePath: a sibling of todo.txt
+func (r *Repository) trashPath() string {
 	dir := filepath.Dir(r.path)
-	return filepath.Join(dir, "done.txt")
+	if r.trashFile == "" {
+		return filepath.Join(dir, "deleted.txt")
+	}
+	if filepath.IsAbs(r.trashFile) {
+		return r.trashFile
+	}
+	return filepath.Join(dir, r.trashFile)
 }
@@ -71,4 +71,53 @@ func TestRepository(t *testing.T) {
 		is.Equal(loaded[0].Description(), "First task")
 		is.Equal(loaded[1].Description(), "Second task")
 	})
+
+	t.Run("reads and writes org-mode and taskpaper files", func(t *testing.T) {
+		//nolint:gocritic // importShadow: is := is.New(t) is idiomatic for github.com/matryer/is
+		is := is.New(t)
+		tmpDir := t.TempDir()
+
+		orgFile := filepath.Join(tmpDir, "notes.org")
+		repo := file.NewRepository(orgFile)
+		is.NoErr(repo.SaveAll([]todo.Todo{todo.NewWithTags("First task", todo.PriorityA, []string{"work"}, nil)}))
+		content, err := os.ReadFile(orgFile)
+		is.NoErr(err)
+		is.Equal(string(content), "* TODO [#A] First task :work:\n")
+
+		paperFile := filepath.Join(tmpDir, "tasks.taskpaper")
+		//nolint:gosec // G306: test file permissions intentionally match production (0o644)
+		is.NoErr(os.WriteFile(paperFile, []byte("Work:\n\t- First task @priority(A)\n"), 0o644))
+		loaded, err := file.NewRepository(paperFile).LoadAll()
+		is.NoErr(err)
+		is.Equal(loaded[0].String(), "(A) First task +Work\n")
+	})
+
+	t.Run("keeps the text in org-mode and taskpaper files that isn't todos", func(t *testing.T) {
+		is := is.New(t)
+		tmpDir := t.TempDir()
+
+		orgFile := filepath.Join(tmpDir, "notes.org")
+		orgNotes := "#+TITLE: Notes\n* Work\nSome prose about work.\n** TODO [#A] First task\n   Details.\n"
+		//nolint:gosec // G306: test file permissions intentionally match production (0o644)
+		is.NoErr(os.WriteFile(orgFile, []byte(orgNotes), 0o644))
+		repo := file.NewRepository(orgFile)
+		loaded, err := repo.LoadAll()
+		is.NoErr(err)
+		is.NoErr(repo.SaveAll(loaded))
+		content, err := os.ReadFile(orgFile)
+		is.NoErr(err)
+		is.Equal(string(content), orgNotes)
+
+		paperFile := filepath.Join(tmpDir, "tasks.taskpaper")
+		paperNotes := "Plans for the week.\nWork:\n\tKeep mornings free.\n\t- First task @priority(A)\n"
+		//nolint:gosec // G306: test file permissions intentionally match production (0o644)
+		is.NoErr(os.WriteFile(paperFile, []byte(paperNotes), 0o644))
+		repo = file.NewRepository(paperFile)
+		loaded, err = repo.LoadAll()
+		is.NoErr(err)
+		is.NoErr(repo.SaveAll([]todo.Todo{loaded[0].ChangePriority(todo.PriorityB)}))
+		content, err = os.ReadFile(paperFile)
+		is.NoErr(err)
+		is.Equal(string(content), "Plans for the week.\nWork:\n\tKeep mornings free.\n\t- First task @priority(B)\n")
+	})
 }
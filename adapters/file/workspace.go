@@ -0,0 +1,255 @@
+package file
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/quii/todo-eisenhower/domain/todo"
+)
+
+// Workspace combines several todo.txt files into one repository
+// Every todo remembers the file it came from (its source) and is written back to that file,
+// and each file keeps its own archive and trash
+type Workspace struct {
+	names []string               // source names in the order the files were given
+	repos map[string]*Repository // repository for each source name
+}
+
+// workspaceSidecarPattern matches archive and trash files that live alongside workspace files
+// (done.txt, deleted.txt, work.done.txt, work.deleted.txt and their monthly rotations)
+var workspaceSidecarPattern = regexp.MustCompile(`(?i)(^|\.)(done|deleted)(-\d{4}-\d{2})?\.txt$`)
+
+// NewWorkspace creates a repository spanning the given todo files
+// The first file is the primary one: todos that don't belong to any file yet (such as new todos) are saved there.
+// Options apply to every file, except that each file's archive and trash are always its own.
+func NewWorkspace(paths []string, opts ...Option) (*Workspace, error) {
+	if len(paths) == 0 {
+		return nil, fmt.Errorf("a workspace needs at least one todo file")
+	}
+
+	names := sourceNames(paths)
+	w := &Workspace{names: names, repos: make(map[string]*Repository, len(paths))}
+	for i, path := range paths {
+		archiveFile, trashFile := sidecarFiles(path)
+		fileOpts := append(append([]Option{}, opts...), WithArchivePath(archiveFile), WithTrashPath(trashFile))
+		w.repos[names[i]] = NewRepository(path, fileOpts...)
+	}
+	return w, nil
+}
+
+// WorkspaceFiles lists the todo files in a workspace directory, with todo.txt (if present) first
+// Org and TaskPaper files are included alongside todo.txt files; archive and trash files are skipped
+func WorkspaceFiles(dir string) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var files []string
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !isTodoFile(name) || workspaceSidecarPattern.MatchString(name) {
+			continue
+		}
+		files = append(files, filepath.Join(dir, name))
+	}
+
+	sort.SliceStable(files, func(i, j int) bool {
+		iTodo := filepath.Base(files[i]) == "todo.txt"
+		jTodo := filepath.Base(files[j]) == "todo.txt"
+		if iTodo != jTodo {
+			return iTodo
+		}
+		return files[i] < files[j]
+	})
+
+	if len(files) == 0 {
+		return nil, fmt.Errorf("no todo files found in %s", dir)
+	}
+	return files, nil
+}
+
+// isTodoFile returns true if the file name has the extension of todo.txt or one of the other formats
+func isTodoFile(name string) bool {
+	ext := strings.ToLower(filepath.Ext(name))
+	_, ok := formats[ext]
+	return ext == ".txt" || ok
+}
+
+// Sources returns the names of the files in the workspace, primary file first
+func (w *Workspace) Sources() []string {
+	return w.names
+}
+
+// Files returns the files every todo file in the workspace reads and writes
+func (w *Workspace) Files() []string {
+	var files []string
+	for _, name := range w.names {
+		files = append(files, w.repos[name].Files()...)
+	}
+	return files
+}
+
+// LoadAll reads todos from every file, tagging each with its source
+func (w *Workspace) LoadAll() ([]todo.Todo, error) {
+	return w.loadEach((*Repository).LoadAll)
+}
+
+// SaveAll writes each todo back to the file it came from (full rewrite of every file)
+func (w *Workspace) SaveAll(todos []todo.Todo) error {
+	return w.saveEach(todos, (*Repository).SaveAll)
+}
+
+// AppendToArchive appends a todo to the archive of the file it came from
+func (w *Workspace) AppendToArchive(t todo.Todo) error {
+	return w.repoFor(t).AppendToArchive(t)
+}
+
+// LoadArchive reads the archives of every file, tagging each todo with its source
+func (w *Workspace) LoadArchive() ([]todo.Todo, error) {
+	return w.loadEach((*Repository).LoadArchive)
+}
+
+// SaveArchive writes archived todos back to the archive of the file they came from
+func (w *Workspace) SaveArchive(todos []todo.Todo) error {
+	return w.saveEach(todos, (*Repository).SaveArchive)
+}
+
+// AppendToTrash appends a deleted todo to the trash of the file it came from
+func (w *Workspace) AppendToTrash(t todo.Todo) error {
+	return w.repoFor(t).AppendToTrash(t)
+}
+
+// LoadTrash reads the trash of every file, tagging each todo with its source
+func (w *Workspace) LoadTrash() ([]todo.Todo, error) {
+	return w.loadEach((*Repository).LoadTrash)
+}
+
+// SaveTrash writes deleted todos back to the trash of the file they came from
+func (w *Workspace) SaveTrash(todos []todo.Todo) error {
+	return w.saveEach(todos, (*Repository).SaveTrash)
+}
+
+// repoFor returns the repository a todo belongs to, falling back to the primary file
+func (w *Workspace) repoFor(t todo.Todo) *Repository {
+	if repo, ok := w.repos[t.Source()]; ok {
+		return repo
+	}
+	return w.repos[w.names[0]]
+}
+
+// loadEach runs load against every file and tags the results with their source
+func (w *Workspace) loadEach(load func(*Repository) ([]todo.Todo, error)) ([]todo.Todo, error) {
+	all := make([]todo.Todo, 0)
+	for _, name := range w.names {
+		todos, err := load(w.repos[name])
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", name, err)
+		}
+		for _, t := range todos {
+			all = append(all, t.WithSource(name))
+		}
+	}
+	return all, nil
+}
+
+// saveEach groups todos by source and runs save against every file, including files left empty
+func (w *Workspace) saveEach(todos []todo.Todo, save func(*Repository, []todo.Todo) error) error {
+	grouped := make(map[*Repository][]todo.Todo, len(w.repos))
+	for _, t := range todos {
+		repo := w.repoFor(t)
+		grouped[repo] = append(grouped[repo], t)
+	}
+
+	for _, name := range w.names {
+		repo := w.repos[name]
+		if err := save(repo, grouped[repo]); err != nil {
+			return fmt.Errorf("%s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// sourceNames derives a short, unique name for each file (work.txt -> "work")
+// Clashing names are told apart by their extension when that differs (work.txt and work.org -> "work.txt", "work.org"),
+// otherwise by as many parent directories as it takes (/x/a/todo.txt and /y/a/todo.txt -> "x/a/todo", "y/a/todo").
+// The same file given twice gets a numeric suffix.
+func sourceNames(paths []string) []string {
+	withExt := make([]bool, len(paths))
+	depth := make([]int, len(paths))
+	name := func(i int) string {
+		path := filepath.Clean(paths[i])
+		base := filepath.Base(path)
+		if !withExt[i] {
+			base = strings.TrimSuffix(base, filepath.Ext(base))
+		}
+		dirs := strings.Split(filepath.ToSlash(filepath.Dir(path)), "/")
+		return strings.Join(append(dirs[max(len(dirs)-depth[i], 0):], base), "/")
+	}
+
+	names := make([]string, len(paths))
+	for {
+		clashes := make(map[string][]int)
+		for i := range paths {
+			names[i] = name(i)
+			clashes[names[i]] = append(clashes[names[i]], i)
+		}
+
+		progressed := false
+		for _, group := range clashes {
+			if len(group) < 2 {
+				continue
+			}
+			exts, files := make(map[string]bool), make(map[string]bool)
+			for _, i := range group {
+				exts[filepath.Ext(paths[i])] = true
+				files[filepath.Clean(paths[i])] = true
+			}
+			if len(files) == 1 {
+				continue // the same file given twice
+			}
+			for _, i := range group {
+				switch {
+				case len(exts) > 1 && !withExt[i]:
+					withExt[i] = true
+					progressed = true
+				case depth[i] < strings.Count(filepath.ToSlash(filepath.Clean(paths[i])), "/"):
+					depth[i]++
+					progressed = true
+				}
+			}
+		}
+		if !progressed {
+			break
+		}
+	}
+
+	seen := make(map[string]int)
+	for i := range names {
+		seen[names[i]]++
+		if seen[names[i]] > 1 {
+			names[i] = fmt.Sprintf("%s#%d", names[i], seen[names[i]])
+		}
+	}
+	return names
+}
+
+// sidecarFiles returns the archive and trash file names for a workspace file
+// todo.txt keeps the todo.sh names (done.txt, deleted.txt); work.txt gets work.done.txt and work.deleted.txt,
+// and other formats keep their extension (work.org.done.txt) so they don't share work.txt's files
+func sidecarFiles(path string) (archiveFile, trashFile string) {
+	base := filepath.Base(path)
+	if base == "todo.txt" {
+		return "done.txt", "deleted.txt"
+	}
+
+	stem := base
+	if strings.EqualFold(filepath.Ext(base), ".txt") {
+		stem = strings.TrimSuffix(base, filepath.Ext(base))
+	}
+	return stem + ".done.txt", stem + ".deleted.txt"
+}
@@ -0,0 +1,178 @@
+package file_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/matryer/is"
+	"github.com/quii/todo-eisenhower/adapters/file"
+	"github.com/quii/todo-eisenhower/domain/todo"
+)
+
+func TestWorkspace(t *testing.T) {
+	newWorkspace := func(t *testing.T) (*file.Workspace, string) {
+		t.Helper()
+		tmpDir := t.TempDir()
+		writeFile(t, filepath.Join(tmpDir, "work.txt"), "(A) Deploy API\n")
+		writeFile(t, filepath.Join(tmpDir, "personal.txt"), "(B) Book dentist\n")
+
+		w, err := file.NewWorkspace([]string{
+			filepath.Join(tmpDir, "work.txt"),
+			filepath.Join(tmpDir, "personal.txt"),
+		})
+		if err != nil {
+			t.Fatalf("failed to create workspace: %v", err)
+		}
+		return w, tmpDir
+	}
+
+	t.Run("loads todos from every file and remembers their source", func(t *testing.T) {
+		//nolint:gocritic // importShadow: is := is.New(t) is idiomatic for github.com/matryer/is
+		is := is.New(t)
+		w, _ := newWorkspace(t)
+
+		todos, err := w.LoadAll()
+		is.NoErr(err)
+		is.Equal(len(todos), 2)
+		is.Equal(todos[0].Source(), "work")
+		is.Equal(todos[1].Source(), "personal")
+		is.Equal(w.Sources(), []string{"work", "personal"})
+	})
+
+	t.Run("writes each todo back to its own file", func(t *testing.T) {
+		is := is.New(t)
+		w, dir := newWorkspace(t)
+
+		todos, err := w.LoadAll()
+		is.NoErr(err)
+
+		moved := todos[1].ChangePriority(todo.PriorityA)
+		newTodo := todo.New("Plan sprint", todo.PriorityB)
+		is.NoErr(w.SaveAll([]todo.Todo{todos[0], moved, newTodo}))
+
+		work, err := os.ReadFile(filepath.Join(dir, "work.txt"))
+		is.NoErr(err)
+		is.Equal(string(work), "(A) Deploy API\n(B) Plan sprint\n") // new todos go to the primary file
+
+		personal, err := os.ReadFile(filepath.Join(dir, "personal.txt"))
+		is.NoErr(err)
+		is.Equal(string(personal), "(A) Book dentist\n")
+	})
+
+	t.Run("archives and trashes into each file's own sidecar files", func(t *testing.T) {
+		is := is.New(t)
+		w, dir := newWorkspace(t)
+
+		todos, err := w.LoadAll()
+		is.NoErr(err)
+
+		completed := todos[1].ToggleCompletion(time.Date(2026, 10, 3, 0, 0, 0, 0, time.UTC))
+		is.NoErr(w.AppendToArchive(completed))
+		is.NoErr(w.AppendToTrash(todos[0].MarkDeleted(time.Date(2026, 10, 4, 0, 0, 0, 0, time.UTC))))
+
+		archive, err := os.ReadFile(filepath.Join(dir, "personal.done.txt"))
+		is.NoErr(err)
+		is.Equal(string(archive), "x 2026-10-03 (B) Book dentist\n")
+
+		trash, err := os.ReadFile(filepath.Join(dir, "work.deleted.txt"))
+		is.NoErr(err)
+		is.Equal(string(trash), "(A) Deploy API deleted:2026-10-04\n")
+
+		archived, err := w.LoadArchive()
+		is.NoErr(err)
+		is.Equal(len(archived), 1)
+		is.Equal(archived[0].Source(), "personal")
+	})
+
+	t.Run("todo.txt keeps done.txt as its archive", func(t *testing.T) {
+		is := is.New(t)
+		tmpDir := t.TempDir()
+
+		w, err := file.NewWorkspace([]string{
+			filepath.Join(tmpDir, "todo.txt"),
+			filepath.Join(tmpDir, "work.txt"),
+		})
+		is.NoErr(err)
+
+		is.NoErr(w.AppendToArchive(todo.NewCompleted("Done", todo.PriorityA, nil).WithSource("todo")))
+
+		_, err = os.Stat(filepath.Join(tmpDir, "done.txt"))
+		is.NoErr(err)
+	})
+
+	t.Run("disambiguates files with the same name", func(t *testing.T) {
+		is := is.New(t)
+		tmpDir := t.TempDir()
+
+		w, err := file.NewWorkspace([]string{
+			filepath.Join(tmpDir, "work", "todo.txt"),
+			filepath.Join(tmpDir, "home", "todo.txt"),
+		})
+		is.NoErr(err)
+		is.Equal(w.Sources(), []string{"work/todo", "home/todo"})
+	})
+
+	t.Run("keeps names unique when files clash on extension or directory", func(t *testing.T) {
+		is := is.New(t)
+		tmpDir := t.TempDir()
+		writeFile(t, filepath.Join(tmpDir, "work.txt"), "(A) Deploy API\n")
+		writeFile(t, filepath.Join(tmpDir, "work.org"), "* TODO [#B] Plan sprint\n")
+		for _, dir := range []string{"x/a", "y/a"} {
+			is.NoErr(os.MkdirAll(filepath.Join(tmpDir, dir), 0o750))
+			writeFile(t, filepath.Join(tmpDir, dir, "todo.txt"), "")
+		}
+
+		w, err := file.NewWorkspace([]string{
+			filepath.Join(tmpDir, "work.txt"),
+			filepath.Join(tmpDir, "work.org"),
+			filepath.Join(tmpDir, "x", "a", "todo.txt"),
+			filepath.Join(tmpDir, "y", "a", "todo.txt"),
+		})
+		is.NoErr(err)
+		is.Equal(w.Sources(), []string{"work.txt", "work.org", "x/a/todo", "y/a/todo"})
+
+		todos, err := w.LoadAll()
+		is.NoErr(err)
+		is.Equal(len(todos), 2) // neither file hides the other
+		is.Equal(todos[0].Source(), "work.txt")
+		is.Equal(todos[1].Source(), "work.org")
+
+		is.NoErr(w.AppendToArchive(todos[1].ToggleCompletion(time.Date(2026, 10, 3, 0, 0, 0, 0, time.UTC))))
+		_, err = os.Stat(filepath.Join(tmpDir, "work.org.done.txt"))
+		is.NoErr(err)
+	})
+}
+
+func TestWorkspaceFiles(t *testing.T) {
+	t.Run("lists todo files, skipping archives and trash", func(t *testing.T) {
+		is := is.New(t)
+		tmpDir := t.TempDir()
+		for _, name := range []string{
+			"work.txt", "todo.txt", "personal.txt",
+			"done.txt", "deleted.txt", "done-2026-10.txt",
+			"work.done.txt", "work.deleted.txt", "notes.md",
+			"plans.org", "errands.taskpaper", "plans.org.done.txt",
+		} {
+			writeFile(t, filepath.Join(tmpDir, name), "")
+		}
+
+		files, err := file.WorkspaceFiles(tmpDir)
+		is.NoErr(err)
+		is.Equal(files, []string{
+			filepath.Join(tmpDir, "todo.txt"),
+			filepath.Join(tmpDir, "errands.taskpaper"),
+			filepath.Join(tmpDir, "personal.txt"),
+			filepath.Join(tmpDir, "plans.org"),
+			filepath.Join(tmpDir, "work.txt"),
+		})
+	})
+
+	t.Run("errors when the directory has no todo files", func(t *testing.T) {
+		is := is.New(t)
+
+		_, err := file.WorkspaceFiles(t.TempDir())
+		is.True(err != nil)
+	})
+}
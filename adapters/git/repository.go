@@ -0,0 +1,293 @@
+// Package git provides a TodoRepository decorator that versions todo files in a local git repository.
+package git
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/quii/todo-eisenhower/domain/todo"
+	"github.com/quii/todo-eisenhower/usecases"
+)
+
+// DefaultDebounce is how long the repository waits for more changes before committing
+const DefaultDebounce = 2 * time.Second
+
+// defaultCommitMessage is used when changes were saved without a use case describing them
+const defaultCommitMessage = "Update todos"
+
+// Repository wraps a TodoRepository and commits every change to the git repository in dir
+// Only the files the todos live in are staged and committed, so other work in the same repository is left alone.
+// The debounce starts once a use case has recorded what it changed, so one change is never split across commits,
+// and changes made in quick succession are batched into one commit after the debounce period.
+type Repository struct {
+	usecases.TodoRepository
+	dir      string
+	files    func() []string // the todo, archive, trash and journal files to commit
+	debounce time.Duration
+
+	mu       sync.Mutex
+	dirty    bool        // files changed since the last commit
+	messages []string    // summaries of the changes since the last commit
+	timer    *time.Timer // pending debounced commit
+	err      error       // first background commit failure, reported by Close
+}
+
+// Option configures optional behaviour of a git Repository
+type Option func(*Repository)
+
+// WithDebounce sets how long to wait for more changes before committing (0 commits immediately)
+func WithDebounce(d time.Duration) Option {
+	return func(r *Repository) {
+		r.debounce = d
+	}
+}
+
+// ConflictError reports files left conflicted by a pull
+type ConflictError struct {
+	Dir   string
+	Files []string
+}
+
+// Error explains which files conflicted and how to recover
+func (e *ConflictError) Error() string {
+	return fmt.Sprintf("git pull in %s hit conflicts in %s; the rebase was aborted so your local todos are unchanged - resolve the conflict with git and start again",
+		e.Dir, strings.Join(e.Files, ", "))
+}
+
+// NewRepository decorates repo so that changes to files are committed to the git repository in dir
+// files is called at every commit, as files such as monthly archives can appear while the repository is open;
+// files outside dir are never committed
+func NewRepository(repo usecases.TodoRepository, dir string, files func() []string, opts ...Option) *Repository {
+	r := &Repository{
+		TodoRepository: repo,
+		dir:            dir,
+		files:          files,
+		debounce:       DefaultDebounce,
+	}
+	for _, opt := range opts {
+		opt(r)
+	}
+	return r
+}
+
+// SaveAll saves todos and schedules a commit
+func (r *Repository) SaveAll(todos []todo.Todo) error {
+	return r.changed(r.TodoRepository.SaveAll(todos))
+}
+
+// AppendToArchive archives a todo and schedules a commit
+func (r *Repository) AppendToArchive(t todo.Todo) error {
+	return r.changed(r.TodoRepository.AppendToArchive(t))
+}
+
+// SaveArchive saves the archive and schedules a commit
+func (r *Repository) SaveArchive(todos []todo.Todo) error {
+	return r.changed(r.TodoRepository.SaveArchive(todos))
+}
+
+// AppendToTrash trashes a todo and schedules a commit
+func (r *Repository) AppendToTrash(t todo.Todo) error {
+	return r.changed(r.TodoRepository.AppendToTrash(t))
+}
+
+// SaveTrash saves the trash and schedules a commit
+func (r *Repository) SaveTrash(todos []todo.Todo) error {
+	return r.changed(r.TodoRepository.SaveTrash(todos))
+}
+
+// Record uses the event's summary as the commit message, after passing it on to any journal being decorated
+// (so the journal entry is part of the same commit), and starts the debounce now the use case is done
+func (r *Repository) Record(event usecases.Event) error {
+	if recorder, ok := r.TodoRepository.(interface{ Record(usecases.Event) error }); ok {
+		if err := recorder.Record(event); err != nil {
+			return err
+		}
+	}
+
+	r.mu.Lock()
+	r.messages = append(r.messages, event.Summary())
+	if r.debounce > 0 {
+		if r.timer != nil {
+			r.timer.Stop()
+		}
+		r.timer = time.AfterFunc(r.debounce, r.commit)
+		r.mu.Unlock()
+		return nil
+	}
+	r.mu.Unlock()
+
+	r.commit()
+	return nil
+}
+
+// Pull fetches and rebases onto the upstream branch before the todos are loaded
+// Conflicts abort the rebase and are reported as a *ConflictError
+func (r *Repository) Pull() error {
+	if _, err := r.git("pull", "--rebase", "--autostash"); err != nil {
+		conflicts, _ := r.git("diff", "--name-only", "--diff-filter=U")
+		if files := strings.Fields(conflicts); len(files) > 0 {
+			_, _ = r.git("rebase", "--abort")
+			return &ConflictError{Dir: r.dir, Files: files}
+		}
+		return err
+	}
+	return nil
+}
+
+// Close commits any pending changes immediately and reports any earlier commit failure
+func (r *Repository) Close() error {
+	r.mu.Lock()
+	if r.timer != nil {
+		r.timer.Stop()
+		r.timer = nil
+	}
+	r.mu.Unlock()
+
+	r.commit()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.err
+}
+
+// changed marks the files dirty when the wrapped write succeeded
+// A use case is part-way through, so any pending commit waits until it records what changed (or Close)
+func (r *Repository) changed(err error) error {
+	if err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	r.dirty = true
+	if r.timer != nil {
+		r.timer.Stop()
+		r.timer = nil
+	}
+	r.mu.Unlock()
+
+	return nil
+}
+
+// commit stages the todo files and commits them with the collected messages
+func (r *Repository) commit() {
+	r.mu.Lock()
+	if !r.dirty {
+		r.mu.Unlock()
+		return
+	}
+	message := commitMessage(r.messages)
+	r.dirty = false
+	r.messages = nil
+	r.timer = nil
+	r.mu.Unlock()
+
+	if err := r.commitAll(message); err != nil {
+		r.mu.Lock()
+		if r.err == nil {
+			r.err = err
+		}
+		r.mu.Unlock()
+	}
+}
+
+// commitAll stages and commits the todo files, doing nothing when they haven't changed
+// Committing with a pathspec leaves anything else the user has staged out of the commit
+func (r *Repository) commitAll(message string) error {
+	paths, err := r.paths()
+	if err != nil || len(paths) == 0 {
+		return err
+	}
+
+	if _, err := r.git(append([]string{"add", "--all", "--"}, paths...)...); err != nil {
+		return err
+	}
+
+	// diff --cached --quiet exits 0 when nothing is staged
+	if _, err := r.git(append([]string{"diff", "--cached", "--quiet", "--"}, paths...)...); err == nil {
+		return nil
+	}
+
+	_, err = r.git(append([]string{"commit", "--quiet", "--message", message, "--"}, paths...)...)
+	return err
+}
+
+// paths returns the todo files under dir, relative to it, that exist or that git tracks (so deletions are committed)
+// git refuses pathspecs that match nothing, so files that were never created are left out
+func (r *Repository) paths() ([]string, error) {
+	dir, err := filepath.Abs(r.dir)
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make(map[string]bool)
+	var candidates []string
+	for _, file := range r.files() {
+		abs, err := filepath.Abs(file)
+		if err != nil {
+			return nil, err
+		}
+		rel, err := filepath.Rel(dir, abs)
+		if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) || seen[rel] {
+			continue
+		}
+		seen[rel] = true
+		candidates = append(candidates, rel)
+	}
+	if len(candidates) == 0 {
+		return nil, nil
+	}
+
+	tracked, err := r.git(append([]string{"ls-files", "-z", "--"}, candidates...)...)
+	if err != nil {
+		return nil, err
+	}
+	isTracked := make(map[string]bool)
+	for _, path := range strings.Split(tracked, "\x00") {
+		isTracked[filepath.FromSlash(path)] = true
+	}
+
+	var paths []string
+	for _, rel := range candidates {
+		if _, err := os.Stat(filepath.Join(dir, rel)); err == nil || isTracked[rel] {
+			paths = append(paths, rel)
+		}
+	}
+	return paths, nil
+}
+
+// git runs a git command in dir and returns its output
+func (r *Repository) git(args ...string) (string, error) {
+	cmd := exec.Command("git", append([]string{"-C", r.dir}, args...)...)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && stderr.Len() > 0 {
+			return stdout.String(), fmt.Errorf("git %s: %s", args[0], strings.TrimSpace(stderr.String()))
+		}
+		return stdout.String(), fmt.Errorf("git %s: %w", args[0], err)
+	}
+	return stdout.String(), nil
+}
+
+// commitMessage turns the summaries of batched changes into a commit message
+// One change uses its summary; several get a count in the subject and one line each in the body
+func commitMessage(messages []string) string {
+	switch len(messages) {
+	case 0:
+		return defaultCommitMessage
+	case 1:
+		return messages[0]
+	default:
+		return fmt.Sprintf("Update %d todos\n\n- %s", len(messages), strings.Join(messages, "\n- "))
+	}
+}
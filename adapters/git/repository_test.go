@@ -0,0 +1,278 @@
+package git_test
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/matryer/is"
+	"github.com/quii/todo-eisenhower/adapters/file"
+	"github.com/quii/todo-eisenhower/adapters/git"
+	"github.com/quii/todo-eisenhower/domain/matrix"
+	"github.com/quii/todo-eisenhower/domain/todo"
+	"github.com/quii/todo-eisenhower/usecases"
+)
+
+func TestRepository(t *testing.T) {
+	t.Run("commits each use case with a descriptive message", func(t *testing.T) {
+		//nolint:gocritic // importShadow: is := is.New(t) is idiomatic for github.com/matryer/is
+		is := is.New(t)
+		dir := newGitRepo(t)
+		writeTodos(t, dir, "(B) Deploy API\n")
+		runGit(t, dir, "add", ".")
+		runGit(t, dir, "commit", "-m", "Initial todos")
+
+		repo := newRepository(dir, git.WithDebounce(0))
+		m, err := usecases.LoadMatrix(repo)
+		is.NoErr(err)
+
+		_, err = usecases.ChangePriority(repo, m, matrix.ScheduleQuadrant, 0, todo.PriorityA)
+		is.NoErr(err)
+		is.NoErr(repo.Close())
+
+		is.Equal(runGit(t, dir, "log", "-1", "--format=%s"), "Move 'Deploy API' to Do First")
+		is.Equal(runGit(t, dir, "status", "--porcelain"), "")
+	})
+
+	t.Run("batches changes made within the debounce period", func(t *testing.T) {
+		is := is.New(t)
+		dir := newGitRepo(t)
+
+		repo := newRepository(dir, git.WithDebounce(time.Hour))
+		m, err := usecases.LoadMatrix(repo)
+		is.NoErr(err)
+
+		m, err = usecases.AddTodo(repo, m, "Deploy API", todo.PriorityA)
+		is.NoErr(err)
+		_, err = usecases.AddTodo(repo, m, "Plan sprint", todo.PriorityB)
+		is.NoErr(err)
+
+		is.Equal(commitCount(t, dir), 0) // still waiting for the debounce
+
+		is.NoErr(repo.Close()) // Close flushes the pending commit
+
+		is.Equal(commitCount(t, dir), 1)
+		message := runGit(t, dir, "log", "-1", "--format=%B")
+		is.True(strings.HasPrefix(message, "Update 2 todos"))
+		is.True(strings.Contains(message, "- Add 'Deploy API' to Do First"))
+		is.True(strings.Contains(message, "- Add 'Plan sprint' to Schedule"))
+	})
+
+	t.Run("commits the journal alongside the change", func(t *testing.T) {
+		is := is.New(t)
+		dir := newGitRepo(t)
+		todoPath := filepath.Join(dir, "todo.txt")
+
+		fileRepo, journal := file.NewRepository(todoPath), file.NewJournal(file.JournalPath(todoPath))
+		journaled := usecases.WithJournal(fileRepo, journal, "alice")
+		repo := git.NewRepository(journaled, dir, func() []string { return append(fileRepo.Files(), journal.Path()) }, git.WithDebounce(0))
+		m, err := usecases.LoadMatrix(repo)
+		is.NoErr(err)
+
+		_, err = usecases.AddTodo(repo, m, "Deploy API", todo.PriorityA)
+		is.NoErr(err)
+		is.NoErr(repo.Close())
+
+		is.Equal(runGit(t, dir, "status", "--porcelain"), "")
+		is.True(strings.Contains(runGit(t, dir, "show", "--name-only", "--format="), "journal.jsonl"))
+	})
+
+	t.Run("leaves other files in the repository alone", func(t *testing.T) {
+		is := is.New(t)
+		dir := newGitRepo(t)
+		writeTodos(t, dir, "(B) Deploy API\n")
+		writeFile(t, filepath.Join(dir, "main.go"), "package main\n")
+		runGit(t, dir, "add", ".")
+		runGit(t, dir, "commit", "-m", "Initial commit")
+
+		writeFile(t, filepath.Join(dir, "main.go"), "package main\n\nfunc main() {}\n")
+		writeFile(t, filepath.Join(dir, "notes.md"), "work in progress\n")
+		runGit(t, dir, "add", "notes.md") // staged by the user, but not theirs to commit
+
+		repo := newRepository(dir, git.WithDebounce(0))
+		m, err := usecases.LoadMatrix(repo)
+		is.NoErr(err)
+		_, err = usecases.ToggleCompletion(repo, m, matrix.ScheduleQuadrant, 0)
+		is.NoErr(err)
+		is.NoErr(repo.Close())
+
+		is.Equal(runGit(t, dir, "show", "--name-only", "--format="), "todo.txt")
+		is.Equal(runGit(t, dir, "status", "--porcelain"), "M main.go\nA  notes.md")
+	})
+
+	t.Run("commits a use case in one piece however long it takes", func(t *testing.T) {
+		is := is.New(t)
+		dir := newGitRepo(t)
+		todoPath := filepath.Join(dir, "todo.txt")
+
+		fileRepo, journal := file.NewRepository(todoPath), file.NewJournal(file.JournalPath(todoPath))
+		journaled := usecases.WithJournal(fileRepo, slowJournal{journal}, "alice")
+		repo := git.NewRepository(journaled, dir, func() []string { return append(fileRepo.Files(), journal.Path()) },
+			git.WithDebounce(time.Millisecond))
+		m, err := usecases.LoadMatrix(repo)
+		is.NoErr(err)
+
+		_, err = usecases.AddTodo(repo, m, "Deploy API", todo.PriorityA)
+		is.NoErr(err)
+		is.NoErr(repo.Close())
+
+		is.Equal(commitCount(t, dir), 1)
+		is.Equal(runGit(t, dir, "show", "--name-only", "--format="), "journal.jsonl\ntodo.txt")
+	})
+
+	t.Run("does nothing when nothing changed", func(t *testing.T) {
+		is := is.New(t)
+		dir := newGitRepo(t)
+
+		repo := newRepository(dir, git.WithDebounce(0))
+		is.NoErr(repo.Close())
+
+		is.Equal(commitCount(t, dir), 0)
+	})
+}
+
+func TestRepository_Pull(t *testing.T) {
+	t.Run("rebases onto upstream changes", func(t *testing.T) {
+		is := is.New(t)
+		mine, theirs := newClonePair(t)
+
+		writeTodos(t, theirs, "(A) Shared task\n(B) Added elsewhere\n")
+		runGit(t, theirs, "commit", "-am", "Add from laptop")
+		runGit(t, theirs, "push", "--quiet")
+
+		repo := newRepository(mine)
+		is.NoErr(repo.Pull())
+
+		todos, err := repo.LoadAll()
+		is.NoErr(err)
+		is.Equal(len(todos), 2)
+	})
+
+	t.Run("reports conflicts and leaves local todos untouched", func(t *testing.T) {
+		is := is.New(t)
+		mine, theirs := newClonePair(t)
+
+		writeTodos(t, theirs, "(A) Shared task edited on laptop\n")
+		runGit(t, theirs, "commit", "-am", "Edit on laptop")
+		runGit(t, theirs, "push", "--quiet")
+
+		writeTodos(t, mine, "(A) Shared task edited on desktop\n")
+		runGit(t, mine, "commit", "-am", "Edit on desktop")
+
+		repo := newRepository(mine)
+		err := repo.Pull()
+
+		var conflict *git.ConflictError
+		is.True(errors.As(err, &conflict))
+		is.Equal(conflict.Files, []string{"todo.txt"})
+		is.True(strings.Contains(err.Error(), "todo.txt"))
+
+		content, readErr := os.ReadFile(filepath.Join(mine, "todo.txt"))
+		is.NoErr(readErr)
+		is.Equal(string(content), "(A) Shared task edited on desktop\n")
+	})
+}
+
+// slowJournal takes longer to record an event than the debounce period
+type slowJournal struct {
+	*file.Journal
+}
+
+func (j slowJournal) Record(event usecases.Event) error {
+	time.Sleep(50 * time.Millisecond)
+	return j.Journal.Record(event)
+}
+
+// newRepository commits the todo.txt in dir along with its archive and trash
+func newRepository(dir string, opts ...git.Option) *git.Repository {
+	fileRepo := file.NewRepository(filepath.Join(dir, "todo.txt"))
+	return git.NewRepository(fileRepo, dir, fileRepo.Files, opts...)
+}
+
+// newGitRepo creates an empty git repository with a committer identity
+func newGitRepo(t *testing.T) string {
+	t.Helper()
+	requireGit(t)
+	dir := t.TempDir()
+	runGit(t, dir, "init", "--quiet", "--initial-branch=main")
+	configureIdentity(t, dir)
+	return dir
+}
+
+// newClonePair creates a bare remote with one todo and two clones of it
+func newClonePair(t *testing.T) (mine, theirs string) {
+	t.Helper()
+	requireGit(t)
+	root := t.TempDir()
+	remote := filepath.Join(root, "remote.git")
+	runGit(t, root, "init", "--quiet", "--bare", "--initial-branch=main", remote)
+
+	seed := newGitRepo(t)
+	writeTodos(t, seed, "(A) Shared task\n")
+	runGit(t, seed, "add", ".")
+	runGit(t, seed, "commit", "-m", "Seed")
+	runGit(t, seed, "remote", "add", "origin", remote)
+	runGit(t, seed, "push", "--quiet", "-u", "origin", "main")
+
+	mine = filepath.Join(root, "mine")
+	theirs = filepath.Join(root, "theirs")
+	runGit(t, root, "clone", "--quiet", remote, mine)
+	runGit(t, root, "clone", "--quiet", remote, theirs)
+	configureIdentity(t, mine)
+	configureIdentity(t, theirs)
+	return mine, theirs
+}
+
+func configureIdentity(t *testing.T, dir string) {
+	t.Helper()
+	runGit(t, dir, "config", "user.name", "Test")
+	runGit(t, dir, "config", "user.email", "test@example.com")
+	runGit(t, dir, "config", "commit.gpgsign", "false")
+}
+
+func requireGit(t *testing.T) {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+}
+
+func runGit(t *testing.T, dir string, args ...string) string {
+	t.Helper()
+	out, err := exec.Command("git", append([]string{"-C", dir}, args...)...).CombinedOutput()
+	if err != nil {
+		t.Fatalf("git %s: %v\n%s", strings.Join(args, " "), err, out)
+	}
+	return strings.TrimSpace(string(out))
+}
+
+func commitCount(t *testing.T, dir string) int {
+	t.Helper()
+	out, err := exec.Command("git", "-C", dir, "rev-list", "--count", "HEAD").CombinedOutput()
+	if err != nil {
+		return 0 // no commits yet
+	}
+	count, err := strconv.Atoi(strings.TrimSpace(string(out)))
+	if err != nil {
+		t.Fatalf("unexpected rev-list output %q", out)
+	}
+	return count
+}
+
+func writeTodos(t *testing.T, dir, content string) {
+	t.Helper()
+	writeFile(t, filepath.Join(dir, "todo.txt"), content)
+}
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	//nolint:gosec // G306: test file permissions intentionally match production (0o644)
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("failed to write %s: %v", filepath.Base(path), err)
+	}
+}
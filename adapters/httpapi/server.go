@@ -0,0 +1,455 @@
+// Package httpapi serves the use cases over a small JSON REST API, so browser extensions, launchers
+// and scripts can read and change the todo list without the matrix.
+//
+// Todos are identified by the ids the CLI subcommands use: their position in the matrix, counting from
+// the first Do First todo (not their line number in the todo file). Todos and the inventory are
+// written in the jsonexport schema, and errors as {"error": "message"}. Request bodies must be sent
+// as application/json.
+//
+// The API is for the machine it runs on: requests must name a loopback Host (or one allowed with
+// WithAllowedHosts), so web pages can't reach it through DNS rebinding, and requests from other web
+// sites are refused, so pages can't change todos behind the user's back. Browser extensions may call
+// it. WithToken also requires an "Authorization: Bearer" token.
+//
+//	GET    /todos                 the matrix and its inventory (?quadrant=schedule&filter=+project)
+//	POST   /todos                 add {"todo": "(A) description due:tomorrow", "quadrant": "schedule"}
+//	GET    /todos/{id}            one todo
+//	PUT    /todos/{id}            edit {"todo": "(B) new description +project"}
+//	POST   /todos/{id}/toggle     complete or reopen
+//	POST   /todos/{id}/move       move {"quadrant": "delegate"}
+//	POST   /todos/{id}/archive    archive a completed todo
+//	DELETE /todos/{id}            move to the trash
+//	POST   /archive               archive every completed todo
+//	GET    /inventory             the inventory
+package httpapi
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"mime"
+	"net"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/quii/todo-eisenhower/adapters/jsonexport"
+	"github.com/quii/todo-eisenhower/domain/matrix"
+	"github.com/quii/todo-eisenhower/domain/todo"
+	"github.com/quii/todo-eisenhower/domain/todotxt"
+	"github.com/quii/todo-eisenhower/usecases"
+)
+
+// maxBodyBytes limits the size of request bodies
+const maxBodyBytes = 1 << 20
+
+// quadrants are listed in matrix order, which is also the order of the todo ids
+var quadrants = []matrix.QuadrantType{
+	matrix.DoFirstQuadrant, matrix.ScheduleQuadrant, matrix.DelegateQuadrant, matrix.EliminateQuadrant, matrix.BacklogQuadrant,
+}
+
+// extensionSchemes are the origins of browser extensions, which the user installed and may call the API
+var extensionSchemes = []string{"chrome-extension", "moz-extension", "safari-web-extension"}
+
+// Server handles API requests against a todo repository
+// Requests are handled one at a time, and each loads the todo list afresh so changes saved by the
+// CLI or the matrix between requests are kept. The file isn't locked, though: a matrix that saves
+// the todos it loaded earlier overwrites what the API changed in the meantime.
+type Server struct {
+	repo         usecases.TodoRepository
+	now          func() time.Time
+	stalePolicy  todo.StalePolicy
+	token        string   // required as a bearer token when set
+	allowedHosts []string // accepted in the Host header as well as loopback names and addresses
+	mu           sync.Mutex
+	mux          *http.ServeMux
+}
+
+// Option configures optional behaviour of a Server
+type Option func(*Server)
+
+// WithClock sets the clock used to expand date shortcuts such as due:tomorrow (time.Now by default)
+func WithClock(now func() time.Time) Option {
+	return func(s *Server) {
+		s.now = now
+	}
+}
+
+// WithStalePolicy sets when todos are flagged as stale (todo.DefaultStalePolicy by default)
+func WithStalePolicy(policy todo.StalePolicy) Option {
+	return func(s *Server) {
+		s.stalePolicy = policy
+	}
+}
+
+// WithToken requires every request to send "Authorization: Bearer token"
+func WithToken(token string) Option {
+	return func(s *Server) {
+		s.token = token
+	}
+}
+
+// WithAllowedHosts accepts requests naming these hosts, for a server listening on an address other than loopback
+func WithAllowedHosts(hosts ...string) Option {
+	return func(s *Server) {
+		s.allowedHosts = append(s.allowedHosts, hosts...)
+	}
+}
+
+// NewServer creates a Server that reads and writes todos through repo
+func NewServer(repo usecases.TodoRepository, opts ...Option) *Server {
+	s := &Server{repo: repo, now: time.Now, stalePolicy: todo.DefaultStalePolicy, mux: http.NewServeMux()}
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	s.mux.HandleFunc("GET /todos", s.handle(s.list))
+	s.mux.HandleFunc("POST /todos", s.handle(s.add))
+	s.mux.HandleFunc("GET /todos/{id}", s.handle(s.get))
+	s.mux.HandleFunc("PUT /todos/{id}", s.handle(s.edit))
+	s.mux.HandleFunc("POST /todos/{id}/toggle", s.handle(s.toggle))
+	s.mux.HandleFunc("POST /todos/{id}/move", s.handle(s.move))
+	s.mux.HandleFunc("POST /todos/{id}/archive", s.handle(s.archive))
+	s.mux.HandleFunc("DELETE /todos/{id}", s.handle(s.remove))
+	s.mux.HandleFunc("POST /archive", s.handle(s.archiveAll))
+	s.mux.HandleFunc("GET /inventory", s.handle(s.inventory))
+	return s
+}
+
+// ServeHTTP routes a request to its handler
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mux.ServeHTTP(w, r)
+}
+
+// apiError is a mistake in a request, reported with its HTTP status
+type apiError struct {
+	status  int
+	message string
+}
+
+func (e apiError) Error() string {
+	return e.message
+}
+
+func errStatus(status int, format string, args ...any) error {
+	return apiError{status: status, message: fmt.Sprintf(format, args...)}
+}
+
+// response is what a handler writes: a status and a JSON body, or no body when body is nil
+type response struct {
+	status int
+	body   any
+}
+
+// handle runs a handler with the todo list loaded, one request at a time, and writes its response
+func (s *Server) handle(h func(r *http.Request, m matrix.Matrix) (response, error)) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		r.Body = http.MaxBytesReader(w, r.Body, maxBodyBytes)
+
+		res, err := s.run(h, r)
+		if err != nil {
+			status := http.StatusInternalServerError
+			var apiErr apiError
+			if errors.As(err, &apiErr) {
+				status = apiErr.status
+			}
+			res = response{status: status, body: map[string]string{"error": err.Error()}}
+		}
+
+		if res.body == nil {
+			w.WriteHeader(res.status)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(res.status)
+		_ = json.NewEncoder(w).Encode(res.body)
+	}
+}
+
+// run checks the request may use the API, then loads the todo list and runs a handler with it
+func (s *Server) run(h func(r *http.Request, m matrix.Matrix) (response, error), r *http.Request) (response, error) {
+	if err := s.check(r); err != nil {
+		return response{}, err
+	}
+	m, err := usecases.LoadMatrix(s.repo)
+	if err != nil {
+		return response{}, err
+	}
+	return h(r, m)
+}
+
+// check refuses requests for another host, from other web sites and without the token
+func (s *Server) check(r *http.Request) error {
+	host, _, err := net.SplitHostPort(r.Host)
+	if err != nil {
+		host = r.Host
+	}
+	host = strings.Trim(host, "[]")
+	ip := net.ParseIP(host)
+	if !strings.EqualFold(host, "localhost") && (ip == nil || !ip.IsLoopback()) && !slices.Contains(s.allowedHosts, host) {
+		return errStatus(http.StatusForbidden, "host %q isn't allowed", r.Host)
+	}
+
+	if origin := r.Header.Get("Origin"); origin != "" {
+		u, err := url.Parse(origin)
+		if err != nil || (u.Host != r.Host && !slices.Contains(extensionSchemes, u.Scheme)) {
+			return errStatus(http.StatusForbidden, "requests from %s aren't allowed", origin)
+		}
+	}
+
+	// Forms and other simple requests a web page can send without asking are never JSON
+	if r.Method != http.MethodGet && r.Header.Get("Content-Type") != "" && !isJSON(r) {
+		return errStatus(http.StatusUnsupportedMediaType, "request body must be sent as application/json")
+	}
+
+	if s.token != "" {
+		token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if !ok || subtle.ConstantTimeCompare([]byte(token), []byte(s.token)) != 1 {
+			return errStatus(http.StatusUnauthorized, "missing or wrong bearer token")
+		}
+	}
+	return nil
+}
+
+// list writes the matrix and its inventory, optionally only one quadrant or the todos matching a filter
+func (s *Server) list(r *http.Request, m matrix.Matrix) (response, error) {
+	listed := quadrants
+	if name := r.URL.Query().Get("quadrant"); name != "" {
+		quadrant, err := parseQuadrant(name)
+		if err != nil {
+			return response{}, err
+		}
+		listed = []matrix.QuadrantType{quadrant}
+	}
+	filter := r.URL.Query().Get("filter")
+
+	now := s.now()
+	doc := jsonexport.Build(m, s.stalePolicy, now)
+	doc.Todos = []jsonexport.Todo{}
+	for _, quadrant := range listed {
+		for index, t := range m.GetTodosForQuadrant(quadrant) {
+			if filter != "" && !matrix.MatchesFilter(t, filter) {
+				continue
+			}
+			doc.Todos = append(doc.Todos, jsonexport.BuildTodo(m, quadrant, index, s.stalePolicy, now))
+		}
+	}
+	return response{status: http.StatusOK, body: doc}, nil
+}
+
+// addRequest is the body of POST /todos
+type addRequest struct {
+	Todo     string `json:"todo"`               // todo.txt text; a leading "(A)" sets the quadrant
+	Quadrant string `json:"quadrant,omitempty"` // used when the text has no priority
+}
+
+// add creates a todo; a leading "(A)" priority wins over the quadrant, and without either the todo goes to Eliminate
+func (s *Server) add(r *http.Request, m matrix.Matrix) (response, error) {
+	var req addRequest
+	if err := decode(r, &req); err != nil {
+		return response{}, err
+	}
+
+	priority, description, hasPriority := todotxt.SplitPriority(strings.TrimSpace(req.Todo))
+	if description == "" {
+		return response{}, errStatus(http.StatusBadRequest, "missing todo description")
+	}
+	if !hasPriority && req.Quadrant != "" {
+		quadrant, err := parseQuadrant(req.Quadrant)
+		if err != nil {
+			return response{}, err
+		}
+		priority = matrix.PriorityFor(quadrant)
+	}
+
+	description = todotxt.ExpandDateShortcuts(description, s.now())
+	m, err := usecases.AddTodo(s.repo, m, description, priority)
+	if err != nil {
+		return response{}, err
+	}
+
+	// The new todo is the last in its quadrant
+	quadrant := matrix.QuadrantFor(priority)
+	return s.todoResponse(http.StatusCreated, m, quadrant, len(m.GetTodosForQuadrant(quadrant))-1), nil
+}
+
+// get writes one todo
+func (s *Server) get(r *http.Request, m matrix.Matrix) (response, error) {
+	quadrant, index, err := locate(r, m)
+	if err != nil {
+		return response{}, err
+	}
+	return s.todoResponse(http.StatusOK, m, quadrant, index), nil
+}
+
+// editRequest is the body of PUT /todos/{id}
+type editRequest struct {
+	Todo string `json:"todo"` // todo.txt text; a leading "(B)" also moves the todo
+}
+
+// edit replaces a todo's description and tags, keeping its dates; a leading "(B)" also moves it
+func (s *Server) edit(r *http.Request, m matrix.Matrix) (response, error) {
+	quadrant, index, err := locate(r, m)
+	if err != nil {
+		return response{}, err
+	}
+	var req editRequest
+	if err := decode(r, &req); err != nil {
+		return response{}, err
+	}
+
+	priority, description, hasPriority := todotxt.SplitPriority(strings.TrimSpace(req.Todo))
+	if description == "" {
+		return response{}, errStatus(http.StatusBadRequest, "missing todo description")
+	}
+
+	description = todotxt.ExpandDateShortcuts(description, s.now())
+	if m, err = usecases.EditTodo(s.repo, m, quadrant, index, description); err != nil {
+		return response{}, err
+	}
+
+	if hasPriority && matrix.QuadrantFor(priority) != quadrant {
+		target := matrix.QuadrantFor(priority)
+		if m, err = usecases.ChangePriority(s.repo, m, quadrant, index, priority); err != nil {
+			return response{}, err
+		}
+		quadrant, index = target, len(m.GetTodosForQuadrant(target))-1
+	}
+	return s.todoResponse(http.StatusOK, m, quadrant, index), nil
+}
+
+// toggle completes an open todo or reopens a completed one
+func (s *Server) toggle(r *http.Request, m matrix.Matrix) (response, error) {
+	quadrant, index, err := locate(r, m)
+	if err != nil {
+		return response{}, err
+	}
+	if m, err = usecases.ToggleCompletion(s.repo, m, quadrant, index); err != nil {
+		return response{}, err
+	}
+	return s.todoResponse(http.StatusOK, m, quadrant, index), nil
+}
+
+// moveRequest is the body of POST /todos/{id}/move
+type moveRequest struct {
+	Quadrant string `json:"quadrant"`
+}
+
+// move moves a todo to another quadrant; moving it to the quadrant it is in leaves it alone
+func (s *Server) move(r *http.Request, m matrix.Matrix) (response, error) {
+	quadrant, index, err := locate(r, m)
+	if err != nil {
+		return response{}, err
+	}
+	var req moveRequest
+	if err := decode(r, &req); err != nil {
+		return response{}, err
+	}
+	target, err := parseQuadrant(req.Quadrant)
+	if err != nil {
+		return response{}, err
+	}
+
+	if target == quadrant {
+		return s.todoResponse(http.StatusOK, m, quadrant, index), nil
+	}
+	if m, err = usecases.ChangePriority(s.repo, m, quadrant, index, matrix.PriorityFor(target)); err != nil {
+		return response{}, err
+	}
+	return s.todoResponse(http.StatusOK, m, target, len(m.GetTodosForQuadrant(target))-1), nil
+}
+
+// archive moves a completed todo to the archive
+func (s *Server) archive(r *http.Request, m matrix.Matrix) (response, error) {
+	quadrant, index, err := locate(r, m)
+	if err != nil {
+		return response{}, err
+	}
+	if !m.GetTodosForQuadrant(quadrant)[index].IsCompleted() {
+		return response{}, errStatus(http.StatusConflict, "todo %s isn't completed", r.PathValue("id"))
+	}
+	if _, err := usecases.ArchiveTodo(s.repo, m, quadrant, index); err != nil {
+		return response{}, err
+	}
+	return response{status: http.StatusNoContent}, nil
+}
+
+// archiveAll moves every completed todo to the archive
+func (s *Server) archiveAll(_ *http.Request, m matrix.Matrix) (response, error) {
+	before := len(m.AllTodosIncludingBacklog())
+	m, err := usecases.ArchiveAllCompleted(s.repo, m)
+	if err != nil {
+		return response{}, err
+	}
+	archived := before - len(m.AllTodosIncludingBacklog())
+	return response{status: http.StatusOK, body: map[string]int{"archived": archived}}, nil
+}
+
+// remove moves a todo to the trash
+func (s *Server) remove(r *http.Request, m matrix.Matrix) (response, error) {
+	quadrant, index, err := locate(r, m)
+	if err != nil {
+		return response{}, err
+	}
+	if _, err := usecases.DeleteTodo(s.repo, m, m.GetTodosForQuadrant(quadrant)[index]); err != nil {
+		return response{}, err
+	}
+	return response{status: http.StatusNoContent}, nil
+}
+
+// inventory writes the work-in-progress analysis of the matrix
+func (s *Server) inventory(_ *http.Request, m matrix.Matrix) (response, error) {
+	return response{status: http.StatusOK, body: jsonexport.BuildInventory(m, s.now())}, nil
+}
+
+func (s *Server) todoResponse(status int, m matrix.Matrix, quadrant matrix.QuadrantType, index int) response {
+	return response{status: status, body: jsonexport.BuildTodo(m, quadrant, index, s.stalePolicy, s.now())}
+}
+
+// decode reads a JSON request body
+func decode(r *http.Request, v any) error {
+	if !isJSON(r) {
+		return errStatus(http.StatusUnsupportedMediaType, "request body must be sent as application/json")
+	}
+	decoder := json.NewDecoder(r.Body)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(v); err != nil {
+		return errStatus(http.StatusBadRequest, "invalid request body: %v", err)
+	}
+	return nil
+}
+
+func isJSON(r *http.Request) bool {
+	mediaType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	return err == nil && mediaType == "application/json"
+}
+
+// parseQuadrant reads a quadrant name such as do-first
+func parseQuadrant(name string) (matrix.QuadrantType, error) {
+	quadrant, ok := matrix.ParseQuadrant(name)
+	if !ok {
+		return quadrant, errStatus(http.StatusBadRequest, "unknown quadrant %q (expected do-first, schedule, delegate, eliminate or backlog)", name)
+	}
+	return quadrant, nil
+}
+
+// locate finds the todo with the id in the request's path
+func locate(r *http.Request, m matrix.Matrix) (quadrant matrix.QuadrantType, index int, err error) {
+	arg := r.PathValue("id")
+	id, err := strconv.Atoi(arg)
+	if err != nil {
+		return quadrant, 0, errStatus(http.StatusBadRequest, "invalid todo id %q: expected a number", arg)
+	}
+	quadrant, index, ok := m.Locate(id)
+	if !ok {
+		return quadrant, 0, errStatus(http.StatusNotFound, "no todo with id %d", id)
+	}
+	return quadrant, index, nil
+}
@@ -0,0 +1,327 @@
+package httpapi_test
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/matryer/is"
+	"github.com/quii/todo-eisenhower/adapters/httpapi"
+	"github.com/quii/todo-eisenhower/adapters/jsonexport"
+	"github.com/quii/todo-eisenhower/adapters/memory"
+	"github.com/quii/todo-eisenhower/domain/todotxt"
+)
+
+// today is a Tuesday, so due:tomorrow is 2026-01-21
+var today = time.Date(2026, 1, 20, 9, 0, 0, 0, time.UTC)
+
+// serve starts a server holding the given todo.txt lines
+func serve(t *testing.T, lines string) (*httptest.Server, *memory.Repository) {
+	t.Helper()
+	todos, err := todotxt.Unmarshal(strings.NewReader(lines))
+	if err != nil {
+		t.Fatal(err)
+	}
+	repo := memory.NewRepository()
+	if err := repo.SaveAll(todos); err != nil {
+		t.Fatal(err)
+	}
+
+	server := httptest.NewServer(httpapi.NewServer(repo, httpapi.WithClock(func() time.Time { return today })))
+	t.Cleanup(server.Close)
+	return server, repo
+}
+
+// call sends a request, with a JSON body when there is one, and decodes the JSON response into out, returning the status
+func call(t *testing.T, server *httptest.Server, method, path, body string, out any) int {
+	t.Helper()
+	req, err := http.NewRequest(method, server.URL+path, strings.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if body != "" {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	return send(t, server, req, out)
+}
+
+// send sends a request and decodes the JSON response into out, returning the status
+func send(t *testing.T, server *httptest.Server, req *http.Request, out any) int {
+	t.Helper()
+	res, err := server.Client().Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = res.Body.Close() }()
+
+	if out != nil {
+		if err := json.NewDecoder(res.Body).Decode(out); err != nil {
+			t.Fatal(err)
+		}
+	} else {
+		_, _ = io.Copy(io.Discard, res.Body)
+	}
+	return res.StatusCode
+}
+
+func TestList(t *testing.T) {
+	server, _ := serve(t, "(B) Plan roadmap +product\n(A) Fix prod +ops\n(C) Reply to emails @office\n")
+
+	t.Run("lists the matrix with ids and the inventory", func(t *testing.T) {
+		//nolint:gocritic // importShadow: is := is.New(t) is idiomatic for github.com/matryer/is
+		is := is.New(t)
+		var doc jsonexport.Document
+		is.Equal(call(t, server, http.MethodGet, "/todos", "", &doc), http.StatusOK)
+		is.Equal(len(doc.Todos), 3)
+		is.Equal(doc.Todos[0].ID, 1)
+		is.Equal(doc.Todos[0].Description, "Fix prod")
+		is.Equal(doc.Todos[0].Quadrant, "do-first")
+		is.Equal(doc.Inventory.TotalActive, 3)
+	})
+
+	t.Run("filters by quadrant and tag", func(t *testing.T) {
+		is := is.New(t)
+		var doc jsonexport.Document
+		is.Equal(call(t, server, http.MethodGet, "/todos?quadrant=schedule", "", &doc), http.StatusOK)
+		is.Equal(len(doc.Todos), 1)
+		is.Equal(doc.Todos[0].ID, 2)
+
+		is.Equal(call(t, server, http.MethodGet, "/todos?filter=@office", "", &doc), http.StatusOK)
+		is.Equal(len(doc.Todos), 1)
+		is.Equal(doc.Todos[0].Description, "Reply to emails")
+	})
+
+	t.Run("rejects an unknown quadrant", func(t *testing.T) {
+		is := is.New(t)
+		var body map[string]string
+		is.Equal(call(t, server, http.MethodGet, "/todos?quadrant=someday", "", &body), http.StatusBadRequest)
+		is.True(strings.Contains(body["error"], "unknown quadrant"))
+	})
+}
+
+func TestGet(t *testing.T) {
+	is := is.New(t)
+	server, _ := serve(t, "(A) Fix prod\n")
+
+	var got jsonexport.Todo
+	is.Equal(call(t, server, http.MethodGet, "/todos/1", "", &got), http.StatusOK)
+	is.Equal(got.Line, "(A) Fix prod")
+
+	var body map[string]string
+	is.Equal(call(t, server, http.MethodGet, "/todos/2", "", &body), http.StatusNotFound)
+	is.Equal(body["error"], "no todo with id 2")
+	is.Equal(call(t, server, http.MethodGet, "/todos/first", "", &body), http.StatusBadRequest)
+}
+
+func TestAdd(t *testing.T) {
+	t.Run("adds to the quadrant of its priority, expanding date shortcuts", func(t *testing.T) {
+		is := is.New(t)
+		server, repo := serve(t, "(A) Fix prod\n")
+
+		var added jsonexport.Todo
+		is.Equal(call(t, server, http.MethodPost, "/todos", `{"todo": "(B) Plan roadmap +product due:tomorrow"}`, &added), http.StatusCreated)
+		is.Equal(added.ID, 2)
+		is.Equal(added.Quadrant, "schedule")
+		is.Equal(added.DueDate, "2026-01-21")
+		is.Equal(added.Projects, []string{"product"})
+
+		todos, err := repo.LoadAll()
+		is.NoErr(err)
+		is.Equal(len(todos), 2)
+	})
+
+	t.Run("adds to the quadrant given when there's no priority", func(t *testing.T) {
+		is := is.New(t)
+		server, _ := serve(t, "")
+
+		var added jsonexport.Todo
+		is.Equal(call(t, server, http.MethodPost, "/todos", `{"todo": "Call mum", "quadrant": "delegate"}`, &added), http.StatusCreated)
+		is.Equal(added.Quadrant, "delegate")
+		is.Equal(added.Priority, "C")
+	})
+
+	t.Run("rejects bad requests", func(t *testing.T) {
+		is := is.New(t)
+		server, repo := serve(t, "")
+
+		var body map[string]string
+		is.Equal(call(t, server, http.MethodPost, "/todos", `{"todo": "  "}`, &body), http.StatusBadRequest)
+		is.Equal(body["error"], "missing todo description")
+		is.Equal(call(t, server, http.MethodPost, "/todos", `{"text": "Call mum"}`, &body), http.StatusBadRequest)
+		is.Equal(call(t, server, http.MethodPost, "/todos", `not json`, &body), http.StatusBadRequest)
+
+		todos, err := repo.LoadAll()
+		is.NoErr(err)
+		is.Equal(len(todos), 0)
+	})
+}
+
+func TestEdit(t *testing.T) {
+	is := is.New(t)
+	server, repo := serve(t, "(A) 2026-01-01 Fix prod\n(B) Plan roadmap\n")
+
+	var edited jsonexport.Todo
+	is.Equal(call(t, server, http.MethodPut, "/todos/1", `{"todo": "Fix prod database +ops"}`, &edited), http.StatusOK)
+	is.Equal(edited.Line, "(A) 2026-01-01 Fix prod database +ops")
+
+	is.Equal(call(t, server, http.MethodPut, "/todos/1", `{"todo": "(C) Fix prod database +ops"}`, &edited), http.StatusOK)
+	is.Equal(edited.Quadrant, "delegate")
+	is.Equal(edited.ID, 2)
+	is.Equal(repo.String(), "(B) Plan roadmap\n(C) 2026-01-01 Fix prod database +ops\n")
+}
+
+func TestToggle(t *testing.T) {
+	is := is.New(t)
+	server, _ := serve(t, "(A) Fix prod\n")
+
+	var toggled jsonexport.Todo
+	is.Equal(call(t, server, http.MethodPost, "/todos/1/toggle", "", &toggled), http.StatusOK)
+	is.True(toggled.Completed)
+	is.Equal(call(t, server, http.MethodPost, "/todos/1/toggle", "", &toggled), http.StatusOK)
+	is.True(!toggled.Completed)
+}
+
+func TestMove(t *testing.T) {
+	is := is.New(t)
+	server, repo := serve(t, "(A) Fix prod\n(B) Plan roadmap\n")
+
+	var moved jsonexport.Todo
+	is.Equal(call(t, server, http.MethodPost, "/todos/1/move", `{"quadrant": "schedule"}`, &moved), http.StatusOK)
+	is.Equal(moved.ID, 2)
+	is.Equal(moved.Quadrant, "schedule")
+	is.Equal(repo.String(), "(B) Plan roadmap\n(B) Fix prod\n")
+
+	var body map[string]string
+	is.Equal(call(t, server, http.MethodPost, "/todos/1/move", `{"quadrant": "later"}`, &body), http.StatusBadRequest)
+}
+
+func TestArchive(t *testing.T) {
+	t.Run("archives a completed todo", func(t *testing.T) {
+		is := is.New(t)
+		server, repo := serve(t, "x 2026-01-19 (A) Fix prod\n(B) Plan roadmap\n")
+
+		is.Equal(call(t, server, http.MethodPost, "/todos/1/archive", "", nil), http.StatusNoContent)
+		is.Equal(repo.String(), "(B) Plan roadmap\n")
+		is.Equal(repo.ArchiveString(), "x 2026-01-19 (A) Fix prod\n")
+
+		var body map[string]string
+		is.Equal(call(t, server, http.MethodPost, "/todos/1/archive", "", &body), http.StatusConflict)
+		is.Equal(body["error"], "todo 1 isn't completed")
+	})
+
+	t.Run("archives every completed todo", func(t *testing.T) {
+		is := is.New(t)
+		server, repo := serve(t, "x (A) Fix prod\n(B) Plan roadmap\nx (C) Reply to emails\n")
+
+		var body map[string]int
+		is.Equal(call(t, server, http.MethodPost, "/archive", "", &body), http.StatusOK)
+		is.Equal(body["archived"], 2)
+		is.Equal(repo.String(), "(B) Plan roadmap\n")
+	})
+}
+
+func TestDelete(t *testing.T) {
+	is := is.New(t)
+	server, repo := serve(t, "(A) Fix prod\n(B) Plan roadmap\n")
+
+	is.Equal(call(t, server, http.MethodDelete, "/todos/1", "", nil), http.StatusNoContent)
+	is.Equal(repo.String(), "(B) Plan roadmap\n")
+
+	trash, err := repo.LoadTrash()
+	is.NoErr(err)
+	is.Equal(len(trash), 1)
+	is.Equal(trash[0].Description(), "Fix prod")
+}
+
+func TestInventory(t *testing.T) {
+	is := is.New(t)
+	server, _ := serve(t, "(A) 2026-01-10 Fix prod +ops\n(A) 2026-01-16 Restart workers +ops\n(B) 2026-01-18 Plan roadmap\n")
+
+	var inventory jsonexport.Inventory
+	is.Equal(call(t, server, http.MethodGet, "/inventory", "", &inventory), http.StatusOK)
+	is.Equal(inventory.TotalActive, 3)
+	is.Equal(inventory.Quadrants["do-first"], jsonexport.QuadrantMetrics{Active: 2, OldestDays: 10})
+	is.Equal(inventory.Projects, []jsonexport.TagMetrics{{Tag: "ops", Count: 2, AvgAgeDays: 7}})
+}
+
+func TestChangesMadeElsewhereArePickedUp(t *testing.T) {
+	is := is.New(t)
+	server, repo := serve(t, "(A) Fix prod\n")
+
+	todos, err := todotxt.Unmarshal(strings.NewReader("(A) Fix prod\n(B) Plan roadmap\n"))
+	is.NoErr(err)
+	is.NoErr(repo.SaveAll(todos))
+
+	var added jsonexport.Todo
+	is.Equal(call(t, server, http.MethodPost, "/todos", `{"todo": "(C) Reply to emails"}`, &added), http.StatusCreated)
+	is.Equal(added.ID, 3)
+	is.Equal(len(strings.Split(strings.TrimSpace(repo.String()), "\n")), 3)
+}
+
+func TestRequestsFromElsewhere(t *testing.T) {
+	request := func(t *testing.T, server *httptest.Server, method, path, body string, header http.Header) *http.Request {
+		t.Helper()
+		req, err := http.NewRequest(method, server.URL+path, strings.NewReader(body))
+		if err != nil {
+			t.Fatal(err)
+		}
+		req.Header = header
+		return req
+	}
+
+	t.Run("refuses hosts other than loopback, so DNS rebinding can't reach it", func(t *testing.T) {
+		is := is.New(t)
+		server, _ := serve(t, "(A) Fix prod\n")
+
+		req := request(t, server, http.MethodGet, "/todos", "", http.Header{})
+		req.Host = "attacker.example:8080"
+		var body map[string]string
+		is.Equal(send(t, server, req, &body), http.StatusForbidden)
+		is.Equal(body["error"], `host "attacker.example:8080" isn't allowed`)
+
+		req.Host = "localhost:8080"
+		is.Equal(send(t, server, req, nil), http.StatusOK)
+	})
+
+	t.Run("refuses requests from other web sites but not browser extensions", func(t *testing.T) {
+		is := is.New(t)
+		server, repo := serve(t, "(A) Fix prod\n")
+
+		req := request(t, server, http.MethodPost, "/todos/1/toggle", "", http.Header{"Origin": {"https://attacker.example"}})
+		is.Equal(send(t, server, req, nil), http.StatusForbidden)
+		is.Equal(repo.String(), "(A) Fix prod\n")
+
+		req = request(t, server, http.MethodPost, "/todos/1/toggle", "", http.Header{"Origin": {"moz-extension://2c127fa4-62c7-7e4f-90e5-472b45eecfdc"}})
+		is.Equal(send(t, server, req, nil), http.StatusOK)
+	})
+
+	t.Run("only accepts JSON bodies", func(t *testing.T) {
+		is := is.New(t)
+		server, repo := serve(t, "(A) Fix prod\n")
+
+		var body map[string]string
+		req := request(t, server, http.MethodPost, "/todos", `{"todo": "Call mum"}`, http.Header{})
+		is.Equal(send(t, server, req, &body), http.StatusUnsupportedMediaType)
+		is.Equal(body["error"], "request body must be sent as application/json")
+
+		req = request(t, server, http.MethodPost, "/todos/1/toggle", "todo=1", http.Header{"Content-Type": {"application/x-www-form-urlencoded"}})
+		is.Equal(send(t, server, req, nil), http.StatusUnsupportedMediaType)
+		is.Equal(repo.String(), "(A) Fix prod\n")
+	})
+
+	t.Run("requires the token when there is one", func(t *testing.T) {
+		is := is.New(t)
+		server := httptest.NewServer(httpapi.NewServer(memory.NewRepository(), httpapi.WithToken("s3cret")))
+		t.Cleanup(server.Close)
+
+		var body map[string]string
+		is.Equal(send(t, server, request(t, server, http.MethodGet, "/todos", "", http.Header{}), &body), http.StatusUnauthorized)
+		is.Equal(body["error"], "missing or wrong bearer token")
+		is.Equal(send(t, server, request(t, server, http.MethodGet, "/todos", "", http.Header{"Authorization": {"Bearer guess"}}), nil), http.StatusUnauthorized)
+		is.Equal(send(t, server, request(t, server, http.MethodGet, "/todos", "", http.Header{"Authorization": {"Bearer s3cret"}}), nil), http.StatusOK)
+	})
+}
@@ -0,0 +1,187 @@
+// Package jsonexport writes the matrix and its inventory as JSON for dashboards, bots and scripts.
+//
+// The schema is versioned: fields are only ever added within a version, and SchemaVersion changes
+// if a field is removed, renamed or changes meaning. Dates are "YYYY-MM-DD" and missing dates are
+// left out. Lists are always present, even when empty.
+package jsonexport
+
+import (
+	"encoding/json"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/quii/todo-eisenhower/domain/matrix"
+	"github.com/quii/todo-eisenhower/domain/todo"
+	"github.com/quii/todo-eisenhower/domain/todotxt"
+)
+
+// SchemaVersion is the version of the document written by Write
+const SchemaVersion = 1
+
+// Document is the top-level JSON document
+type Document struct {
+	SchemaVersion int       `json:"schema_version"`
+	GeneratedAt   string    `json:"generated_at"` // RFC 3339
+	Todos         []Todo    `json:"todos"`
+	Inventory     Inventory `json:"inventory"`
+}
+
+// Todo is one todo with everything known about it
+type Todo struct {
+	ID              int      `json:"id"` // position in matrix order (Do First first), as used by the CLI subcommands
+	Quadrant        string   `json:"quadrant"`
+	Priority        string   `json:"priority,omitempty"`
+	Description     string   `json:"description"`
+	Completed       bool     `json:"completed"`
+	CreationDate    string   `json:"creation_date,omitempty"`
+	CompletionDate  string   `json:"completion_date,omitempty"`
+	DueDate         string   `json:"due_date,omitempty"`
+	PrioritisedDate string   `json:"prioritised_date,omitempty"`
+	Projects        []string `json:"projects"`
+	Contexts        []string `json:"contexts"`
+	Source          string   `json:"source,omitempty"` // workspace file the todo lives in
+	Stale           bool     `json:"stale"`
+	Overdue         bool     `json:"overdue"`
+	Line            string   `json:"line"` // the todo in todo.txt format
+}
+
+// Inventory is the work-in-progress analysis of the matrix
+type Inventory struct {
+	Quadrants   map[string]QuadrantMetrics `json:"quadrants"` // keyed by quadrant name, e.g. "do-first"
+	TotalActive int                        `json:"total_active"`
+	Throughput  Throughput                 `json:"throughput"`
+	Projects    []TagMetrics               `json:"projects"` // most todos first
+	Contexts    []TagMetrics               `json:"contexts"`
+}
+
+// QuadrantMetrics are the active todos in a quadrant and how long the oldest has waited
+type QuadrantMetrics struct {
+	Active     int `json:"active"`
+	OldestDays int `json:"oldest_days"`
+}
+
+// Throughput is how many todos were completed and added recently
+type Throughput struct {
+	CompletedLast7Days int `json:"completed_last_7_days"`
+	AddedLast7Days     int `json:"added_last_7_days"`
+}
+
+// TagMetrics are the active todos with a project or context
+type TagMetrics struct {
+	Tag        string `json:"tag"`
+	Count      int    `json:"count"`
+	AvgAgeDays int    `json:"avg_age_days"`
+}
+
+// quadrants are exported in matrix order, which is also the order of the todo ids
+var quadrants = []matrix.QuadrantType{
+	matrix.DoFirstQuadrant, matrix.ScheduleQuadrant, matrix.DelegateQuadrant, matrix.EliminateQuadrant, matrix.BacklogQuadrant,
+}
+
+// Build creates the document for a matrix; stalePolicy decides which todos are flagged stale
+func Build(m matrix.Matrix, stalePolicy todo.StalePolicy, now time.Time) Document {
+	doc := Document{
+		SchemaVersion: SchemaVersion,
+		GeneratedAt:   now.Format(time.RFC3339),
+		Todos:         []Todo{},
+		Inventory:     buildInventory(matrix.NewInventory(m, now)),
+	}
+
+	for _, quadrant := range quadrants {
+		for index, t := range m.GetTodosForQuadrant(quadrant) {
+			doc.Todos = append(doc.Todos, buildTodo(t, m.Position(quadrant, index), quadrant, stalePolicy, now))
+		}
+	}
+	return doc
+}
+
+// BuildTodo creates the JSON for the todo at index in a quadrant
+func BuildTodo(m matrix.Matrix, quadrant matrix.QuadrantType, index int, stalePolicy todo.StalePolicy, now time.Time) Todo {
+	return buildTodo(m.GetTodosForQuadrant(quadrant)[index], m.Position(quadrant, index), quadrant, stalePolicy, now)
+}
+
+// BuildInventory creates the JSON for the matrix's work-in-progress analysis
+func BuildInventory(m matrix.Matrix, now time.Time) Inventory {
+	return buildInventory(matrix.NewInventory(m, now))
+}
+
+// Write builds the document for a matrix and writes it as indented JSON
+func Write(w io.Writer, m matrix.Matrix, stalePolicy todo.StalePolicy, now time.Time) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(Build(m, stalePolicy, now))
+}
+
+func buildTodo(t todo.Todo, id int, quadrant matrix.QuadrantType, stalePolicy todo.StalePolicy, now time.Time) Todo {
+	priority := ""
+	if t.Priority() != todo.PriorityNone {
+		priority = t.Priority().String()
+	}
+
+	return Todo{
+		ID:              id,
+		Quadrant:        quadrant.String(),
+		Priority:        priority,
+		Description:     t.Description(),
+		Completed:       t.IsCompleted(),
+		CreationDate:    formatDate(t.CreationDate()),
+		CompletionDate:  formatDate(t.CompletionDate()),
+		DueDate:         formatDate(t.DueDate()),
+		PrioritisedDate: formatDate(t.PrioritisedDate()),
+		Projects:        nonNil(t.Projects()),
+		Contexts:        nonNil(t.Contexts()),
+		Source:          t.Source(),
+		Stale:           stalePolicy.IsStale(t, now),
+		Overdue:         t.IsOverdue(now),
+		Line:            strings.TrimSuffix(t.String(), "\n"),
+	}
+}
+
+func buildInventory(inv matrix.Inventory) Inventory {
+	return Inventory{
+		Quadrants: map[string]QuadrantMetrics{
+			matrix.DoFirstQuadrant.String():   {Active: inv.DoFirstActive, OldestDays: inv.DoFirstOldestDays},
+			matrix.ScheduleQuadrant.String():  {Active: inv.ScheduleActive, OldestDays: inv.ScheduleOldestDays},
+			matrix.DelegateQuadrant.String():  {Active: inv.DelegateActive, OldestDays: inv.DelegateOldestDays},
+			matrix.EliminateQuadrant.String(): {Active: inv.EliminateActive, OldestDays: inv.EliminateOldestDays},
+		},
+		TotalActive: inv.TotalActive,
+		Throughput: Throughput{
+			CompletedLast7Days: inv.CompletedLast7Days,
+			AddedLast7Days:     inv.AddedLast7Days,
+		},
+		Projects: sortedTags(inv.ProjectBreakdown),
+		Contexts: sortedTags(inv.ContextBreakdown),
+	}
+}
+
+// sortedTags orders tag metrics by count (descending), then by tag
+func sortedTags(breakdown map[string]matrix.TagMetrics) []TagMetrics {
+	tags := make([]TagMetrics, 0, len(breakdown))
+	for _, metrics := range breakdown {
+		tags = append(tags, TagMetrics{Tag: metrics.Tag, Count: metrics.Count, AvgAgeDays: metrics.AvgAgeDays})
+	}
+	sort.Slice(tags, func(i, j int) bool {
+		if tags[i].Count == tags[j].Count {
+			return tags[i].Tag < tags[j].Tag
+		}
+		return tags[i].Count > tags[j].Count
+	})
+	return tags
+}
+
+func formatDate(date *time.Time) string {
+	if date == nil {
+		return ""
+	}
+	return date.Format(todotxt.DateFormat)
+}
+
+func nonNil(values []string) []string {
+	if values == nil {
+		return []string{}
+	}
+	return values
+}
@@ -0,0 +1,127 @@
+package jsonexport_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/matryer/is"
+	"github.com/quii/todo-eisenhower/adapters/jsonexport"
+	"github.com/quii/todo-eisenhower/domain/matrix"
+	"github.com/quii/todo-eisenhower/domain/todo"
+	"github.com/quii/todo-eisenhower/domain/todotxt"
+)
+
+// now is Tuesday 2026-01-20
+var now = time.Date(2026, 1, 20, 9, 0, 0, 0, time.UTC)
+
+func matrixOf(t *testing.T, lines string) matrix.Matrix {
+	t.Helper()
+	todos, err := todotxt.Unmarshal(strings.NewReader(lines))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return matrix.New(todos)
+}
+
+func TestWrite(t *testing.T) {
+	t.Run("writes the documented schema", func(t *testing.T) {
+		//nolint:gocritic // importShadow: is := is.New(t) is idiomatic for github.com/matryer/is
+		is := is.New(t)
+		m := matrixOf(t, "(B) 2026-01-19 Plan roadmap\n")
+
+		var buf bytes.Buffer
+		is.NoErr(jsonexport.Write(&buf, m, todo.DefaultStalePolicy, now))
+
+		is.Equal(buf.String(), `{
+  "schema_version": 1,
+  "generated_at": "2026-01-20T09:00:00Z",
+  "todos": [
+    {
+      "id": 1,
+      "quadrant": "schedule",
+      "priority": "B",
+      "description": "Plan roadmap",
+      "completed": false,
+      "creation_date": "2026-01-19",
+      "projects": [],
+      "contexts": [],
+      "stale": false,
+      "overdue": false,
+      "line": "(B) 2026-01-19 Plan roadmap"
+    }
+  ],
+  "inventory": {
+    "quadrants": {
+      "delegate": {
+        "active": 0,
+        "oldest_days": 0
+      },
+      "do-first": {
+        "active": 0,
+        "oldest_days": 0
+      },
+      "eliminate": {
+        "active": 0,
+        "oldest_days": 0
+      },
+      "schedule": {
+        "active": 1,
+        "oldest_days": 1
+      }
+    },
+    "total_active": 1,
+    "throughput": {
+      "completed_last_7_days": 0,
+      "added_last_7_days": 1
+    },
+    "projects": [],
+    "contexts": []
+  }
+}
+`)
+	})
+
+	t.Run("flags stale and overdue todos and gives ids in matrix order", func(t *testing.T) {
+		is := is.New(t)
+		m := matrixOf(t, "(C) 2026-01-01 Book venue +offsite @phone due:2026-01-10\n(A) 2026-01-19 Fix prod +ops prioritised:2026-01-19\n")
+
+		doc := jsonexport.Build(m, todo.DefaultStalePolicy, now)
+		is.Equal(len(doc.Todos), 2)
+
+		fix, venue := doc.Todos[0], doc.Todos[1]
+		is.Equal(fix.ID, 1)
+		is.Equal(fix.Quadrant, "do-first")
+		is.Equal(fix.PrioritisedDate, "2026-01-19")
+		is.True(!fix.Stale)
+
+		is.Equal(venue.ID, 2)
+		is.Equal(venue.Quadrant, "delegate")
+		is.Equal(venue.Projects, []string{"offsite"})
+		is.Equal(venue.Contexts, []string{"phone"})
+		is.Equal(venue.DueDate, "2026-01-10")
+		is.True(venue.Stale)
+		is.True(venue.Overdue)
+
+		// A stricter policy flags more
+		strict := jsonexport.Build(m, todo.StalePolicy{DoFirstDays: 0, OtherDays: 5}, now)
+		is.True(strict.Todos[0].Stale)
+	})
+
+	t.Run("includes the tag breakdowns, busiest first", func(t *testing.T) {
+		is := is.New(t)
+		m := matrixOf(t, "(A) 2026-01-19 Fix prod +ops\n(B) 2026-01-19 Plan roadmap +ops +product\n")
+
+		var buf bytes.Buffer
+		is.NoErr(jsonexport.Write(&buf, m, todo.DefaultStalePolicy, now))
+
+		var doc jsonexport.Document
+		is.NoErr(json.Unmarshal(buf.Bytes(), &doc))
+		is.Equal(doc.Inventory.TotalActive, 2)
+		is.Equal(len(doc.Inventory.Projects), 2)
+		is.Equal(doc.Inventory.Projects[0], jsonexport.TagMetrics{Tag: "ops", Count: 2, AvgAgeDays: 1})
+		is.Equal(doc.Inventory.Projects[1].Tag, "product")
+	})
+}
@@ -0,0 +1,204 @@
+// Package mailbox reads messages from local Maildir directories and mbox files, such as the folders
+// a mail client or mbsync keeps in step with a mail server.
+//
+// A message is flagged when its Maildir file name has the F flag, or in an mbox when its X-Status
+// header has an F or its X-Mozilla-Status header has Thunderbird's flagged bit. Labels come from
+// the X-Keywords, X-Label, Keywords and X-Gmail-Labels headers. Messages whose headers can't be
+// parsed are skipped and counted.
+package mailbox
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"mime"
+	"net/mail"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/quii/todo-eisenhower/usecases"
+)
+
+// labelHeaders hold the keywords and labels mail clients set
+var labelHeaders = []string{"X-Keywords", "X-Label", "Keywords", "X-Gmail-Labels"}
+
+// mozillaFlagged is the flagged bit of Thunderbird's X-Mozilla-Status header
+const mozillaFlagged = 0x0004
+
+// Read reads every message in the Maildir directory or mbox file at path
+func Read(path string) (usecases.Mailbox, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return usecases.Mailbox{}, err
+	}
+	if info.IsDir() {
+		return readMaildir(path)
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return usecases.Mailbox{}, err
+	}
+	defer func() { _ = f.Close() }()
+	return ReadMbox(f)
+}
+
+// readMaildir reads the messages in a Maildir's new and cur directories
+func readMaildir(dir string) (usecases.Mailbox, error) {
+	var box usecases.Mailbox
+	found := false
+	for _, sub := range []string{"new", "cur"} {
+		entries, err := os.ReadDir(filepath.Join(dir, sub))
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return usecases.Mailbox{}, err
+		}
+		found = true
+
+		for _, entry := range entries {
+			if entry.IsDir() || strings.HasPrefix(entry.Name(), ".") {
+				continue
+			}
+			content, err := os.ReadFile(filepath.Join(dir, sub, entry.Name()))
+			if err != nil {
+				return usecases.Mailbox{}, err
+			}
+			message, err := parse(content)
+			if err != nil {
+				box.Unreadable++
+				continue
+			}
+			message.Flagged = message.Flagged || maildirFlagged(entry.Name())
+			box.Messages = append(box.Messages, message)
+		}
+	}
+	if !found {
+		return usecases.Mailbox{}, fmt.Errorf("%s is not a Maildir: it has no cur or new directory", dir)
+	}
+	return box, nil
+}
+
+// maildirFlagged reads the F flag from a Maildir file name, as in 1700000000.M1P2.host:2,FS
+func maildirFlagged(name string) bool {
+	for _, separator := range []string{":2,", "!2,"} {
+		if _, flags, ok := strings.Cut(name, separator); ok {
+			return strings.Contains(flags, "F")
+		}
+	}
+	return false
+}
+
+// ReadMbox reads the messages in an mbox file
+// Messages start with a "From " line; ">From " lines in a message are unescaped.
+func ReadMbox(r io.Reader) (usecases.Mailbox, error) {
+	var box usecases.Mailbox
+	var current *bytes.Buffer
+	flush := func() {
+		if current == nil {
+			return
+		}
+		message, err := parse(current.Bytes())
+		if err != nil {
+			box.Unreadable++
+			return
+		}
+		box.Messages = append(box.Messages, message)
+	}
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16<<20)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if strings.HasPrefix(line, "From ") {
+			flush()
+			current = &bytes.Buffer{}
+			continue
+		}
+		if current == nil {
+			continue
+		}
+		if strings.HasPrefix(strings.TrimLeft(line, ">"), "From ") {
+			line = line[1:]
+		}
+		current.WriteString(line + "\n")
+	}
+	if err := scanner.Err(); err != nil {
+		return usecases.Mailbox{}, err
+	}
+	flush()
+	return box, nil
+}
+
+// parse reads the headers of a message
+func parse(content []byte) (usecases.MailMessage, error) {
+	msg, err := mail.ReadMessage(bytes.NewReader(content))
+	if err != nil {
+		return usecases.MailMessage{}, err
+	}
+	header := msg.Header
+	decoder := new(mime.WordDecoder)
+
+	subject, err := decoder.DecodeHeader(header.Get("Subject"))
+	if err != nil {
+		subject = header.Get("Subject")
+	}
+
+	from := header.Get("From")
+	if address, err := mail.ParseAddress(from); err == nil {
+		from = address.Address
+	}
+
+	id := strings.Trim(strings.TrimSpace(header.Get("Message-ID")), "<>")
+	if id == "" {
+		// Without a Message-ID, the sender, date and subject identify the message
+		sum := sha256.Sum256([]byte(from + "\n" + header.Get("Date") + "\n" + subject))
+		id = hex.EncodeToString(sum[:8]) + "@todo-eisenhower"
+	}
+
+	var labels []string
+	for _, name := range labelHeaders {
+		value, err := decoder.DecodeHeader(header.Get(name))
+		if err != nil {
+			value = header.Get(name)
+		}
+		labels = append(labels, splitLabels(value)...)
+	}
+
+	return usecases.MailMessage{
+		ID:      id,
+		From:    from,
+		Subject: subject,
+		Flagged: strings.Contains(header.Get("X-Status"), "F") || mozillaStatusFlagged(header.Get("X-Mozilla-Status")),
+		Labels:  labels,
+	}, nil
+}
+
+// splitLabels splits a label header on commas, as Gmail labels can have spaces, or on spaces when
+// there are no commas, as in Dovecot's keywords
+func splitLabels(value string) []string {
+	separator := func(r rune) bool { return r == ' ' || r == '\t' }
+	if strings.Contains(value, ",") {
+		separator = func(r rune) bool { return r == ',' }
+	}
+
+	var labels []string
+	for _, label := range strings.FieldsFunc(value, separator) {
+		if label = strings.Trim(strings.TrimSpace(label), `"`); label != "" {
+			labels = append(labels, label)
+		}
+	}
+	return labels
+}
+
+// mozillaStatusFlagged reads Thunderbird's X-Mozilla-Status, a hexadecimal bit field
+func mozillaStatusFlagged(status string) bool {
+	bits, err := strconv.ParseUint(strings.TrimSpace(status), 16, 32)
+	return err == nil && bits&mozillaFlagged != 0
+}
@@ -0,0 +1,122 @@
+package mailbox_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/matryer/is"
+	"github.com/quii/todo-eisenhower/adapters/mailbox"
+	"github.com/quii/todo-eisenhower/usecases"
+)
+
+func TestRead_Maildir(t *testing.T) {
+	//nolint:gocritic // importShadow: is := is.New(t) is idiomatic for github.com/matryer/is
+	is := is.New(t)
+	dir := t.TempDir()
+	write := func(name, content string) {
+		path := filepath.Join(dir, name)
+		is.NoErr(os.MkdirAll(filepath.Dir(path), 0o755))
+		is.NoErr(os.WriteFile(path, []byte(content), 0o644))
+	}
+	is.NoErr(os.MkdirAll(filepath.Join(dir, "tmp"), 0o755))
+
+	write("cur/1700000000.M1P1.host:2,FS", "From: Alice Smith <alice@example.com>\r\nSubject: =?UTF-8?Q?Caf=C3=A9_booking?=\r\nMessage-ID: <a1@example.com>\r\n\r\nBody\r\n")
+	write("cur/1700000001.M2P1.host:2,S", "From: bob@example.com\nSubject: Lunch?\nMessage-ID: <b2@example.com>\nX-Keywords: Inbox todo\n\nBody\n")
+	write("new/1700000002.M3P1.host", "From: carol@example.com\nSubject: Hello\nMessage-ID: <c3@example.com>\n\nBody\n")
+
+	box, err := mailbox.Read(dir)
+	is.NoErr(err)
+	is.Equal(len(box.Messages), 3)
+
+	byID := map[string]usecases.MailMessage{}
+	for _, message := range box.Messages {
+		byID[message.ID] = message
+	}
+	is.Equal(byID["a1@example.com"], usecases.MailMessage{ID: "a1@example.com", From: "alice@example.com", Subject: "Café booking", Flagged: true})
+	is.Equal(byID["b2@example.com"].Labels, []string{"Inbox", "todo"})
+	is.True(!byID["b2@example.com"].Flagged)
+	is.True(!byID["c3@example.com"].Flagged)
+}
+
+func TestRead_NotAMaildir(t *testing.T) {
+	is := is.New(t)
+	_, err := mailbox.Read(t.TempDir())
+	is.True(err != nil)
+}
+
+func TestReadMbox(t *testing.T) {
+	is := is.New(t)
+	mbox := strings.Join([]string{
+		"From alice@example.com Mon Jan 19 09:00:00 2026",
+		"From: alice@example.com",
+		"Subject: Contract review",
+		"Message-ID: <a1@example.com>",
+		"X-Status: F",
+		"",
+		">From the desk of Alice",
+		"",
+		"From bob@example.com Mon Jan 19 10:00:00 2026",
+		"From: bob@example.com",
+		"Subject: Lunch?",
+		"Message-ID: <b2@example.com>",
+		"X-Mozilla-Status: 0005",
+		"",
+		"Body",
+		"",
+		"From carol@example.com Mon Jan 19 11:00:00 2026",
+		"From: carol@example.com",
+		"Subject: No id",
+		"Date: Mon, 19 Jan 2026 11:00:00 +0000",
+		"X-Gmail-Labels: Important,Follow up",
+		"",
+		"Body",
+		"",
+	}, "\n")
+
+	box, err := mailbox.ReadMbox(strings.NewReader(mbox))
+	is.NoErr(err)
+	is.Equal(len(box.Messages), 3)
+	messages := box.Messages
+
+	is.Equal(messages[0].ID, "a1@example.com")
+	is.True(messages[0].Flagged)
+	is.Equal(messages[1].Subject, "Lunch?")
+	is.True(messages[1].Flagged) // Thunderbird's flagged bit
+
+	is.True(!messages[2].Flagged)
+	is.Equal(messages[2].Labels, []string{"Important", "Follow up"})
+	is.True(strings.HasSuffix(messages[2].ID, "@todo-eisenhower")) // made up from the headers
+
+	again, err := mailbox.ReadMbox(strings.NewReader(mbox))
+	is.NoErr(err)
+	is.Equal(again.Messages[2].ID, messages[2].ID)
+}
+
+func TestReadMbox_SkipsMessagesThatCantBeParsed(t *testing.T) {
+	is := is.New(t)
+	mbox := strings.Join([]string{
+		"From alice@example.com Mon Jan 19 09:00:00 2026",
+		"From: alice@example.com",
+		"Subject: Contract review",
+		"",
+		"Body",
+		"",
+		"From mailer-daemon Mon Jan 19 10:00:00 2026",
+		"this line is not a header",
+		"",
+		"From bob@example.com Mon Jan 19 11:00:00 2026",
+		"From: bob@example.com",
+		"Subject: Lunch?",
+		"",
+		"Body",
+		"",
+	}, "\n")
+
+	box, err := mailbox.ReadMbox(strings.NewReader(mbox))
+	is.NoErr(err)
+	is.Equal(box.Unreadable, 1)
+	is.Equal(len(box.Messages), 2)
+	is.Equal(box.Messages[1].Subject, "Lunch?")
+}
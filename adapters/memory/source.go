@@ -13,6 +13,7 @@ import (
 type Repository struct {
 	buffer        *bytes.Buffer
 	archiveBuffer *bytes.Buffer
+	trashBuffer   *bytes.Buffer
 }
 
 // NewRepository creates a new empty in-memory repository
@@ -20,6 +21,7 @@ func NewRepository() *Repository {
 	return &Repository{
 		buffer:        &bytes.Buffer{},
 		archiveBuffer: &bytes.Buffer{},
+		trashBuffer:   &bytes.Buffer{},
 	}
 }
 
@@ -44,7 +46,39 @@ func (r *Repository) AppendToArchive(t todo.Todo) error {
 	return todotxt.Marshal(r.archiveBuffer, []todo.Todo{t})
 }
 
+// LoadArchive reads archived todos from the archive buffer
+func (r *Repository) LoadArchive() ([]todo.Todo, error) {
+	return todotxt.Unmarshal(bytes.NewReader(r.archiveBuffer.Bytes()))
+}
+
+// SaveArchive writes archived todos to the archive buffer (full rewrite)
+func (r *Repository) SaveArchive(todos []todo.Todo) error {
+	r.archiveBuffer.Reset()
+	return todotxt.Marshal(r.archiveBuffer, todos)
+}
+
 // ArchiveString returns the current archive buffer contents (useful for test assertions)
 func (r *Repository) ArchiveString() string {
 	return r.archiveBuffer.String()
 }
+
+// AppendToTrash appends a deleted todo to the trash buffer
+func (r *Repository) AppendToTrash(t todo.Todo) error {
+	return todotxt.Marshal(r.trashBuffer, []todo.Todo{t})
+}
+
+// LoadTrash reads deleted todos from the trash buffer
+func (r *Repository) LoadTrash() ([]todo.Todo, error) {
+	return todotxt.Unmarshal(bytes.NewReader(r.trashBuffer.Bytes()))
+}
+
+// SaveTrash writes deleted todos to the trash buffer (full rewrite)
+func (r *Repository) SaveTrash(todos []todo.Todo) error {
+	r.trashBuffer.Reset()
+	return todotxt.Marshal(r.trashBuffer, todos)
+}
+
+// TrashString returns the current trash buffer contents (useful for test assertions)
+func (r *Repository) TrashString() string {
+	return r.trashBuffer.String()
+}
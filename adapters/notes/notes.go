@@ -0,0 +1,176 @@
+// Package notes syncs the checklist items in a directory of Markdown notes, such as an Obsidian
+// vault, with the todo list.
+//
+// Scan reads the items as todos, and Repository decorates a TodoRepository so that saving a
+// completed (or reopened) todo ticks (or unticks) its item's box in the note it came from.
+package notes
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/quii/todo-eisenhower/domain/checklist"
+	"github.com/quii/todo-eisenhower/domain/todo"
+	"github.com/quii/todo-eisenhower/usecases"
+)
+
+// Scan reads the checklist items in every Markdown note under dir, skipping hidden directories
+// such as .obsidian and .trash. Items are tagged with their note's path relative to dir.
+func Scan(dir string, now time.Time) ([]todo.Todo, error) {
+	var items []todo.Todo
+	err := filepath.WalkDir(dir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() {
+			if path != dir && strings.HasPrefix(entry.Name(), ".") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !entry.Type().IsRegular() || !isNote(entry.Name()) {
+			return nil
+		}
+
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		found, err := readNote(path, filepath.ToSlash(rel), now)
+		if err != nil {
+			return err
+		}
+		items = append(items, found...)
+		return nil
+	})
+	return items, err
+}
+
+func readNote(path, name string, now time.Time) ([]todo.Todo, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = f.Close() }()
+
+	items, err := checklist.Unmarshal(f, name, now)
+	if err != nil {
+		return nil, fmt.Errorf("reading %s: %w", path, err)
+	}
+	return items, nil
+}
+
+// isNote returns true for Markdown files
+func isNote(name string) bool {
+	ext := strings.ToLower(filepath.Ext(name))
+	return ext == ".md" || ext == ".markdown"
+}
+
+// Repository wraps a TodoRepository and ticks the boxes of checklist items in the notes under dir
+// when their todos are completed
+type Repository struct {
+	usecases.TodoRepository
+	dir       string
+	completed map[string]bool // whether each note todo was completed when last loaded or saved, by checklist.Key
+}
+
+// NewRepository decorates repo so that completing todos from the notes under dir ticks their boxes
+func NewRepository(repo usecases.TodoRepository, dir string) *Repository {
+	return &Repository{TodoRepository: repo, dir: dir, completed: make(map[string]bool)}
+}
+
+// LoadAll loads todos, remembering which note todos are completed
+func (r *Repository) LoadAll() ([]todo.Todo, error) {
+	todos, err := r.TodoRepository.LoadAll()
+	if err != nil {
+		return nil, err
+	}
+	r.remember(todos)
+	return todos, nil
+}
+
+// SaveAll saves todos, then ticks the boxes of todos completed since they were loaded and unticks
+// the boxes of todos reopened since. Boxes of other todos are left as they are, so ticks made in
+// the notes aren't undone before they are synced. Notes are only written when a box changes, and
+// items that have gone are left alone.
+func (r *Repository) SaveAll(todos []todo.Todo) error {
+	if err := r.TodoRepository.SaveAll(todos); err != nil {
+		return err
+	}
+	previously := r.completed
+	r.remember(todos)
+
+	byNote := make(map[string][]todo.Todo)
+	var names []string
+	for _, t := range todos {
+		name, _, ok := checklist.Location(t)
+		if !ok || !filepath.IsLocal(filepath.FromSlash(name)) {
+			continue
+		}
+		if was, seen := previously[checklist.Key(t)]; !seen || was == t.IsCompleted() {
+			continue
+		}
+		if _, seen := byNote[name]; !seen {
+			names = append(names, name)
+		}
+		byNote[name] = append(byNote[name], t)
+	}
+
+	for _, name := range names {
+		if err := r.apply(name, byNote[name]); err != nil {
+			return fmt.Errorf("ticking checklist items in %s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// Record passes events on to any journal being decorated
+func (r *Repository) Record(event usecases.Event) error {
+	if recorder, ok := r.TodoRepository.(interface{ Record(usecases.Event) error }); ok {
+		return recorder.Record(event)
+	}
+	return nil
+}
+
+// remember records which note todos are completed
+func (r *Repository) remember(todos []todo.Todo) {
+	r.completed = make(map[string]bool)
+	for _, t := range todos {
+		if key := checklist.Key(t); key != "" {
+			r.completed[key] = t.IsCompleted()
+		}
+	}
+}
+
+// apply updates the boxes of todos' items in one note
+func (r *Repository) apply(name string, todos []todo.Todo) error {
+	path := filepath.Join(r.dir, filepath.FromSlash(name))
+	info, err := os.Stat(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	changed := false
+	for _, t := range todos {
+		var ticked bool
+		content, ticked = checklist.Apply(content, t)
+		changed = changed || ticked
+	}
+	if !changed {
+		return nil
+	}
+	return os.WriteFile(path, content, info.Mode().Perm())
+}
@@ -0,0 +1,100 @@
+package notes_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/matryer/is"
+	"github.com/quii/todo-eisenhower/adapters/memory"
+	"github.com/quii/todo-eisenhower/adapters/notes"
+	"github.com/quii/todo-eisenhower/domain/matrix"
+	"github.com/quii/todo-eisenhower/usecases"
+)
+
+var now = time.Date(2026, 1, 20, 9, 0, 0, 0, time.UTC)
+
+// vault writes notes into a new directory
+func vault(t *testing.T, files map[string]string) string {
+	t.Helper()
+	dir := t.TempDir()
+	for name, content := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+func TestScan(t *testing.T) {
+	//nolint:gocritic // importShadow: is := is.New(t) is idiomatic for github.com/matryer/is
+	is := is.New(t)
+	dir := vault(t, map[string]string{
+		"Inbox.md":                 "- [ ] Renew passport #admin\n",
+		"Projects/Offsite plan.md": "Notes\n\n- [x] Book venue\n- [ ] Send invites\n",
+		"readme.txt":               "- [ ] not a note\n",
+		".obsidian/templates.md":   "- [ ] a template\n",
+	})
+
+	items, err := notes.Scan(dir, now)
+	is.NoErr(err)
+	is.Equal(len(items), 3)
+	is.Equal(items[0].Description(), "Renew passport note:Inbox.md:1")
+	is.Equal(items[1].Description(), "Book venue note:Projects/Offsite%20plan.md:3")
+	is.True(items[1].IsCompleted())
+	is.Equal(items[2].Description(), "Send invites note:Projects/Offsite%20plan.md:4")
+}
+
+func TestRepository(t *testing.T) {
+	t.Run("ticks and unticks the boxes of todos completed and reopened in the matrix", func(t *testing.T) {
+		is := is.New(t)
+		dir := vault(t, map[string]string{"Inbox.md": "# Inbox\n- [ ] Renew passport #admin\n- [ ] Call mum\n"})
+		items, err := notes.Scan(dir, now)
+		is.NoErr(err)
+
+		repo := notes.NewRepository(memory.NewRepository(), dir)
+		m, err := usecases.LoadMatrix(repo)
+		is.NoErr(err)
+		m, _, err = usecases.SyncNotes(repo, m, items, now)
+		is.NoErr(err)
+
+		m, err = usecases.ToggleCompletion(repo, m, matrix.BacklogQuadrant, 0)
+		is.NoErr(err)
+		content, err := os.ReadFile(filepath.Join(dir, "Inbox.md"))
+		is.NoErr(err)
+		is.Equal(string(content), "# Inbox\n- [x] Renew passport #admin\n- [ ] Call mum\n")
+
+		_, err = usecases.ToggleCompletion(repo, m, matrix.BacklogQuadrant, 0)
+		is.NoErr(err)
+		content, err = os.ReadFile(filepath.Join(dir, "Inbox.md"))
+		is.NoErr(err)
+		is.Equal(string(content), "# Inbox\n- [ ] Renew passport #admin\n- [ ] Call mum\n")
+	})
+
+	t.Run("leaves boxes ticked in the notes alone until they are synced", func(t *testing.T) {
+		is := is.New(t)
+		dir := vault(t, map[string]string{"Inbox.md": "- [ ] Renew passport\n- [ ] Call mum\n"})
+		items, err := notes.Scan(dir, now)
+		is.NoErr(err)
+
+		repo := notes.NewRepository(memory.NewRepository(), dir)
+		m, err := usecases.LoadMatrix(repo)
+		is.NoErr(err)
+		m, _, err = usecases.SyncNotes(repo, m, items, now)
+		is.NoErr(err)
+
+		ticked := "- [ ] Renew passport\n- [x] Call mum\n"
+		is.NoErr(os.WriteFile(filepath.Join(dir, "Inbox.md"), []byte(ticked), 0o644))
+		_, err = usecases.ToggleCompletion(repo, m, matrix.BacklogQuadrant, 0)
+		is.NoErr(err)
+
+		content, err := os.ReadFile(filepath.Join(dir, "Inbox.md"))
+		is.NoErr(err)
+		is.Equal(string(content), "- [x] Renew passport\n- [x] Call mum\n")
+	})
+}
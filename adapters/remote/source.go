@@ -0,0 +1,100 @@
+// Package remote fetches todo.txt files published at http(s) URLs, for viewing read-only.
+package remote
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"sync"
+
+	"github.com/quii/todo-eisenhower/domain/todo"
+	"github.com/quii/todo-eisenhower/domain/todotxt"
+)
+
+// Source is a todo.txt file at a URL
+// It remembers the validators of the last response so unchanged files aren't downloaded again
+type Source struct {
+	url    string
+	client *http.Client
+
+	mu           sync.Mutex
+	etag         string
+	lastModified string
+	todos        []todo.Todo // todos from the last download
+}
+
+// Option configures optional behaviour of a Source
+type Option func(*Source)
+
+// WithClient sets the HTTP client used for requests (http.DefaultClient by default)
+func WithClient(client *http.Client) Option {
+	return func(s *Source) {
+		s.client = client
+	}
+}
+
+// IsURL returns true if arg is an http or https URL
+func IsURL(arg string) bool {
+	u, err := url.Parse(arg)
+	return err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Host != ""
+}
+
+// NewSource creates a source for the todo file at rawURL
+func NewSource(rawURL string, opts ...Option) (*Source, error) {
+	if !IsURL(rawURL) {
+		return nil, fmt.Errorf("invalid URL %q: expected an http or https URL", rawURL)
+	}
+
+	s := &Source{url: rawURL, client: http.DefaultClient}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s, nil
+}
+
+// Fetch downloads and parses the file
+// changed is false when the server says the file hasn't changed since the last fetch,
+// in which case the todos from that fetch are returned
+func (s *Source) Fetch() (todos []todo.Todo, changed bool, err error) {
+	req, err := http.NewRequest(http.MethodGet, s.url, nil)
+	if err != nil {
+		return nil, false, err
+	}
+
+	s.mu.Lock()
+	if s.etag != "" {
+		req.Header.Set("If-None-Match", s.etag)
+	}
+	if s.lastModified != "" {
+		req.Header.Set("If-Modified-Since", s.lastModified)
+	}
+	s.mu.Unlock()
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, false, err
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	switch {
+	case resp.StatusCode == http.StatusNotModified && s.todos != nil:
+		return s.todos, false, nil
+	case resp.StatusCode < 200 || resp.StatusCode >= 300:
+		return nil, false, fmt.Errorf("GET %s: unexpected response %s", s.url, resp.Status)
+	}
+
+	todos, err = todotxt.Unmarshal(resp.Body)
+	if err != nil {
+		return nil, false, fmt.Errorf("parsing %s: %w", s.url, err)
+	}
+
+	s.etag = resp.Header.Get("ETag")
+	s.lastModified = resp.Header.Get("Last-Modified")
+	s.todos = todos
+	return todos, true, nil
+}
@@ -0,0 +1,108 @@
+package remote_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/matryer/is"
+	"github.com/quii/todo-eisenhower/adapters/remote"
+)
+
+// teamList serves a todo.txt file with an ETag, counting full downloads
+type teamList struct {
+	mu        sync.Mutex
+	content   string
+	version   int
+	downloads int
+}
+
+func (l *teamList) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	etag := `"v` + strconv.Itoa(l.version) + `"`
+	w.Header().Set("ETag", etag)
+	if r.Header.Get("If-None-Match") == etag {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+	l.downloads++
+	_, _ = w.Write([]byte(l.content))
+}
+
+func (l *teamList) publish(content string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.content = content
+	l.version++
+}
+
+func TestSource(t *testing.T) {
+	t.Run("fetches and parses todos", func(t *testing.T) {
+		//nolint:gocritic // importShadow: is := is.New(t) is idiomatic for github.com/matryer/is
+		is := is.New(t)
+		list := &teamList{content: "(A) Ship release +team\n(B) Plan offsite\n"}
+		server := httptest.NewServer(list)
+		defer server.Close()
+
+		source, err := remote.NewSource(server.URL + "/team.txt")
+		is.NoErr(err)
+
+		todos, changed, err := source.Fetch()
+		is.NoErr(err)
+		is.True(changed)
+		is.Equal(len(todos), 2)
+		is.Equal(todos[0].Projects(), []string{"team"})
+	})
+
+	t.Run("does not download an unchanged file again", func(t *testing.T) {
+		is := is.New(t)
+		list := &teamList{content: "(A) Ship release\n"}
+		server := httptest.NewServer(list)
+		defer server.Close()
+
+		source, err := remote.NewSource(server.URL + "/team.txt")
+		is.NoErr(err)
+
+		_, _, err = source.Fetch()
+		is.NoErr(err)
+		todos, changed, err := source.Fetch()
+		is.NoErr(err)
+
+		is.True(!changed)
+		is.Equal(len(todos), 1)
+		is.Equal(list.downloads, 1)
+
+		list.publish("(A) Ship release\n(C) Update wiki\n")
+		todos, changed, err = source.Fetch()
+		is.NoErr(err)
+		is.True(changed)
+		is.Equal(len(todos), 2)
+		is.Equal(list.downloads, 2)
+	})
+
+	t.Run("reports server errors", func(t *testing.T) {
+		is := is.New(t)
+		server := httptest.NewServer(http.NotFoundHandler())
+		defer server.Close()
+
+		source, err := remote.NewSource(server.URL + "/missing.txt")
+		is.NoErr(err)
+
+		_, _, err = source.Fetch()
+		is.True(err != nil)
+	})
+
+	t.Run("only accepts http and https URLs", func(t *testing.T) {
+		is := is.New(t)
+		is.True(remote.IsURL("https://example.com/team.txt"))
+		is.True(!remote.IsURL("/home/alice/todo.txt"))
+		is.True(!remote.IsURL("davs://example.com/todo.txt"))
+
+		_, err := remote.NewSource("todo.txt")
+		is.True(err != nil)
+	})
+}
@@ -0,0 +1,296 @@
+// Package report renders the matrix as a self-contained Markdown or HTML document for status updates.
+//
+// A report has the four quadrants, the overdue and stale todos, the tag inventory and the last
+// 7 days' throughput. Build gathers them into a Report, which Render writes with the built-in
+// template for its format or with a custom one: text/template for Markdown, html/template for HTML.
+package report
+
+import (
+	"embed"
+	"fmt"
+	htmltemplate "html/template"
+	"io"
+	"sort"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/quii/todo-eisenhower/domain/matrix"
+	"github.com/quii/todo-eisenhower/domain/todo"
+	"github.com/quii/todo-eisenhower/domain/todotxt"
+)
+
+// Format is the kind of document a report is rendered as
+type Format string
+
+// Supported formats
+const (
+	Markdown Format = "markdown"
+	HTML     Format = "html"
+)
+
+// ParseFormat reads a format name such as "markdown", "md" or "html"
+func ParseFormat(name string) (Format, bool) {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case "markdown", "md":
+		return Markdown, true
+	case "html":
+		return HTML, true
+	default:
+		return "", false
+	}
+}
+
+//go:embed templates
+var templates embed.FS
+
+// DefaultTemplate returns the built-in template for a format, as a starting point for custom ones
+func DefaultTemplate(format Format) string {
+	name := "templates/markdown.tmpl"
+	if format == HTML {
+		name = "templates/html.tmpl"
+	}
+	content, err := templates.ReadFile(name)
+	if err != nil {
+		panic(err) // the templates are embedded, so this can't happen
+	}
+	return string(content)
+}
+
+// Report is the data templates are executed with
+type Report struct {
+	GeneratedAt        time.Time
+	Quadrants          []Quadrant // Do First, Schedule, Delegate, Eliminate
+	Overdue            []Item     // open todos past their due date
+	Stale              []Item     // open todos that have waited too long in their quadrant
+	Projects           []Tag      // most active todos first
+	Contexts           []Tag
+	TotalActive        int
+	CompletedLast7Days int
+	AddedLast7Days     int
+}
+
+// Quadrant is one quadrant of the matrix with its todos
+type Quadrant struct {
+	Name       string // e.g. "do-first"
+	Title      string // e.g. "Do First", or the configured title
+	Color      string // e.g. "#FF6B6B"
+	Active     int
+	OldestDays int
+	Todos      []Item // open todos first, then completed ones
+}
+
+// Item is a todo in a report
+type Item struct {
+	ID          int    // position in matrix order (Do First first), as used by the CLI subcommands
+	Quadrant    string // title of the quadrant the todo is in
+	Description string
+	Projects    []string
+	Contexts    []string
+	DueDate     string // "YYYY-MM-DD", or empty
+	Completed   bool
+	Stale       bool
+	Overdue     bool
+}
+
+// Tag is a project or context with the number of active todos that have it
+type Tag struct {
+	Name    string
+	Count   int
+	HighWIP bool // more active todos than the WIP threshold
+}
+
+// Style is how a quadrant is titled and colored
+type Style struct {
+	Title string
+	Color string
+}
+
+// options are the settings a report is built with
+type options struct {
+	stalePolicy  todo.StalePolicy
+	wipThreshold int
+	styles       map[matrix.QuadrantType]Style
+}
+
+// Option configures how a report is built
+type Option func(*options)
+
+// WithStalePolicy sets when todos are reported as stale (todo.DefaultStalePolicy by default)
+func WithStalePolicy(policy todo.StalePolicy) Option {
+	return func(o *options) {
+		o.stalePolicy = policy
+	}
+}
+
+// WithWIPThreshold sets how many active todos a tag can have before it is flagged (5 by default)
+func WithWIPThreshold(threshold int) Option {
+	return func(o *options) {
+		if threshold > 0 {
+			o.wipThreshold = threshold
+		}
+	}
+}
+
+// WithQuadrantStyles overrides quadrant titles and colors; empty fields keep the defaults
+func WithQuadrantStyles(styles map[matrix.QuadrantType]Style) Option {
+	return func(o *options) {
+		for quadrant, style := range styles {
+			current := o.styles[quadrant]
+			if style.Title != "" {
+				current.Title = style.Title
+			}
+			if style.Color != "" {
+				current.Color = style.Color
+			}
+			o.styles[quadrant] = current
+		}
+	}
+}
+
+// quadrants are reported in matrix order; the backlog isn't part of a status update
+var quadrants = []matrix.QuadrantType{
+	matrix.DoFirstQuadrant, matrix.ScheduleQuadrant, matrix.DelegateQuadrant, matrix.EliminateQuadrant,
+}
+
+// Build gathers the report for a matrix
+func Build(m matrix.Matrix, now time.Time, opts ...Option) Report {
+	o := options{
+		stalePolicy:  todo.DefaultStalePolicy,
+		wipThreshold: todo.DefaultWIPThreshold,
+		styles:       make(map[matrix.QuadrantType]Style, len(quadrants)),
+	}
+	for _, quadrant := range quadrants {
+		o.styles[quadrant] = Style{Title: quadrant.Title(), Color: quadrant.Color()}
+	}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	inventory := matrix.NewInventory(m, now)
+	r := Report{
+		GeneratedAt:        now,
+		TotalActive:        inventory.TotalActive,
+		CompletedLast7Days: inventory.CompletedLast7Days,
+		AddedLast7Days:     inventory.AddedLast7Days,
+	}
+
+	active := map[matrix.QuadrantType]int{
+		matrix.DoFirstQuadrant:   inventory.DoFirstActive,
+		matrix.ScheduleQuadrant:  inventory.ScheduleActive,
+		matrix.DelegateQuadrant:  inventory.DelegateActive,
+		matrix.EliminateQuadrant: inventory.EliminateActive,
+	}
+	oldest := map[matrix.QuadrantType]int{
+		matrix.DoFirstQuadrant:   inventory.DoFirstOldestDays,
+		matrix.ScheduleQuadrant:  inventory.ScheduleOldestDays,
+		matrix.DelegateQuadrant:  inventory.DelegateOldestDays,
+		matrix.EliminateQuadrant: inventory.EliminateOldestDays,
+	}
+
+	projectCounts := make(map[string]int)
+	contextCounts := make(map[string]int)
+
+	for _, quadrant := range quadrants {
+		style := o.styles[quadrant]
+		section := Quadrant{
+			Name:       quadrant.String(),
+			Title:      style.Title,
+			Color:      style.Color,
+			Active:     active[quadrant],
+			OldestDays: oldest[quadrant],
+		}
+
+		var completed []Item
+		for index, t := range m.GetTodosForQuadrant(quadrant) {
+			item := Item{
+				ID:          m.Position(quadrant, index),
+				Quadrant:    style.Title,
+				Description: t.Description(),
+				Projects:    t.Projects(),
+				Contexts:    t.Contexts(),
+				Completed:   t.IsCompleted(),
+				Stale:       o.stalePolicy.IsStale(t, now),
+				Overdue:     t.IsOverdue(now),
+			}
+			if t.DueDate() != nil {
+				item.DueDate = t.DueDate().Format(todotxt.DateFormat)
+			}
+
+			if item.Completed {
+				completed = append(completed, item)
+				continue
+			}
+			section.Todos = append(section.Todos, item)
+			if item.Overdue {
+				r.Overdue = append(r.Overdue, item)
+			}
+			if item.Stale {
+				r.Stale = append(r.Stale, item)
+			}
+			for _, project := range item.Projects {
+				projectCounts[project]++
+			}
+			for _, context := range item.Contexts {
+				contextCounts[context]++
+			}
+		}
+		section.Todos = append(section.Todos, completed...)
+		r.Quadrants = append(r.Quadrants, section)
+	}
+
+	r.Projects = sortedTags(projectCounts, o.wipThreshold)
+	r.Contexts = sortedTags(contextCounts, o.wipThreshold)
+	return r
+}
+
+// sortedTags orders tags by count (descending), then by name, as in the matrix's tag inventory
+func sortedTags(counts map[string]int, wipThreshold int) []Tag {
+	tags := make([]Tag, 0, len(counts))
+	for name, count := range counts {
+		tags = append(tags, Tag{Name: name, Count: count, HighWIP: count > wipThreshold})
+	}
+	sort.Slice(tags, func(i, j int) bool {
+		if tags[i].Count == tags[j].Count {
+			return tags[i].Name < tags[j].Name
+		}
+		return tags[i].Count > tags[j].Count
+	})
+	return tags
+}
+
+// funcs are available to every template
+var funcs = map[string]any{
+	"join": strings.Join,
+	"date": func(t time.Time) string { return t.Format(todotxt.DateFormat) },
+}
+
+// Render writes a report using tmpl, or the built-in template for the format when tmpl is empty.
+// Markdown templates use text/template; HTML templates use html/template, so todo text is escaped.
+func Render(w io.Writer, format Format, tmpl string, r Report) error {
+	if tmpl == "" {
+		tmpl = DefaultTemplate(format)
+	}
+
+	type executor interface {
+		Execute(w io.Writer, data any) error
+	}
+	var t executor
+	var err error
+	switch format {
+	case Markdown:
+		t, err = template.New("report").Funcs(funcs).Parse(tmpl)
+	case HTML:
+		t, err = htmltemplate.New("report").Funcs(funcs).Parse(tmpl)
+	default:
+		return fmt.Errorf("unknown report format %q", format)
+	}
+	if err != nil {
+		return fmt.Errorf("parsing template: %w", err)
+	}
+
+	if err := t.Execute(w, r); err != nil {
+		return fmt.Errorf("rendering report: %w", err)
+	}
+	return nil
+}
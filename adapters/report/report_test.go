@@ -0,0 +1,151 @@
+package report_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/matryer/is"
+	"github.com/quii/todo-eisenhower/adapters/report"
+	"github.com/quii/todo-eisenhower/domain/matrix"
+	"github.com/quii/todo-eisenhower/domain/todotxt"
+)
+
+// now is Tuesday 2026-01-20
+var now = time.Date(2026, 1, 20, 9, 0, 0, 0, time.UTC)
+
+func matrixOf(t *testing.T, lines string) matrix.Matrix {
+	t.Helper()
+	todos, err := todotxt.Unmarshal(strings.NewReader(lines))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return matrix.New(todos)
+}
+
+const lines = "(A) 2026-01-02 Fix prod +ops @laptop prioritised:2026-01-02\n" +
+	"(B) 2026-01-19 Plan roadmap +ops due:2026-01-19\n" +
+	"x 2026-01-19 (B) 2026-01-12 Hire designer +team\n" +
+	"(E) Someday learn Rust\n"
+
+func TestBuild(t *testing.T) {
+	//nolint:gocritic // importShadow: is := is.New(t) is idiomatic for github.com/matryer/is
+	is := is.New(t)
+	r := report.Build(matrixOf(t, lines), now, report.WithWIPThreshold(1))
+
+	is.Equal(len(r.Quadrants), 4) // the backlog isn't reported
+	is.Equal(r.Quadrants[0].Title, "Do First")
+	is.Equal(r.Quadrants[1].Active, 1)
+	is.Equal(r.Quadrants[1].Todos[1].Description, "Hire designer") // completed todos come last
+
+	is.Equal(len(r.Overdue), 1)
+	is.Equal(r.Overdue[0].Description, "Plan roadmap")
+	is.Equal(len(r.Stale), 1)
+	is.Equal(r.Stale[0].Description, "Fix prod")
+
+	is.Equal(r.Projects, []report.Tag{{Name: "ops", Count: 2, HighWIP: true}}) // completed todos aren't counted
+	is.Equal(r.Contexts, []report.Tag{{Name: "laptop", Count: 1}})
+	is.Equal(r.CompletedLast7Days, 1)
+	is.Equal(r.AddedLast7Days, 1)
+}
+
+func TestBuild_QuadrantStyles(t *testing.T) {
+	is := is.New(t)
+	r := report.Build(matrixOf(t, lines), now, report.WithQuadrantStyles(map[matrix.QuadrantType]report.Style{
+		matrix.DoFirstQuadrant: {Title: "Fires"},
+	}))
+
+	is.Equal(r.Quadrants[0].Title, "Fires")
+	is.Equal(r.Quadrants[0].Color, "#FF6B6B") // default color kept
+	is.Equal(r.Stale[0].Quadrant, "Fires")
+}
+
+func TestRender(t *testing.T) {
+	t.Run("markdown", func(t *testing.T) {
+		is := is.New(t)
+		var buf bytes.Buffer
+		is.NoErr(report.Render(&buf, report.Markdown, "", report.Build(matrixOf(t, lines), now)))
+
+		is.Equal(buf.String(), `# Eisenhower Matrix Report
+
+_Generated 2026-01-20_
+
+## Summary
+
+| Quadrant | Active | Oldest (days) |
+| --- | ---: | ---: |
+| Do First | 1 | 18 |
+| Schedule | 1 | 1 |
+| Delegate | 0 | 0 |
+| Eliminate | 0 | 0 |
+| **Total** | **2** | |
+
+In the last 7 days: 1 completed, 1 added.
+
+## Do First
+
+- [ ] Fix prod +ops @laptop _stale_
+
+## Schedule
+
+- [ ] Plan roadmap +ops (due 2026-01-19) **overdue**
+- [x] Hire designer +team
+
+## Delegate
+
+_Nothing here_
+
+## Eliminate
+
+_Nothing here_
+
+## Overdue
+
+- Plan roadmap (due 2026-01-19, Schedule)
+
+## Stale
+
+- Fix prod (Do First)
+
+## Tag Inventory
+
+- Projects (+): ops (2)
+- Contexts (@): laptop (1)
+`)
+	})
+
+	t.Run("html escapes todo text", func(t *testing.T) {
+		is := is.New(t)
+		var buf bytes.Buffer
+		r := report.Build(matrixOf(t, "(A) Fix <script>alert(1)</script> +ops\n"), now)
+		is.NoErr(report.Render(&buf, report.HTML, "", r))
+
+		is.True(strings.HasPrefix(buf.String(), "<!DOCTYPE html>"))
+		is.True(strings.Contains(buf.String(), "Fix &lt;script&gt;"))
+		is.True(strings.Contains(buf.String(), `<span class="project">+ops</span>`))
+		is.True(strings.Contains(buf.String(), "border-color: #FF6B6B"))
+	})
+
+	t.Run("custom templates", func(t *testing.T) {
+		is := is.New(t)
+		var buf bytes.Buffer
+		tmpl := `{{range .Quadrants}}{{.Title}}: {{.Active}} {{end}}({{.CompletedLast7Days}} done this week)`
+		is.NoErr(report.Render(&buf, report.Markdown, tmpl, report.Build(matrixOf(t, lines), now)))
+		is.Equal(buf.String(), "Do First: 1 Schedule: 1 Delegate: 0 Eliminate: 0 (1 done this week)")
+
+		err := report.Render(&buf, report.HTML, "{{.Missing", report.Report{})
+		is.True(err != nil)
+		is.True(strings.Contains(err.Error(), "parsing template"))
+	})
+}
+
+func TestParseFormat(t *testing.T) {
+	is := is.New(t)
+	format, ok := report.ParseFormat("md")
+	is.True(ok)
+	is.Equal(format, report.Markdown)
+
+	_, ok = report.ParseFormat("pdf")
+	is.True(!ok)
+}
@@ -0,0 +1,177 @@
+// Package todosh reads the settings shared with todo.sh (todo.txt-cli), so eisenhower opens the same files.
+//
+// todo.sh keeps its settings in a shell script (usually ~/.todo/config) that exports TODO_DIR,
+// TODO_FILE and DONE_FILE. The same variables can also be set in the environment.
+package todosh
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Config holds the todo.sh file locations; empty fields weren't configured
+type Config struct {
+	ConfigFile string // the config file that was read, if any
+	TodoDir    string
+	TodoFile   string
+	DoneFile   string
+}
+
+// settings are the variables eisenhower reads from todo.sh
+var settings = []string{"TODO_DIR", "TODO_FILE", "DONE_FILE"}
+
+// ConfigPaths returns where todo.sh looks for its config file, in the order it looks
+// TODOTXT_CFG_FILE overrides the search
+func ConfigPaths(home string, getenv func(string) string) []string {
+	if path := getenv("TODOTXT_CFG_FILE"); path != "" {
+		return []string{expandHome(path, home)}
+	}
+
+	xdgConfig := getenv("XDG_CONFIG_HOME")
+	if xdgConfig == "" {
+		xdgConfig = filepath.Join(home, ".config")
+	}
+
+	return []string{
+		filepath.Join(home, ".todo", "config"),
+		filepath.Join(home, "todo.cfg"),
+		filepath.Join(home, ".todo.cfg"),
+		filepath.Join(xdgConfig, "todo", "config"),
+	}
+}
+
+// Load finds the todo.sh config and combines it with the environment
+// TODO_DIR, TODO_FILE and DONE_FILE in the environment win over the config file.
+// When only TODO_DIR is known, TODO_FILE and DONE_FILE default to todo.txt and done.txt inside it.
+func Load(home string, getenv func(string) string) (Config, error) {
+	var cfg Config
+
+	vars := make(map[string]string)
+	fromEnv := make(map[string]bool)
+	for _, name := range settings {
+		if value := getenv(name); value != "" {
+			vars[name] = expandHome(value, home)
+			fromEnv[name] = true
+		}
+	}
+
+	for _, path := range ConfigPaths(home, getenv) {
+		//nolint:gosec // G304: todo.sh config locations are well known paths in the user's home
+		f, err := os.Open(path)
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return cfg, err
+		}
+
+		err = parse(f, vars, fromEnv, home, getenv)
+		_ = f.Close()
+		if err != nil {
+			return cfg, fmt.Errorf("reading todo.sh config %s: %w", path, err)
+		}
+		cfg.ConfigFile = path
+		break
+	}
+
+	cfg.TodoDir = vars["TODO_DIR"]
+	cfg.TodoFile = vars["TODO_FILE"]
+	cfg.DoneFile = vars["DONE_FILE"]
+	if cfg.TodoDir != "" {
+		if cfg.TodoFile == "" {
+			cfg.TodoFile = filepath.Join(cfg.TodoDir, "todo.txt")
+		}
+		if cfg.DoneFile == "" {
+			cfg.DoneFile = filepath.Join(cfg.TodoDir, "done.txt")
+		}
+	}
+	return cfg, nil
+}
+
+// parse reads NAME=value assignments (optionally prefixed with export) from a todo.sh config
+// Values may reference earlier variables and the environment ($NAME, ${NAME}, ${NAME:-default}).
+// Assignments to variables set in the environment are ignored, and so are values that need a shell
+// to evaluate (command substitution), such as todo.sh's default TODO_DIR=$(dirname "$0").
+func parse(r io.Reader, vars map[string]string, fromEnv map[string]bool, home string, getenv func(string) string) error {
+	lookup := func(name string) string {
+		name, fallback, _ := strings.Cut(name, ":-")
+		if value, ok := vars[name]; ok && value != "" {
+			return value
+		}
+		if name == "HOME" {
+			return home
+		}
+		if value := getenv(name); value != "" {
+			return value
+		}
+		return fallback
+	}
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		line = strings.TrimPrefix(line, "export ")
+
+		name, value, ok := strings.Cut(line, "=")
+		if !ok || !isName(name) || fromEnv[name] {
+			continue
+		}
+		if strings.Contains(value, "$(") || strings.Contains(value, "`") {
+			continue
+		}
+
+		value, literal := unquote(value)
+		if !literal {
+			value = os.Expand(value, lookup)
+		}
+		vars[name] = expandHome(value, home)
+	}
+	return scanner.Err()
+}
+
+// unquote strips surrounding quotes and any trailing comment from a shell value
+// literal is true for single-quoted values, which the shell doesn't expand
+func unquote(value string) (unquoted string, literal bool) {
+	value = strings.TrimSpace(value)
+	if len(value) >= 2 && (value[0] == '"' || value[0] == '\'') {
+		if end := strings.IndexByte(value[1:], value[0]); end >= 0 {
+			return value[1 : end+1], value[0] == '\''
+		}
+	}
+	if i := strings.Index(value, " #"); i >= 0 {
+		value = value[:i]
+	}
+	return strings.TrimSpace(value), false
+}
+
+// isName returns true if s is a valid shell variable name
+func isName(s string) bool {
+	if s == "" {
+		return false
+	}
+	for i, c := range s {
+		isLetter := c == '_' || (c >= 'A' && c <= 'Z') || (c >= 'a' && c <= 'z')
+		if !isLetter && (i == 0 || c < '0' || c > '9') {
+			return false
+		}
+	}
+	return true
+}
+
+// expandHome replaces a leading ~ with the home directory
+func expandHome(path, home string) string {
+	if path == "~" {
+		return home
+	}
+	if strings.HasPrefix(path, "~/") {
+		return filepath.Join(home, path[2:])
+	}
+	return path
+}
@@ -0,0 +1,115 @@
+package todosh_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/matryer/is"
+	"github.com/quii/todo-eisenhower/adapters/todosh"
+)
+
+// env fakes the environment with a map
+func env(vars map[string]string) func(string) string {
+	return func(name string) string {
+		return vars[name]
+	}
+}
+
+// writeConfig writes a todo.sh config file under home
+func writeConfig(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		t.Fatalf("failed to create config dir: %v", err)
+	}
+	//nolint:gosec // G306: test file permissions intentionally match production (0o644)
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+}
+
+func TestLoad(t *testing.T) {
+	t.Run("reads ~/.todo/config", func(t *testing.T) {
+		//nolint:gocritic // importShadow: is := is.New(t) is idiomatic for github.com/matryer/is
+		is := is.New(t)
+		home := t.TempDir()
+		writeConfig(t, filepath.Join(home, ".todo", "config"), `# todo.sh configuration
+export TODO_DIR="$HOME/Dropbox/todo"
+export TODO_FILE="$TODO_DIR/todo.txt"
+export DONE_FILE="$TODO_DIR/done.txt"
+export REPORT_FILE="$TODO_DIR/report.txt"
+`)
+
+		cfg, err := todosh.Load(home, env(nil))
+		is.NoErr(err)
+
+		is.Equal(cfg.ConfigFile, filepath.Join(home, ".todo", "config"))
+		is.Equal(cfg.TodoDir, filepath.Join(home, "Dropbox", "todo"))
+		is.Equal(cfg.TodoFile, filepath.Join(home, "Dropbox", "todo", "todo.txt"))
+		is.Equal(cfg.DoneFile, filepath.Join(home, "Dropbox", "todo", "done.txt"))
+	})
+
+	t.Run("environment wins over the config file", func(t *testing.T) {
+		is := is.New(t)
+		home := t.TempDir()
+		writeConfig(t, filepath.Join(home, ".todo", "config"), `export TODO_DIR=~/notes
+export TODO_FILE="$TODO_DIR/todo.txt"
+`)
+
+		cfg, err := todosh.Load(home, env(map[string]string{"TODO_DIR": "/srv/todo"}))
+		is.NoErr(err)
+
+		is.Equal(cfg.TodoDir, "/srv/todo")
+		is.Equal(cfg.TodoFile, "/srv/todo/todo.txt") // expanded with the environment's TODO_DIR
+		is.Equal(cfg.DoneFile, "/srv/todo/done.txt")
+	})
+
+	t.Run("TODO_DIR alone implies todo.txt and done.txt", func(t *testing.T) {
+		is := is.New(t)
+
+		cfg, err := todosh.Load(t.TempDir(), env(map[string]string{"TODO_DIR": "/srv/todo"}))
+		is.NoErr(err)
+
+		is.Equal(cfg.ConfigFile, "")
+		is.Equal(cfg.TodoFile, "/srv/todo/todo.txt")
+		is.Equal(cfg.DoneFile, "/srv/todo/done.txt")
+	})
+
+	t.Run("nothing configured", func(t *testing.T) {
+		is := is.New(t)
+
+		cfg, err := todosh.Load(t.TempDir(), env(nil))
+		is.NoErr(err)
+		is.Equal(cfg, todosh.Config{})
+	})
+
+	t.Run("skips values that need a shell", func(t *testing.T) {
+		is := is.New(t)
+		home := t.TempDir()
+		writeConfig(t, filepath.Join(home, "todo.cfg"), `export TODO_DIR=$(dirname "$0")
+TODO_FILE='/literal/$TODO_DIR/todo.txt' # single quotes aren't expanded
+`)
+
+		cfg, err := todosh.Load(home, env(nil))
+		is.NoErr(err)
+
+		is.Equal(cfg.TodoDir, "")
+		is.Equal(cfg.TodoFile, "/literal/$TODO_DIR/todo.txt")
+	})
+
+	t.Run("supports defaults and TODOTXT_CFG_FILE", func(t *testing.T) {
+		is := is.New(t)
+		home := t.TempDir()
+		custom := filepath.Join(home, "custom.cfg")
+		writeConfig(t, custom, `export TODO_DIR=${TODO_DIR:-/default/todo}
+`)
+		writeConfig(t, filepath.Join(home, ".todo", "config"), `export TODO_DIR=/ignored
+`)
+
+		cfg, err := todosh.Load(home, env(map[string]string{"TODOTXT_CFG_FILE": custom}))
+		is.NoErr(err)
+
+		is.Equal(cfg.ConfigFile, custom)
+		is.Equal(cfg.TodoDir, "/default/todo")
+	})
+}
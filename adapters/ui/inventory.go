@@ -13,18 +13,23 @@ import (
 )
 
 // WIPThreshold is the number of items that indicates high work-in-progress
-const WIPThreshold = 5
+const WIPThreshold = todo.DefaultWIPThreshold
 
 // TagInventory represents the count of incomplete todos for a tag
 type TagInventory struct {
-	Tag   string
-	Count int
+	Tag       string
+	Count     int
+	Threshold int // configured WIP threshold; zero means WIPThreshold
 }
 
 // IsHighWIP returns true if the count exceeds the WIP threshold,
 // indicating too much work in progress for this tag
 func (ti TagInventory) IsHighWIP() bool {
-	return ti.Count > WIPThreshold
+	threshold := ti.Threshold
+	if threshold <= 0 {
+		threshold = WIPThreshold
+	}
+	return ti.Count > threshold
 }
 
 // countTagInventory counts incomplete todos by project and context tags
@@ -56,10 +61,10 @@ func countTagInventory(m matrix.Matrix) (projectCounts, contextCounts map[string
 }
 
 // sortTagsByCount sorts tags by count (descending), with alphabetical tiebreaker
-func sortTagsByCount(tagCounts map[string]int) []TagInventory {
+func sortTagsByCount(tagCounts map[string]int, threshold int) []TagInventory {
 	inventory := make([]TagInventory, 0, len(tagCounts))
 	for tag, count := range tagCounts {
-		inventory = append(inventory, TagInventory{Tag: tag, Count: count})
+		inventory = append(inventory, TagInventory{Tag: tag, Count: count, Threshold: threshold})
 	}
 
 	sort.Slice(inventory, func(i, j int) bool {
@@ -73,13 +78,13 @@ func sortTagsByCount(tagCounts map[string]int) []TagInventory {
 }
 
 // renderTagLine renders a single line of tag inventory with the given prefix and counts
-func renderTagLine(prefix string, tagCounts map[string]int, labelStyle, countStyle, highWIPCountStyle lipgloss.Style) string {
+func renderTagLine(prefix string, tagCounts map[string]int, threshold int, labelStyle, countStyle, highWIPCountStyle lipgloss.Style) string {
 	if len(tagCounts) == 0 {
 		return labelStyle.Render("(none)")
 	}
 
 	var output strings.Builder
-	inventory := sortTagsByCount(tagCounts)
+	inventory := sortTagsByCount(tagCounts, threshold)
 	for i, item := range inventory {
 		if item.IsHighWIP() {
 			output.WriteString(highWIPCountStyle.Render("!!! "))
@@ -104,7 +109,7 @@ func renderTagLine(prefix string, tagCounts map[string]int, labelStyle, countSty
 }
 
 // renderTagInventory renders the tag inventory display for overview mode
-func renderTagInventory(m matrix.Matrix, width int) string {
+func renderTagInventory(m matrix.Matrix, width, wipThreshold int) string {
 	projectCounts, contextCounts := countTagInventory(m)
 
 	labelStyle := lipgloss.NewStyle().
@@ -121,11 +126,11 @@ func renderTagInventory(m matrix.Matrix, width int) string {
 	var output strings.Builder
 
 	output.WriteString(labelStyle.Render("Projects (+): "))
-	output.WriteString(renderTagLine("+", projectCounts, labelStyle, countStyle, highWIPCountStyle))
+	output.WriteString(renderTagLine("+", projectCounts, wipThreshold, labelStyle, countStyle, highWIPCountStyle))
 	output.WriteString("\n")
 
 	output.WriteString(labelStyle.Render("Contexts (@): "))
-	output.WriteString(renderTagLine("@", contextCounts, labelStyle, countStyle, highWIPCountStyle))
+	output.WriteString(renderTagLine("@", contextCounts, wipThreshold, labelStyle, countStyle, highWIPCountStyle))
 
 	return output.String()
 }
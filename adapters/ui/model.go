@@ -1,6 +1,7 @@
 package ui
 
 import (
+	"errors"
 	"strings"
 	"time"
 
@@ -26,6 +27,8 @@ const (
 	FocusEliminate
 	FocusBacklog
 	Inventory
+	Trash
+	Archive
 )
 
 // QuadrantMeta contains metadata for a quadrant (title, color, priority, type, getter)
@@ -41,28 +44,28 @@ type QuadrantMeta struct {
 var quadrantMeta = map[ViewMode]QuadrantMeta{
 	FocusDoFirst: {
 		Title:    "Do First",
-		Color:    lipgloss.Color("#FF6B6B"),
+		Color:    lipgloss.Color(matrix.DoFirstQuadrant.Color()),
 		Priority: todo.PriorityA,
 		Type:     matrix.DoFirstQuadrant,
 		GetTodos: func(m matrix.Matrix) []todo.Todo { return m.DoFirst() },
 	},
 	FocusSchedule: {
 		Title:    "Schedule",
-		Color:    lipgloss.Color("#4ECDC4"),
+		Color:    lipgloss.Color(matrix.ScheduleQuadrant.Color()),
 		Priority: todo.PriorityB,
 		Type:     matrix.ScheduleQuadrant,
 		GetTodos: func(m matrix.Matrix) []todo.Todo { return m.Schedule() },
 	},
 	FocusDelegate: {
 		Title:    "Delegate",
-		Color:    lipgloss.Color("#FFE66D"),
+		Color:    lipgloss.Color(matrix.DelegateQuadrant.Color()),
 		Priority: todo.PriorityC,
 		Type:     matrix.DelegateQuadrant,
 		GetTodos: func(m matrix.Matrix) []todo.Todo { return m.Delegate() },
 	},
 	FocusEliminate: {
 		Title:    "Eliminate",
-		Color:    lipgloss.Color("#95E1D3"),
+		Color:    lipgloss.Color(matrix.EliminateQuadrant.Color()),
 		Priority: todo.PriorityD,
 		Type:     matrix.EliminateQuadrant,
 		GetTodos: func(m matrix.Matrix) []todo.Todo { return m.Eliminate() },
@@ -95,6 +98,7 @@ type Model struct {
 	input              textinput.Model
 	allProjects        []string
 	allContexts        []string
+	allSources         []string // workspace file names, offered as source: filters
 	repo               usecases.TodoRepository
 	showSuggestions    bool
 	suggestions        []string
@@ -104,6 +108,21 @@ type Model struct {
 	selectedURLIndex   int // selected URL index when in urlSelectionMode
 	todoTable          table.Model // table for displaying todos
 	inventoryViewport  viewport.Model // viewport for scrollable inventory dashboard
+	trashTodos         []todo.Todo    // recently deleted todos shown in the trash view
+	trashTable         table.Model    // table for displaying the trash
+	archiveSearchMode  bool           // true when typing a search query in the archive view
+	archiveQuery       string         // active archive search (text and +project/@context terms)
+	archiveTodos       []todo.Todo    // all archived todos, most recently completed first
+	archiveTable       table.Model    // table for displaying archive search results
+	archivePolicy      usecases.ArchivePolicy // when completed todos are archived automatically
+	statusMessage      string         // toast shown until the next key press
+	archivedOnQuit     int            // how many todos the quit archive policy archived
+	quitArchiveErr     error          // why the quit archive policy failed, reported after the app exits
+	refresh            RefreshFunc    // fetches the latest todos for a read-only remote source
+	refreshInterval    time.Duration  // how often refresh is called
+	saveAsMode         bool           // true when typing the path to save a read-only session to
+	saveAs             SaveAsFunc     // opens the file a read-only session is saved to (nil when not offered)
+	settings           Settings       // quadrant titles and colors, thresholds and input limit
 }
 
 // NewModel creates a new UI model with the given matrix and file path
@@ -114,7 +133,7 @@ func NewModel(m matrix.Matrix, filePath string) Model {
 	// Initialize text input
 	ti := textinput.New()
 	ti.Placeholder = "Enter todo description..."
-	ti.CharLimit = 200
+	ti.CharLimit = todo.DefaultCharLimit
 	ti.Width = 80
 
 	return Model{
@@ -125,6 +144,8 @@ func NewModel(m matrix.Matrix, filePath string) Model {
 		input:       ti,
 		allProjects: projects,
 		allContexts: contexts,
+		allSources:  extractAllSources(m),
+		settings:    DefaultSettings(),
 	}
 }
 
@@ -140,9 +161,32 @@ func (m Model) SetReadOnly(readOnly bool) Model {
 	return m
 }
 
+// SetArchivePolicy sets the automatic archive policy applied when quitting
+func (m Model) SetArchivePolicy(policy usecases.ArchivePolicy) Model {
+	m.archivePolicy = policy
+	return m
+}
+
+// SetStatusMessage sets a toast message shown until the next key press
+func (m Model) SetStatusMessage(message string) Model {
+	m.statusMessage = message
+	return m
+}
+
+// ArchivedOnQuit returns how many todos were archived by the quit archive policy
+func (m Model) ArchivedOnQuit() int {
+	return m.archivedOnQuit
+}
+
+// QuitArchiveError returns why the quit archive policy couldn't archive, or nil
+// The app has already left the screen by then, so the caller reports it.
+func (m Model) QuitArchiveError() error {
+	return m.quitArchiveErr
+}
+
 // Init initializes the model (required by tea.Model interface)
 func (m Model) Init() tea.Cmd {
-	return nil
+	return m.scheduleRefresh()
 }
 
 // Update handles messages (required by tea.Model interface)
@@ -151,6 +195,9 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
+		// Any key press dismisses the toast
+		m.statusMessage = ""
+
 		// Handle delete mode separately
 		if m.deleteMode {
 			switch msg.String() {
@@ -263,9 +310,14 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				// Save/apply if suggestions are not visible OR if there are no matches
 				// This allows users to type new tags and hit Enter directly
 				if !m.showSuggestions || len(m.suggestions) == 0 {
-					if m.filterMode {
+					switch {
+					case m.saveAsMode:
+						m = m.saveAsFile()
+					case m.filterMode:
 						m = m.applyFilter()
-					} else {
+					case m.archiveSearchMode:
+						m = m.applyArchiveSearch()
+					default:
 						m = m.saveTodo()
 					}
 					return m, nil
@@ -275,6 +327,11 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.inputMode = false
 				m.editMode = false
 				m.filterMode = false
+				m.archiveSearchMode = false
+				if m.saveAsMode {
+					m.saveAsMode = false
+					m.input.Placeholder = "Enter todo description..."
+				}
 				m.input.SetValue("")
 				m.showSuggestions = false
 				return m, nil
@@ -283,8 +340,10 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			// Delegate to textinput for character input
 			m.input, cmd = m.input.Update(msg)
 
-			// Update autocomplete suggestions after input changes
-			m = m.updateSuggestions()
+			// Update autocomplete suggestions after input changes (file paths have no tags to suggest)
+			if !m.saveAsMode {
+				m = m.updateSuggestions()
+			}
 
 			return m, cmd
 		}
@@ -292,6 +351,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		// Normal mode key handling
 		switch msg.String() {
 		case "q", "ctrl+c":
+			m = m.applyQuitArchivePolicy()
 			return m, tea.Quit
 		case "1":
 			// Focus on DO FIRST quadrant (from Overview or another quadrant)
@@ -323,12 +383,12 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.viewMode = Overview
 		case "m":
 			// Enter move mode (only in focus mode with todos and not read-only)
-			if m.viewMode != Overview && len(m.currentQuadrantTodos()) > 0 && !m.readOnly {
+			if m.inFocusMode() && len(m.currentQuadrantTodos()) > 0 && !m.readOnly {
 				m.moveMode = true
 			}
 		case "backspace":
 			// Enter delete mode (only in focus mode with todos and not read-only)
-			if m.viewMode != Overview && len(m.currentQuadrantTodos()) > 0 && !m.readOnly {
+			if m.inFocusMode() && len(m.currentQuadrantTodos()) > 0 && !m.readOnly {
 				m.deleteMode = true
 			}
 		case "i":
@@ -343,16 +403,42 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			case Inventory:
 				m.viewMode = Overview
 			}
+		case "t":
+			// Open the trash (only from overview)
+			if m.viewMode == Overview {
+				m = m.openTrash()
+			}
+		case "r":
+			// Restore the selected todo from the trash (not in read-only mode)
+			if m.viewMode == Trash && !m.readOnly {
+				m = m.restoreTodo()
+			}
+		case "A":
+			// Browse the archive (only from overview)
+			if m.viewMode == Overview {
+				m = m.openArchive()
+			}
+		case "/":
+			// Search the archive
+			if m.viewMode == Archive {
+				m.inputMode = true
+				m.archiveSearchMode = true
+				m.input.SetValue(m.archiveQuery)
+				m.input.Focus()
+			}
+		case "u", "U":
+			// Unarchive the selected todo; U also marks it as not done
+			if m.viewMode == Archive && !m.readOnly {
+				m = m.unarchiveTodo(msg.String() == "U")
+			}
 		case "esc":
 			// Return to overview from any other mode
-			if m.viewMode == Inventory {
-				m.viewMode = Overview
-			} else if m.viewMode != Overview {
+			if m.viewMode != Overview {
 				m.viewMode = Overview
 			}
 		case "a":
 			// Enter input mode only if in focus mode and not read-only
-			if m.viewMode != Overview && !m.readOnly {
+			if m.inFocusMode() && !m.readOnly {
 				m.inputMode = true
 				m.editMode = false
 				m.input.SetValue("")
@@ -360,7 +446,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 		case "e":
 			// Enter edit mode only if in focus mode with a selected todo and not read-only
-			if m.viewMode != Overview && !m.readOnly {
+			if m.inFocusMode() && !m.readOnly {
 				// Get the selected todo (handles hideCompleted case)
 				currentTodo, _, ok := m.getSelectedTodo()
 				if ok {
@@ -373,7 +459,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 		case "o":
 			// Open URLs from selected todo (only in focus mode with a selected todo)
-			if m.viewMode != Overview {
+			if m.inFocusMode() {
 				// Get the selected todo (handles hideCompleted case)
 				currentTodo, _, ok := m.getSelectedTodo()
 				if ok {
@@ -394,6 +480,11 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					}
 				}
 			}
+		case "S":
+			// Save a read-only session to a file (Shift+S)
+			if m.viewMode == Overview && m.readOnly && m.saveAs != nil {
+				m = m.startSaveAs()
+			}
 		case "f":
 			// Enter filter mode from overview
 			if m.viewMode == Overview {
@@ -403,14 +494,17 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.input.Focus()
 			}
 		case "c":
-			// Clear filter
-			if m.activeFilter != "" {
+			// Clear archive search or filter
+			if m.viewMode == Archive {
+				m.archiveQuery = ""
+				m = m.rebuildArchiveTable(0)
+			} else if m.activeFilter != "" {
 				m.activeFilter = ""
 				m.viewMode = Overview
 			}
 		case "d":
 			// Archive completed todo (only in focus mode and not read-only)
-			if m.viewMode != Overview && !m.readOnly {
+			if m.inFocusMode() && !m.readOnly {
 				m = m.archiveTodo()
 			}
 		case "D":
@@ -422,7 +516,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			// Toggle hide/show completed items
 			m.hideCompleted = !m.hideCompleted
 			// Rebuild table if in focus mode to reflect the change
-			if m.viewMode != Overview && m.viewMode != Inventory {
+			if m.inFocusMode() {
 				m = m.rebuildTable()
 			}
 		case "down", "s", "j":
@@ -432,8 +526,20 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.inventoryViewport, cmd = m.inventoryViewport.Update(msg)
 				return m, cmd
 			}
+			// Navigate down in the trash
+			if m.viewMode == Trash {
+				var cmd tea.Cmd
+				m.trashTable, cmd = m.trashTable.Update(msg)
+				return m, cmd
+			}
+			// Navigate down in the archive
+			if m.viewMode == Archive {
+				var cmd tea.Cmd
+				m.archiveTable, cmd = m.archiveTable.Update(msg)
+				return m, cmd
+			}
 			// Navigate down in focus mode using table
-			if m.viewMode != Overview {
+			if m.inFocusMode() {
 				var cmd tea.Cmd
 				m.todoTable, cmd = m.todoTable.Update(msg)
 				m.selectedTodoIndex = m.todoTable.Cursor()
@@ -446,8 +552,20 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.inventoryViewport, cmd = m.inventoryViewport.Update(msg)
 				return m, cmd
 			}
+			// Navigate up in the trash
+			if m.viewMode == Trash {
+				var cmd tea.Cmd
+				m.trashTable, cmd = m.trashTable.Update(msg)
+				return m, cmd
+			}
+			// Navigate up in the archive
+			if m.viewMode == Archive {
+				var cmd tea.Cmd
+				m.archiveTable, cmd = m.archiveTable.Update(msg)
+				return m, cmd
+			}
 			// Navigate up in focus mode using table
-			if m.viewMode != Overview {
+			if m.inFocusMode() {
 				var cmd tea.Cmd
 				m.todoTable, cmd = m.todoTable.Update(msg)
 				m.selectedTodoIndex = m.todoTable.Cursor()
@@ -455,7 +573,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 		case " ":
 			// Toggle completion in focus mode (space bar) and not read-only
-			if m.viewMode != Overview && !m.readOnly {
+			if m.inFocusMode() && !m.readOnly {
 				m = m.toggleCompletion()
 			}
 		}
@@ -469,6 +587,10 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				return m, cmd
 			}
 		}
+	case refreshTickMsg:
+		return m, m.fetchRefresh()
+	case refreshedMsg:
+		return m.applyRefresh(msg)
 	case tea.WindowSizeMsg:
 		m.width = msg.Width
 		m.height = msg.Height
@@ -494,7 +616,7 @@ func (m Model) saveTodo() Model {
 	}
 
 	// Expand date shortcuts (due:tomorrow -> due:YYYY-MM-DD)
-	description = expandDateShortcuts(description, time.Now())
+	description = todotxt.ExpandDateShortcuts(description, time.Now())
 
 	if m.repo == nil {
 		return m // No-op if no writer configured
@@ -523,15 +645,15 @@ func (m Model) saveTodo() Model {
 		updatedMatrix, err = usecases.AddTodo(m.repo, m.matrix, description, priority)
 	}
 
-	if err != nil {
-		// TODO: Show error to user in future story
-		return m
+	if !saved(err) {
+		return m.saveFailed(err)
 	}
 
 	m.matrix = updatedMatrix
 
 	// Refresh tag lists
 	m.allProjects, m.allContexts = extractAllTags(m.matrix)
+	m.allSources = extractAllSources(m.matrix)
 
 	if !m.editMode {
 		// Reset selection to first todo only when adding
@@ -567,8 +689,18 @@ func (m Model) applyFilter() Model {
 		prefix = filterInput[0]
 	}
 
-	switch prefix {
-	case '+':
+	switch {
+	case strings.HasPrefix(strings.ToLower(filterInput), matrix.SourceFilterPrefix):
+		// Workspace file filter - normalize to the actual file name casing
+		sourceName := filterInput[len(matrix.SourceFilterPrefix):]
+		m.activeFilter = matrix.SourceFilterPrefix + sourceName
+		for _, source := range m.allSources {
+			if strings.EqualFold(sourceName, source) {
+				m.activeFilter = matrix.SourceFilterPrefix + source
+				break
+			}
+		}
+	case prefix == '+':
 		// Project filter - validate and normalize
 		projectName := filterInput[1:] // Strip the +
 		for _, project := range m.allProjects {
@@ -581,7 +713,7 @@ func (m Model) applyFilter() Model {
 		if m.activeFilter == "" {
 			m.activeFilter = filterInput
 		}
-	case '@':
+	case prefix == '@':
 		// Context filter - validate and normalize
 		contextName := filterInput[1:] // Strip the @
 		for _, context := range m.allContexts {
@@ -630,6 +762,12 @@ func (m Model) applyFilter() Model {
 func (m Model) updateSuggestions() Model {
 	inputValue := m.input.Value()
 
+	// Archive search is free text, so no suggestions
+	if m.archiveSearchMode {
+		m.showSuggestions = false
+		return m
+	}
+
 	// Filter mode: show all projects and contexts
 	if m.filterMode {
 		return m.updateFilterSuggestions(inputValue)
@@ -701,6 +839,14 @@ func (m Model) updateFilterSuggestions(inputValue string) Model {
 		}
 	}
 
+	// Add matching workspace files
+	sourceTerm := strings.TrimPrefix(inputValue, matrix.SourceFilterPrefix)
+	for _, source := range m.allSources {
+		if sourceTerm == "" || strings.HasPrefix(strings.ToLower(source), sourceTerm) {
+			matches = append(matches, matrix.SourceFilterPrefix+source)
+		}
+	}
+
 	m.suggestions = matches
 	m.showSuggestions = len(matches) > 0
 	m.selectedSuggestion = 0
@@ -745,7 +891,7 @@ func (m Model) completeSuggestion() Model {
 
 // currentQuadrantPriority returns the priority for the current focused quadrant
 func (m Model) currentQuadrantPriority() todo.Priority {
-	if meta, ok := quadrantMeta[m.viewMode]; ok {
+	if meta, ok := m.settings.quadrant(m.viewMode); ok {
 		return meta.Priority
 	}
 	return todo.PriorityNone
@@ -761,15 +907,21 @@ func (m Model) currentQuadrantTodos() []todo.Todo {
 	}
 
 	// Get todos from the (possibly filtered) matrix using metadata
-	if meta, ok := quadrantMeta[m.viewMode]; ok {
+	if meta, ok := m.settings.quadrant(m.viewMode); ok {
 		return meta.GetTodos(displayMatrix)
 	}
 	return []todo.Todo{}
 }
 
+// inFocusMode returns true when a single quadrant is focused
+func (m Model) inFocusMode() bool {
+	_, ok := quadrantMeta[m.viewMode]
+	return ok
+}
+
 // currentQuadrantType returns the quadrant type for the current view mode
 func (m Model) currentQuadrantType() matrix.QuadrantType {
-	if meta, ok := quadrantMeta[m.viewMode]; ok {
+	if meta, ok := m.settings.quadrant(m.viewMode); ok {
 		return meta.Type
 	}
 	return matrix.DoFirstQuadrant
@@ -790,9 +942,8 @@ func (m Model) toggleCompletion() Model {
 	// Use the ToggleCompletion usecase
 	quadrant := m.currentQuadrantType()
 	updatedMatrix, err := usecases.ToggleCompletion(m.repo, m.matrix, quadrant, actualIndex)
-	if err != nil {
-		// TODO: Show error to user in future story
-		return m
+	if !saved(err) {
+		return m.saveFailed(err)
 	}
 
 	m.matrix = updatedMatrix
@@ -818,9 +969,8 @@ func (m Model) changeTodoPriority(newPriority todo.Priority) Model {
 	// Use the ChangePriority usecase
 	quadrant := m.currentQuadrantType()
 	updatedMatrix, err := usecases.ChangePriority(m.repo, m.matrix, quadrant, actualIndex, newPriority)
-	if err != nil {
-		// TODO: Show error to user in future story
-		return m
+	if !saved(err) {
+		return m.saveFailed(err)
 	}
 
 	m.matrix = updatedMatrix
@@ -841,9 +991,8 @@ func (m Model) archiveTodo() Model {
 	// Use the ArchiveTodo usecase
 	quadrant := m.currentQuadrantType()
 	updatedMatrix, err := usecases.ArchiveTodo(m.repo, m.matrix, quadrant, actualIndex)
-	if err != nil {
-		// TODO: Show error to user in future story
-		return m
+	if !saved(err) {
+		return m.saveFailed(err)
 	}
 
 	m.matrix = updatedMatrix
@@ -861,7 +1010,7 @@ func (m Model) archiveAllCompleted() Model {
 	var updatedMatrix matrix.Matrix
 	var err error
 
-	if m.viewMode == Overview || m.viewMode == Inventory {
+	if !m.inFocusMode() {
 		// Archive all completed todos across all quadrants
 		updatedMatrix, err = usecases.ArchiveAllCompleted(m.repo, m.matrix)
 	} else {
@@ -870,21 +1019,59 @@ func (m Model) archiveAllCompleted() Model {
 		updatedMatrix, err = usecases.ArchiveCompletedInQuadrant(m.repo, m.matrix, quadrant)
 	}
 
-	if err != nil {
-		// TODO: Show error to user in future story
-		return m
+	if !saved(err) {
+		return m.saveFailed(err)
 	}
 
 	m.matrix = updatedMatrix
 
 	// After archiving, adjust the view if in focus mode
-	if m.viewMode != Overview && m.viewMode != Inventory {
+	if m.inFocusMode() {
 		return m.adjustAfterQuadrantChange()
 	}
 
 	return m
 }
 
+// saved reports whether a use case saved its change; a journal failure doesn't undo it
+func saved(err error) bool {
+	return err == nil || errors.Is(err, usecases.ErrJournal)
+}
+
+// saveFailed shows why a change couldn't be saved and reloads the todos, so the screen shows what is
+// actually stored rather than the change that was lost, e.g. after someone else changed a shared file
+func (m Model) saveFailed(err error) Model {
+	m.statusMessage = "Save failed: " + err.Error()
+	reloaded, loadErr := usecases.LoadMatrix(m.repo)
+	if loadErr != nil {
+		return m
+	}
+	m.matrix = reloaded
+	m.allProjects, m.allContexts = extractAllTags(m.matrix)
+	m.allSources = extractAllSources(m.matrix)
+	if m.inFocusMode() {
+		m = m.adjustAfterQuadrantChange()
+	}
+	return m
+}
+
+// applyQuitArchivePolicy archives completed todos on exit when the policy asks for it
+func (m Model) applyQuitArchivePolicy() Model {
+	if m.repo == nil || m.readOnly {
+		return m
+	}
+
+	updatedMatrix, count, err := usecases.ApplyQuitArchivePolicy(m.repo, m.matrix, m.archivePolicy)
+	m.quitArchiveErr = err
+	if !saved(err) {
+		return m
+	}
+
+	m.matrix = updatedMatrix
+	m.archivedOnQuit = count
+	return m
+}
+
 // deleteTodo deletes the currently selected todo
 func (m Model) deleteTodo() Model {
 	if m.repo == nil {
@@ -899,13 +1086,125 @@ func (m Model) deleteTodo() Model {
 
 	// Use the DeleteTodo usecase
 	updatedMatrix, err := usecases.DeleteTodo(m.repo, m.matrix, todoToDelete)
+	if !saved(err) {
+		return m.saveFailed(err)
+	}
+
+	m.matrix = updatedMatrix
+	return m.adjustAfterQuadrantChange()
+}
+
+// openTrash loads the trash and switches to the trash view
+func (m Model) openTrash() Model {
+	if m.repo == nil {
+		return m // No-op if no repository configured
+	}
+
+	trashed, err := usecases.LoadTrash(m.repo)
 	if err != nil {
-		// TODO: Show error to user in future story
+		m.statusMessage = "Couldn't open the trash: " + err.Error()
 		return m
 	}
 
+	m.viewMode = Trash
+	m.trashTodos = trashed
+	m.trashTable = buildTrashTable(m.trashTodos, m.settings, m.width, m.height, 0)
+	return m
+}
+
+// restoreTodo restores the selected todo from the trash to its original quadrant
+func (m Model) restoreTodo() Model {
+	if m.repo == nil {
+		return m // No-op if no repository configured
+	}
+
+	index := m.trashTable.Cursor()
+	if index < 0 || index >= len(m.trashTodos) {
+		return m
+	}
+
+	updatedMatrix, err := usecases.RestoreTodo(m.repo, m.matrix, m.trashTodos[index])
+	if !saved(err) {
+		return m.saveFailed(err)
+	}
+
 	m.matrix = updatedMatrix
-	return m.adjustAfterQuadrantChange()
+	m.allProjects, m.allContexts = extractAllTags(m.matrix)
+	m.allSources = extractAllSources(m.matrix)
+
+	// Reload the trash so the restored todo disappears from the list
+	m = m.openTrash()
+	m.trashTable.SetCursor(min(index, len(m.trashTodos)-1))
+	return m
+}
+
+// openArchive loads done.txt and switches to the archive view
+func (m Model) openArchive() Model {
+	if m.repo == nil {
+		return m // No-op if no repository configured
+	}
+
+	archived, err := usecases.LoadArchive(m.repo)
+	if err != nil {
+		m.statusMessage = "Couldn't open the archive: " + err.Error()
+		return m
+	}
+
+	m.viewMode = Archive
+	m.archiveTodos = archived
+	return m.rebuildArchiveTable(0)
+}
+
+// applyArchiveSearch applies the search query from input to the archive view
+func (m Model) applyArchiveSearch() Model {
+	m.archiveQuery = strings.TrimSpace(m.input.Value())
+
+	m.inputMode = false
+	m.archiveSearchMode = false
+	m.input.SetValue("")
+
+	return m.rebuildArchiveTable(0)
+}
+
+// visibleArchiveTodos returns the archived todos matching the active search
+func (m Model) visibleArchiveTodos() []todo.Todo {
+	return usecases.SearchArchive(m.archiveTodos, m.archiveQuery)
+}
+
+// rebuildArchiveTable rebuilds the archive table from the current search results
+func (m Model) rebuildArchiveTable(selectedIndex int) Model {
+	m.archiveTable = buildArchiveTable(m.visibleArchiveTodos(), m.settings, m.width, m.height, selectedIndex)
+	return m
+}
+
+// unarchiveTodo moves the selected archived todo back into its original quadrant
+func (m Model) unarchiveTodo(uncomplete bool) Model {
+	if m.repo == nil {
+		return m // No-op if no repository configured
+	}
+
+	visible := m.visibleArchiveTodos()
+	index := m.archiveTable.Cursor()
+	if index < 0 || index >= len(visible) {
+		return m
+	}
+
+	updatedMatrix, err := usecases.UnarchiveTodo(m.repo, m.matrix, visible[index], uncomplete)
+	if !saved(err) {
+		return m.saveFailed(err)
+	}
+
+	m.matrix = updatedMatrix
+	m.allProjects, m.allContexts = extractAllTags(m.matrix)
+	m.allSources = extractAllSources(m.matrix)
+
+	// Reload the archive so the unarchived todo disappears from the list
+	archived, err := usecases.LoadArchive(m.repo)
+	if err != nil {
+		return m
+	}
+	m.archiveTodos = archived
+	return m.rebuildArchiveTable(min(index, len(m.visibleArchiveTodos())-1))
 }
 
 // getSelectedTodo returns the currently selected todo and its index in the full list
@@ -961,8 +1260,8 @@ func (m Model) adjustAfterQuadrantChange() Model {
 
 // rebuildTable rebuilds the todo table based on current quadrant
 func (m Model) rebuildTable() Model {
-	if m.viewMode == Overview {
-		return m // No table in overview mode
+	if !m.inFocusMode() {
+		return m // No quadrant table outside focus mode
 	}
 
 	todos := m.currentQuadrantTodos()
@@ -972,7 +1271,7 @@ func (m Model) rebuildTable() Model {
 		todos = filterActive(todos)
 	}
 
-	m.todoTable = buildTodoTable(todos, m.width, m.height, m.selectedTodoIndex)
+	m.todoTable = buildTodoTable(todos, m.settings.StalePolicy, m.width, m.height, m.selectedTodoIndex)
 	return m
 }
 
@@ -989,7 +1288,7 @@ func (m Model) View() string {
 	// Render based on current view mode
 	switch m.viewMode {
 	case FocusDoFirst, FocusSchedule, FocusDelegate, FocusEliminate, FocusBacklog:
-		meta := quadrantMeta[m.viewMode]
+		meta, _ := m.settings.quadrant(m.viewMode)
 		todos := meta.GetTodos(m.matrix)
 		if m.inputMode {
 			content = RenderFocusedQuadrantWithInput(
@@ -1021,7 +1320,19 @@ func (m Model) View() string {
 		}
 	case Inventory:
 		content = m.inventoryViewport.View()
+	case Trash:
+		content = RenderTrash(m.trashTodos, displayPath, m.trashTable, m.width, m.height, m.readOnly)
+	case Archive:
+		searchLine := ""
+		if m.inputMode && m.archiveSearchMode {
+			searchLine = m.input.View()
+		}
+		content = RenderArchive(m.visibleArchiveTodos(), displayPath, m.archiveTable, m.archiveQuery, searchLine, m.width, m.height, m.readOnly)
 	default: // Overview
+		if m.inputMode && m.saveAsMode {
+			return RenderSaveAsInput(displayPath, m.input, m.width, m.height)
+		}
+
 		// If in filter input mode, show filter input
 		if m.inputMode && m.filterMode {
 			content = RenderFilterInput(
@@ -1044,7 +1355,14 @@ func (m Model) View() string {
 
 		// Pass terminal dimensions to RenderMatrix for responsive sizing
 		// Pass the active filter for help text display only
-		content = RenderMatrixWithFilterHint(displayMatrix, displayPath, m.width, m.height, m.activeFilter, m.readOnly, m.hideCompleted)
+		content = renderMatrix(displayMatrix, m.settings, displayPath, m.width, m.height, m.activeFilter, m.readOnly, m.hideCompleted)
+		if m.readOnly && m.saveAs != nil {
+			content += "\n" + renderHelp("S to save as a file")
+		}
+
+		if m.statusMessage != "" {
+			content += "\n\n" + RenderToast(m.statusMessage)
+		}
 
 		// Center the content in the terminal if we have dimensions
 		if m.width > 0 && m.height > 0 {
@@ -1056,7 +1374,7 @@ func (m Model) View() string {
 
 	// If in move mode, overlay the move dialog
 	if m.moveMode {
-		return RenderMoveOverlay(m.width, m.height)
+		return renderMoveOverlay(m.settings, m.width, m.height)
 	}
 
 	// If in delete mode, overlay the delete confirmation dialog
@@ -1069,6 +1387,10 @@ func (m Model) View() string {
 		return RenderURLSelectionOverlay(m.urls, m.selectedURLIndex, m.width, m.height)
 	}
 
+	if m.statusMessage != "" {
+		content += "\n" + RenderToast(m.statusMessage)
+	}
+
 	// Focus mode content is already full-width and properly aligned
 	return content
 }
@@ -0,0 +1,104 @@
+package ui
+
+import (
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// PassphraseModel asks for the passphrase of an encrypted todo file before the matrix is shown
+type PassphraseModel struct {
+	filePath  string
+	input     textinput.Model
+	submitted bool
+
+	newFile  bool   // the file doesn't exist yet, so the passphrase is typed twice
+	first    string // the passphrase typed the first time, while it is being repeated
+	mismatch bool   // the last repeat didn't match
+}
+
+// NewPassphraseModel creates a passphrase prompt for the given encrypted file
+func NewPassphraseModel(filePath string) PassphraseModel {
+	ti := textinput.New()
+	ti.Placeholder = "passphrase"
+	ti.EchoMode = textinput.EchoPassword
+	ti.EchoCharacter = '•'
+	ti.Width = 40
+	ti.Focus()
+
+	return PassphraseModel{filePath: filePath, input: ti}
+}
+
+// SetNewFile asks for the passphrase twice, as a typo in the passphrase of a new file would lock
+// the user out of it
+func (m PassphraseModel) SetNewFile() PassphraseModel {
+	m.newFile = true
+	return m
+}
+
+// Init starts the cursor blinking (required by tea.Model interface)
+func (m PassphraseModel) Init() tea.Cmd {
+	return textinput.Blink
+}
+
+// Update handles key presses (required by tea.Model interface)
+// Enter submits a non-empty passphrase; esc or ctrl+c cancels
+func (m PassphraseModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if key, ok := msg.(tea.KeyMsg); ok {
+		switch key.String() {
+		case "enter":
+			value := m.input.Value()
+			if value == "" {
+				return m, nil
+			}
+			if m.newFile {
+				m.input.SetValue("")
+				if m.first == "" {
+					m.first, m.mismatch = value, false
+					return m, nil
+				}
+				if value != m.first {
+					m.first, m.mismatch = "", true
+					return m, nil
+				}
+				m.input.SetValue(value)
+			}
+			m.submitted = true
+			return m, tea.Quit
+		case "esc", "ctrl+c":
+			return m, tea.Quit
+		}
+	}
+
+	var cmd tea.Cmd
+	m.input, cmd = m.input.Update(msg)
+	return m, cmd
+}
+
+// View renders the prompt (required by tea.Model interface)
+func (m PassphraseModel) View() string {
+	if m.submitted {
+		return ""
+	}
+
+	heading, action := "Unlock "+m.filePath, "enter to unlock"
+	if m.newFile {
+		heading, action = "Choose a passphrase for "+m.filePath, "enter to continue"
+		if m.first != "" {
+			heading, action = "Repeat the passphrase for "+m.filePath, "enter to create"
+		}
+	}
+
+	title := lipgloss.NewStyle().Bold(true).Foreground(TextPrimary).Render(heading)
+	help := renderHelp(action, "esc to cancel")
+	view := title + "\n\n" + m.input.View() + "\n\n"
+	if m.mismatch {
+		view += lipgloss.NewStyle().Foreground(TextSecondary).Render("The passphrases didn't match, try again") + "\n\n"
+	}
+	return view + help + "\n"
+}
+
+// Passphrase returns the entered passphrase, and false if the prompt was cancelled
+func (m PassphraseModel) Passphrase() (string, bool) {
+	return m.input.Value(), m.submitted
+}
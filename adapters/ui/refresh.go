@@ -0,0 +1,81 @@
+package ui
+
+import (
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/quii/todo-eisenhower/domain/matrix"
+	"github.com/quii/todo-eisenhower/domain/todo"
+)
+
+// RefreshFunc fetches the latest todos for a read-only source
+// changed is false when the todos are the same as the last fetch
+type RefreshFunc func() (todos []todo.Todo, changed bool, err error)
+
+// refreshTickMsg is sent when it's time to fetch the source again
+type refreshTickMsg struct{}
+
+// refreshedMsg carries the result of a fetch
+type refreshedMsg struct {
+	todos   []todo.Todo
+	changed bool
+	err     error
+}
+
+// SetRefresh re-fetches the todos every interval (used for read-only remote sources)
+func (m Model) SetRefresh(interval time.Duration, refresh RefreshFunc) Model {
+	m.refreshInterval = interval
+	m.refresh = refresh
+	return m
+}
+
+// scheduleRefresh waits for the refresh interval, if refreshing is enabled
+func (m Model) scheduleRefresh() tea.Cmd {
+	if m.refresh == nil || m.refreshInterval <= 0 {
+		return nil
+	}
+	return tea.Tick(m.refreshInterval, func(time.Time) tea.Msg {
+		return refreshTickMsg{}
+	})
+}
+
+// fetchRefresh fetches the todos in the background
+// A tick scheduled before the list was saved as a file finds nothing left to fetch
+func (m Model) fetchRefresh() tea.Cmd {
+	if m.refresh == nil {
+		return nil
+	}
+	refresh := m.refresh
+	return func() tea.Msg {
+		todos, changed, err := refresh()
+		return refreshedMsg{todos: todos, changed: changed, err: err}
+	}
+}
+
+// applyRefresh replaces the matrix with freshly fetched todos and schedules the next refresh
+// A failed fetch keeps the todos already on screen and says so in a toast.
+// A fetch that finishes after the list was saved as a file is dropped, so it can't overwrite the saved file.
+func (m Model) applyRefresh(msg refreshedMsg) (Model, tea.Cmd) {
+	if m.refresh == nil {
+		return m, nil
+	}
+
+	switch {
+	case msg.err != nil:
+		m.statusMessage = "Refresh failed, showing the last version: " + msg.err.Error()
+	case msg.changed:
+		if m.repo != nil {
+			if err := m.repo.SaveAll(msg.todos); err != nil {
+				m.statusMessage = "Refresh failed: " + err.Error()
+				return m, m.scheduleRefresh()
+			}
+		}
+		m.matrix = matrix.New(msg.todos)
+		m.allProjects, m.allContexts = extractAllTags(m.matrix)
+		m.allSources = extractAllSources(m.matrix)
+		if m.inFocusMode() {
+			m = m.adjustAfterQuadrantChange()
+		}
+	}
+	return m, m.scheduleRefresh()
+}
@@ -56,12 +56,7 @@ func buildDescriptionWithTags(t todo.Todo) string {
 }
 
 var (
-	// Color palette - Eisenhower matrix themed
-	urgentImportantColor = lipgloss.Color("#FF6B6B") // Red - Do First
-	importantColor       = lipgloss.Color("#4ECDC4") // Teal - Schedule
-	urgentColor          = lipgloss.Color("#FFE66D") // Yellow - Delegate
-	neitherColor         = lipgloss.Color("#95E1D3") // Light green - Eliminate
-
+	// Quadrant colors live in quadrantMeta so they can be configured
 	headerStyle = lipgloss.NewStyle().
 			Bold(true).
 			Foreground(lipgloss.Color("#FAFAFA")).
@@ -99,6 +94,11 @@ func RenderMatrix(m matrix.Matrix, filePath string, terminalWidth, terminalHeigh
 // RenderMatrixWithFilterHint renders the matrix with a filter hint for help text
 // The activeFilter parameter is only used for display (help text), not for actual filtering
 func RenderMatrixWithFilterHint(m matrix.Matrix, filePath string, terminalWidth, terminalHeight int, activeFilter string, readOnly, hideCompleted bool) string {
+	return renderMatrix(m, DefaultSettings(), filePath, terminalWidth, terminalHeight, activeFilter, readOnly, hideCompleted)
+}
+
+// renderMatrix renders the matrix with the quadrant titles, colors and thresholds from the settings
+func renderMatrix(m matrix.Matrix, s Settings, filePath string, terminalWidth, terminalHeight int, activeFilter string, readOnly, hideCompleted bool) string {
 	// Calculate quadrant dimensions based on terminal size
 	quadrantWidth, quadrantHeight := calculateQuadrantDimensions(terminalWidth, terminalHeight)
 	// For overview mode, always show top 5 todos per quadrant (cleaner, more consistent)
@@ -128,10 +128,10 @@ func RenderMatrixWithFilterHint(m matrix.Matrix, filePath string, terminalWidth,
 	}
 
 	// Render quadrant contents
-	doFirst := renderQuadrantContent("Do First", urgentImportantColor, doFirstTodos, quadrantWidth, quadrantHeight, displayLimit, 1)
-	schedule := renderQuadrantContent("Schedule", importantColor, scheduleTodos, quadrantWidth, quadrantHeight, displayLimit, 2)
-	delegate := renderQuadrantContent("Delegate", urgentColor, delegateTodos, quadrantWidth, quadrantHeight, displayLimit, 3)
-	eliminate := renderQuadrantContent("Eliminate", neitherColor, eliminateTodos, quadrantWidth, quadrantHeight, displayLimit, 4)
+	doFirst := renderQuadrantContent(s.style(matrix.DoFirstQuadrant), s.StalePolicy, doFirstTodos, quadrantWidth, quadrantHeight, displayLimit, 1)
+	schedule := renderQuadrantContent(s.style(matrix.ScheduleQuadrant), s.StalePolicy, scheduleTodos, quadrantWidth, quadrantHeight, displayLimit, 2)
+	delegate := renderQuadrantContent(s.style(matrix.DelegateQuadrant), s.StalePolicy, delegateTodos, quadrantWidth, quadrantHeight, displayLimit, 3)
+	eliminate := renderQuadrantContent(s.style(matrix.EliminateQuadrant), s.StalePolicy, eliminateTodos, quadrantWidth, quadrantHeight, displayLimit, 4)
 
 	// Create vertical divider that spans quadrant height
 	verticalDivider := createVerticalDivider(quadrantHeight)
@@ -166,7 +166,7 @@ func RenderMatrixWithFilterHint(m matrix.Matrix, filePath string, terminalWidth,
 	output.WriteString("\n\n")
 
 	// Add tag inventory
-	inventory := renderTagInventory(m, terminalWidth)
+	inventory := renderTagInventory(m, terminalWidth, s.WIPThreshold)
 	output.WriteString(inventory)
 	output.WriteString("\n\n")
 
@@ -176,9 +176,9 @@ func RenderMatrixWithFilterHint(m matrix.Matrix, filePath string, terminalWidth,
 	backlogCount := len(m.Backlog())
 	backlogHint := fmt.Sprintf("5: Backlog (%d)", backlogCount)
 	if activeFilter != "" {
-		helpText = renderHelp("1-4 to focus", backlogHint, "c to clear filter", "h to hide/show completed", "i for inventory", "q to quit")
+		helpText = renderHelp("1-4 to focus", backlogHint, "c to clear filter", "h to hide/show completed", "i for inventory", "A for archive", "t for trash", "q to quit")
 	} else {
-		helpText = renderHelp("1-4 to focus", backlogHint, "f to filter", "h to hide/show completed", "i for inventory", "q to quit")
+		helpText = renderHelp("1-4 to focus", backlogHint, "f to filter", "h to hide/show completed", "i for inventory", "A for archive", "t for trash", "q to quit")
 	}
 	output.WriteString(helpText)
 
@@ -400,8 +400,9 @@ func colorizeDescription(description string) string {
 }
 
 // renderQuadrantContent renders just the content of a quadrant (no border)
-func renderQuadrantContent(title string, color lipgloss.Color, todos []todo.Todo, width, height, displayLimit, quadrantNumber int) string {
+func renderQuadrantContent(style QuadrantStyle, stalePolicy todo.StalePolicy, todos []todo.Todo, width, height, displayLimit, quadrantNumber int) string {
 	var lines []string
+	title, color := style.Title, style.Color
 
 	// Calculate stats
 	totalTasks := len(todos)
@@ -454,7 +455,7 @@ func renderQuadrantContent(title string, color lipgloss.Color, todos []todo.Todo
 			} else {
 				// Add ! prefix for stale tasks in overview mode
 				prefix := "• "
-				if t.IsStale(time.Now()) {
+				if stalePolicy.IsStale(t, time.Now()) {
 					prefix = "! "
 				}
 				todoLine = activeTodoStyle.Render(prefix) + description
@@ -564,8 +565,18 @@ func RenderFocusedQuadrantWithTable(
 	return output.String()
 }
 
+// hasSources returns true if any of the todos came from a named workspace file
+func hasSources(todos []todo.Todo) bool {
+	for _, t := range todos {
+		if t.Source() != "" {
+			return true
+		}
+	}
+	return false
+}
+
 // buildTodoTable creates a table.Model from a list of todos
-func buildTodoTable(todos []todo.Todo, terminalWidth, terminalHeight, selectedIndex int) table.Model {
+func buildTodoTable(todos []todo.Todo, stalePolicy todo.StalePolicy, terminalWidth, terminalHeight, selectedIndex int) table.Model {
 	// Calculate column widths based on terminal width
 	// Reserve some width for borders, padding, etc.
 	availableWidth := max(terminalWidth-10, 80)
@@ -577,7 +588,14 @@ func buildTodoTable(todos []todo.Todo, terminalWidth, terminalHeight, selectedIn
 	createdWidth := 12
 	completedWidth := 12
 	dueDateWidth := 12
-	taskWidth := max(availableWidth-projectsWidth-contextsWidth-createdWidth-completedWidth-dueDateWidth, 30)
+
+	// Workspaces spanning several files get a column showing which file each todo lives in
+	showSource := hasSources(todos)
+	sourceWidth := 0
+	if showSource {
+		sourceWidth = 12
+	}
+	taskWidth := max(availableWidth-projectsWidth-contextsWidth-createdWidth-completedWidth-dueDateWidth-sourceWidth, 30)
 
 	columns := []table.Column{
 		{Title: "Task", Width: taskWidth},
@@ -587,6 +605,9 @@ func buildTodoTable(todos []todo.Todo, terminalWidth, terminalHeight, selectedIn
 		{Title: "Completed", Width: completedWidth},
 		{Title: "Due Date", Width: dueDateWidth},
 	}
+	if showSource {
+		columns = append(columns, table.Column{Title: "Source", Width: sourceWidth})
+	}
 
 	// Build rows from todos
 	rows := make([]table.Row, len(todos))
@@ -596,7 +617,7 @@ func buildTodoTable(todos []todo.Todo, terminalWidth, terminalHeight, selectedIn
 		taskDesc := t.Description()
 
 		// Apply stale background if task is stale
-		if t.IsStale(time.Now()) {
+		if stalePolicy.IsStale(t, time.Now()) {
 			staleStyle := lipgloss.NewStyle().Background(StaleBgColor)
 			taskDesc = staleStyle.Render(taskDesc)
 		}
@@ -640,6 +661,13 @@ func buildTodoTable(todos []todo.Todo, terminalWidth, terminalHeight, selectedIn
 		}
 
 		rows[i] = table.Row{taskDesc, projects, contexts, created, completed, dueDate}
+		if showSource {
+			source := t.Source()
+			if source == "" {
+				source = "-"
+			}
+			rows[i] = append(rows[i], source)
+		}
 	}
 
 	// Track which rows are completed for styling
@@ -800,15 +828,20 @@ func renderCenteredOverlay(content string, width int, borderColor lipgloss.Termi
 
 // RenderMoveOverlay renders an overlay for move mode
 func RenderMoveOverlay(terminalWidth, terminalHeight int) string {
+	return renderMoveOverlay(DefaultSettings(), terminalWidth, terminalHeight)
+}
+
+// renderMoveOverlay renders the move overlay using the configured quadrant titles
+func renderMoveOverlay(s Settings, terminalWidth, terminalHeight int) string {
 	content := lipgloss.NewStyle().
 		Bold(true).
 		Render("Move to quadrant:") + "\n\n"
 
-	content += "  1. Do First\n"
-	content += "  2. Schedule\n"
-	content += "  3. Delegate\n"
-	content += "  4. Eliminate\n"
-	content += "  5. Backlog\n\n"
+	content += "  1. " + s.style(matrix.DoFirstQuadrant).Title + "\n"
+	content += "  2. " + s.style(matrix.ScheduleQuadrant).Title + "\n"
+	content += "  3. " + s.style(matrix.DelegateQuadrant).Title + "\n"
+	content += "  4. " + s.style(matrix.EliminateQuadrant).Title + "\n"
+	content += "  5. " + s.style(matrix.BacklogQuadrant).Title + "\n\n"
 
 	content += lipgloss.NewStyle().
 		Foreground(lipgloss.Color("240")).
@@ -0,0 +1,81 @@
+package ui
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/bubbles/table"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/quii/todo-eisenhower/domain/todo"
+)
+
+// archiveColor is the accent color for the archive view
+var archiveColor = lipgloss.Color("#7B68EE")
+
+// RenderArchive renders archived todos (done.txt) with search and unarchive controls
+// searchLine is the rendered search input while searching, or empty otherwise
+func RenderArchive(
+	todos []todo.Todo,
+	filePath string,
+	archiveTable table.Model,
+	query string,
+	searchLine string,
+	terminalWidth, terminalHeight int,
+	readOnly bool,
+) string {
+	var output strings.Builder
+
+	// Render file path header with full width and center alignment
+	if filePath != "" {
+		header := headerStyle.
+			Width(terminalWidth).
+			Align(lipgloss.Center).
+			Render("File: " + filePath)
+		output.WriteString(header)
+		output.WriteString("\n\n")
+	}
+
+	gradientTitle := GradientBackground(" Archive ", archiveColor, lightenColor(archiveColor, 0.5))
+	title := lipgloss.NewStyle().
+		Align(lipgloss.Center).
+		Width(terminalWidth).
+		Bold(true).
+		Render(gradientTitle)
+	output.WriteString(title)
+	output.WriteString("\n\n")
+
+	// Search input while searching, otherwise the active query (if any)
+	centered := lipgloss.NewStyle().Width(terminalWidth).Align(lipgloss.Center)
+	switch {
+	case searchLine != "":
+		output.WriteString(centered.Render("Search: " + searchLine))
+		output.WriteString("\n\n")
+	case query != "":
+		output.WriteString(centered.Render(emptyStyle.Render("Showing matches for: " + query)))
+		output.WriteString("\n\n")
+	}
+
+	if len(todos) == 0 {
+		emptyMsg := "(archive is empty)"
+		if query != "" {
+			emptyMsg = "(no archived todos match)"
+		}
+		output.WriteString(centered.Render(emptyStyle.Render(emptyMsg)))
+	} else {
+		output.WriteString(archiveTable.View())
+	}
+
+	output.WriteString("\n\n")
+
+	var helpText string
+	switch {
+	case searchLine != "":
+		helpText = renderHelp("Enter to search", "ESC to cancel")
+	case readOnly || len(todos) == 0:
+		helpText = renderHelp("/ to search", "c to clear search", "ESC to return", "q to quit")
+	default:
+		helpText = renderHelp("/ to search", "c to clear search", "u to unarchive", "U to unarchive as not done", "ESC to return")
+	}
+	output.WriteString(centered.Render(helpText))
+
+	return output.String()
+}
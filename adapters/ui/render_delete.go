@@ -10,7 +10,7 @@ func RenderDeleteOverlay(terminalWidth, terminalHeight int) string {
 		Bold(true).
 		Render("Delete this todo?") + "\n\n"
 
-	content += "  y - Yes, delete it\n"
+	content += "  y - Yes, move it to the trash\n"
 	content += "  n - No, keep it\n\n"
 
 	content += lipgloss.NewStyle().
@@ -18,5 +18,5 @@ func RenderDeleteOverlay(terminalWidth, terminalHeight int) string {
 		Italic(true).
 		Render("Press ESC to cancel")
 
-	return renderCenteredOverlay(content, 30, lipgloss.Color("#7B68EE"), terminalWidth, terminalHeight)
+	return renderCenteredOverlay(content, 36, lipgloss.Color("#7B68EE"), terminalWidth, terminalHeight)
 }
@@ -0,0 +1,88 @@
+package ui
+
+import (
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/table"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/quii/todo-eisenhower/domain/todo"
+)
+
+// buildTrashTable creates a table.Model listing deleted todos
+func buildTrashTable(todos []todo.Todo, settings Settings, terminalWidth, terminalHeight, selectedIndex int) table.Model {
+	return buildHistoryTable(todos, settings, "Deleted", todo.Todo.DeletedDate, terminalWidth, terminalHeight, selectedIndex)
+}
+
+// buildArchiveTable creates a table.Model listing archived todos
+func buildArchiveTable(todos []todo.Todo, settings Settings, terminalWidth, terminalHeight, selectedIndex int) table.Model {
+	return buildHistoryTable(todos, settings, "Completed", todo.Todo.CompletionDate, terminalWidth, terminalHeight, selectedIndex)
+}
+
+// buildHistoryTable creates a table.Model for todos that have left the matrix (trash, archive)
+// Each row shows the quadrant the todo came from and the date it left
+func buildHistoryTable(todos []todo.Todo, settings Settings, dateTitle string, dateOf func(todo.Todo) *time.Time, terminalWidth, terminalHeight, selectedIndex int) table.Model {
+	availableWidth := max(terminalWidth-10, 80)
+
+	quadrantWidth := 12
+	projectsWidth := 15
+	contextsWidth := 15
+	dateWidth := 12
+	taskWidth := max(availableWidth-quadrantWidth-projectsWidth-contextsWidth-dateWidth, 30)
+
+	columns := []table.Column{
+		{Title: "Task", Width: taskWidth},
+		{Title: "Quadrant", Width: quadrantWidth},
+		{Title: "Projects", Width: projectsWidth},
+		{Title: "Contexts", Width: contextsWidth},
+		{Title: dateTitle, Width: dateWidth},
+	}
+
+	rows := make([]table.Row, len(todos))
+	for i, t := range todos {
+		projects := strings.Join(t.Projects(), ", ")
+		if projects == "" {
+			projects = "-"
+		}
+
+		contexts := strings.Join(t.Contexts(), ", ")
+		if contexts == "" {
+			contexts = "-"
+		}
+
+		date := formatDate(dateOf(t))
+		if date == "" {
+			date = "-"
+		}
+
+		rows[i] = table.Row{t.Description(), settings.titleForPriority(t.Priority()), projects, contexts, date}
+	}
+
+	// Reserve space for header, title, search line and help text
+	tableHeight := max(terminalHeight-12, 5)
+
+	t := table.New(
+		table.WithColumns(columns),
+		table.WithRows(rows),
+		table.WithFocused(true),
+		table.WithHeight(tableHeight),
+	)
+
+	s := table.DefaultStyles()
+	s.Header = s.Header.
+		BorderStyle(lipgloss.NormalBorder()).
+		BorderForeground(BorderColor).
+		BorderBottom(true).
+		Bold(false)
+	s.Selected = s.Selected.
+		Foreground(TextPrimary).
+		Background(SelectionBg).
+		Bold(true)
+	t.SetStyles(s)
+
+	if selectedIndex >= 0 && selectedIndex < len(rows) {
+		t.SetCursor(selectedIndex)
+	}
+
+	return t
+}
@@ -0,0 +1,60 @@
+package ui
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// RenderSaveAsInput renders the prompt for the file to save a read-only session to
+func RenderSaveAsInput(filePath string, input textinput.Model, terminalWidth, terminalHeight int) string {
+	var output strings.Builder
+
+	// Render file path header
+	if filePath != "" {
+		header := headerStyle.
+			Width(terminalWidth).
+			Align(lipgloss.Center).
+			Render("File: " + filePath)
+		output.WriteString(header)
+		output.WriteString("\n\n")
+	}
+
+	title := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(TextPrimary).
+		Align(lipgloss.Center).
+		Width(terminalWidth).
+		Render("Save As")
+	output.WriteString(title)
+	output.WriteString("\n\n")
+
+	instructions := lipgloss.NewStyle().
+		Foreground(TextSecondary).
+		Italic(true).
+		Align(lipgloss.Center).
+		Width(terminalWidth).
+		Render("Save these todos to a new todo.txt file and keep editing there")
+	output.WriteString(instructions)
+	output.WriteString("\n\n")
+
+	inputLine := lipgloss.NewStyle().Foreground(TextPrimary).Render("Path: ") + input.View()
+	output.WriteString(lipgloss.NewStyle().
+		Align(lipgloss.Center).
+		Width(terminalWidth).
+		Render(inputLine))
+	output.WriteString("\n\n")
+
+	helpText := renderHelp("Enter to save", "ESC to cancel")
+	output.WriteString(lipgloss.NewStyle().
+		Align(lipgloss.Center).
+		Width(terminalWidth).
+		Render(helpText))
+
+	content := output.String()
+	if terminalWidth > 0 && terminalHeight > 0 {
+		return lipgloss.Place(terminalWidth, terminalHeight, lipgloss.Center, lipgloss.Center, content)
+	}
+	return content
+}
@@ -0,0 +1,13 @@
+package ui
+
+import (
+	"github.com/charmbracelet/lipgloss"
+)
+
+// RenderToast renders a short status message shown beneath the matrix
+func RenderToast(message string) string {
+	return lipgloss.NewStyle().
+		Foreground(TextSecondary).
+		Italic(true).
+		Render(message)
+}
@@ -0,0 +1,67 @@
+package ui
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/bubbles/table"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/quii/todo-eisenhower/domain/todo"
+)
+
+// trashColor is the accent color for the trash view
+var trashColor = lipgloss.Color("#A0A0A0")
+
+// RenderTrash renders the recently deleted todos with their original quadrant
+func RenderTrash(
+	todos []todo.Todo,
+	filePath string,
+	trashTable table.Model,
+	terminalWidth, terminalHeight int,
+	readOnly bool,
+) string {
+	var output strings.Builder
+
+	// Render file path header with full width and center alignment
+	if filePath != "" {
+		header := headerStyle.
+			Width(terminalWidth).
+			Align(lipgloss.Center).
+			Render("File: " + filePath)
+		output.WriteString(header)
+		output.WriteString("\n\n")
+	}
+
+	gradientTitle := GradientBackground(" Recently Deleted ", trashColor, lightenColor(trashColor, 0.5))
+	title := lipgloss.NewStyle().
+		Align(lipgloss.Center).
+		Width(terminalWidth).
+		Bold(true).
+		Render(gradientTitle)
+	output.WriteString(title)
+	output.WriteString("\n\n")
+
+	if len(todos) == 0 {
+		emptyMsg := emptyStyle.Render("(trash is empty)")
+		output.WriteString(lipgloss.NewStyle().
+			Width(terminalWidth).
+			Align(lipgloss.Center).
+			Render(emptyMsg))
+	} else {
+		output.WriteString(trashTable.View())
+	}
+
+	output.WriteString("\n\n")
+
+	var helpText string
+	if readOnly || len(todos) == 0 {
+		helpText = renderHelp("ESC to return", "q to quit")
+	} else {
+		helpText = renderHelp("r to restore", "ESC to return", "q to quit")
+	}
+	output.WriteString(lipgloss.NewStyle().
+		Align(lipgloss.Center).
+		Width(terminalWidth).
+		Render(helpText))
+
+	return output.String()
+}
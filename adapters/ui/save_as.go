@@ -0,0 +1,60 @@
+package ui
+
+import (
+	"fmt"
+
+	"github.com/quii/todo-eisenhower/usecases"
+)
+
+// SaveAsFunc opens a new, writable repository at path
+// It returns the repository and the path to show in the header
+type SaveAsFunc func(path string) (usecases.TodoRepository, string, error)
+
+// SetSaveAs lets a read-only session (stdin or a remote list) be saved to a file with S
+func (m Model) SetSaveAs(saveAs SaveAsFunc) Model {
+	m.saveAs = saveAs
+	return m
+}
+
+// startSaveAs prompts for the file to save to
+func (m Model) startSaveAs() Model {
+	m.inputMode = true
+	m.saveAsMode = true
+	m.input.SetValue("")
+	m.input.Placeholder = "todo.txt"
+	m.input.Focus()
+	return m
+}
+
+// saveAsFile writes every todo to the file entered at the prompt and switches the session to it
+// From then on the session is writable and every change is saved to the new file
+func (m Model) saveAsFile() Model {
+	path := m.input.Value()
+
+	m.inputMode = false
+	m.saveAsMode = false
+	m.input.SetValue("")
+	m.input.Placeholder = "Enter todo description..."
+
+	if path == "" {
+		return m
+	}
+
+	repo, displayPath, err := m.saveAs(path)
+	if err != nil {
+		m.statusMessage = "Save failed: " + err.Error()
+		return m
+	}
+	if err := usecases.SaveAs(repo, m.matrix); err != nil {
+		m.statusMessage = "Save failed: " + err.Error()
+		return m
+	}
+
+	m.repo = repo
+	m.filePath = displayPath
+	m.readOnly = false
+	m.saveAs = nil
+	m.refresh = nil // a remote list is no longer followed once it has been saved
+	m.statusMessage = fmt.Sprintf("Saved %d todos to %s", len(m.matrix.AllTodosIncludingBacklog()), displayPath)
+	return m
+}
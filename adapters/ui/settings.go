@@ -0,0 +1,90 @@
+package ui
+
+import (
+	"github.com/charmbracelet/lipgloss"
+	"github.com/quii/todo-eisenhower/domain/matrix"
+	"github.com/quii/todo-eisenhower/domain/todo"
+)
+
+// QuadrantStyle is how a quadrant is labelled on screen
+type QuadrantStyle struct {
+	Title string
+	Color lipgloss.Color
+}
+
+// Settings are the user-configurable parts of the UI
+type Settings struct {
+	Quadrants    map[matrix.QuadrantType]QuadrantStyle
+	CharLimit    int              // longest todo description accepted by the input
+	WIPThreshold int              // tags with more incomplete todos than this are flagged
+	StalePolicy  todo.StalePolicy // when todos are highlighted as stale
+}
+
+// DefaultSettings returns the settings used when nothing is configured
+func DefaultSettings() Settings {
+	quadrants := make(map[matrix.QuadrantType]QuadrantStyle, len(quadrantMeta))
+	for _, meta := range quadrantMeta {
+		quadrants[meta.Type] = QuadrantStyle{Title: meta.Title, Color: meta.Color}
+	}
+	return Settings{
+		Quadrants:    quadrants,
+		CharLimit:    todo.DefaultCharLimit,
+		WIPThreshold: todo.DefaultWIPThreshold,
+		StalePolicy:  todo.DefaultStalePolicy,
+	}
+}
+
+// SetSettings applies user settings (quadrant titles and colors, thresholds, input limit)
+// Zero values and missing quadrants keep their defaults
+func (m Model) SetSettings(s Settings) Model {
+	defaults := DefaultSettings()
+	for q, style := range s.Quadrants {
+		current := defaults.Quadrants[q]
+		if style.Title != "" {
+			current.Title = style.Title
+		}
+		if style.Color != "" {
+			current.Color = style.Color
+		}
+		defaults.Quadrants[q] = current
+	}
+	if s.CharLimit > 0 {
+		defaults.CharLimit = s.CharLimit
+	}
+	if s.WIPThreshold > 0 {
+		defaults.WIPThreshold = s.WIPThreshold
+	}
+	if s.StalePolicy != (todo.StalePolicy{}) {
+		defaults.StalePolicy = s.StalePolicy
+	}
+
+	m.settings = defaults
+	m.input.CharLimit = defaults.CharLimit
+	return m
+}
+
+// quadrant returns the metadata for a focused quadrant view, styled by the settings
+func (s Settings) quadrant(mode ViewMode) (QuadrantMeta, bool) {
+	meta, ok := quadrantMeta[mode]
+	if !ok {
+		return meta, false
+	}
+	if style, ok := s.Quadrants[meta.Type]; ok {
+		meta.Title = style.Title
+		meta.Color = style.Color
+	}
+	return meta, true
+}
+
+// style returns the title and color of a quadrant
+func (s Settings) style(q matrix.QuadrantType) QuadrantStyle {
+	if style, ok := s.Quadrants[q]; ok {
+		return style
+	}
+	return DefaultSettings().Quadrants[q]
+}
+
+// titleForPriority returns the title of the quadrant a priority belongs to
+func (s Settings) titleForPriority(p todo.Priority) string {
+	return s.style(matrix.QuadrantFor(p)).Title
+}
@@ -46,3 +46,20 @@ func extractAllTags(m matrix.Matrix) (projects, contexts []string) {
 	return projects, contexts
 }
 
+
+// extractAllSources returns the sorted, unique workspace file names of all todos in the matrix
+func extractAllSources(m matrix.Matrix) []string {
+	sourceSet := make(map[string]bool)
+	for _, t := range m.AllTodosIncludingBacklog() {
+		if t.Source() != "" {
+			sourceSet[t.Source()] = true
+		}
+	}
+
+	sources := make([]string, 0, len(sourceSet))
+	for s := range sourceSet {
+		sources = append(sources, s)
+	}
+	sort.Strings(sources)
+	return sources
+}
@@ -0,0 +1,474 @@
+// Package webdav provides a TodoRepository for todo.txt files on a WebDAV (or plain HTTP) server,
+// such as a Nextcloud share.
+//
+// Files are read with GET and written with PUT. Every write is conditional on the ETag seen when the
+// file was last read, so edits made elsewhere in the meantime are reported instead of overwritten.
+// With a cache directory the repository keeps working offline: reads fall back to the cached copy and
+// writes are kept locally until the server can be reached again.
+package webdav
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/quii/todo-eisenhower/domain/todo"
+	"github.com/quii/todo-eisenhower/domain/todotxt"
+)
+
+// ConflictError reports a file that was changed on the server since it was last read
+// Kept is where the rejected local version was saved, if there is a cache to save it in
+type ConflictError struct {
+	URL  string
+	Kept string
+}
+
+// Error explains what happened and how to recover
+func (e *ConflictError) Error() string {
+	msg := fmt.Sprintf("%s was changed on the server since it was loaded, so your change was not saved", e.URL)
+	if e.Kept != "" {
+		msg += fmt.Sprintf(" (your version is in %s)", e.Kept)
+	}
+	return msg + " - reload to see the latest version"
+}
+
+// Repository is a TodoRepository backed by files on a WebDAV server
+// The archive and trash are done.txt and deleted.txt in the same collection as the todo file
+type Repository struct {
+	url      *url.URL
+	client   *http.Client
+	username string
+	password string
+	cacheDir string
+
+	mu      sync.Mutex
+	files   map[string]*fileState // last known server state of each file, by name
+	offline bool                  // the last request failed to reach the server
+}
+
+// fileState is what the repository knows about a file on the server
+type fileState struct {
+	ETag    string `json:"etag,omitempty"`
+	Exists  bool   `json:"exists"`
+	Pending bool   `json:"pending,omitempty"` // the cached copy has changes not yet written to the server
+}
+
+// Option configures optional behaviour of a WebDAV Repository
+type Option func(*Repository)
+
+// WithClient sets the HTTP client used for requests (http.DefaultClient by default)
+func WithClient(client *http.Client) Option {
+	return func(r *Repository) {
+		r.client = client
+	}
+}
+
+// WithBasicAuth sends the username and password with every request
+func WithBasicAuth(username, password string) Option {
+	return func(r *Repository) {
+		r.username = username
+		r.password = password
+	}
+}
+
+// WithCacheDir keeps a copy of every file in dir so the repository can be used offline
+func WithCacheDir(dir string) Option {
+	return func(r *Repository) {
+		r.cacheDir = dir
+	}
+}
+
+// NewRepository creates a repository for the todo file at rawURL
+func NewRepository(rawURL string, opts ...Option) (*Repository, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid WebDAV URL: %w", err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return nil, fmt.Errorf("invalid WebDAV URL %q: scheme must be http or https", rawURL)
+	}
+
+	r := &Repository{
+		url:    u,
+		client: http.DefaultClient,
+		files:  make(map[string]*fileState),
+	}
+	for _, opt := range opts {
+		opt(r)
+	}
+	return r, nil
+}
+
+// Offline reports whether the server couldn't be reached on the last request
+func (r *Repository) Offline() bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.offline
+}
+
+// LoadAll reads todos from the todo file
+// A missing file is treated as an empty todo list
+func (r *Repository) LoadAll() ([]todo.Todo, error) {
+	return r.readTodos(r.todoFile())
+}
+
+// SaveAll writes todos to the todo file (full rewrite)
+func (r *Repository) SaveAll(todos []todo.Todo) error {
+	return r.writeTodos(r.todoFile(), todos)
+}
+
+// AppendToArchive adds a todo to done.txt
+func (r *Repository) AppendToArchive(t todo.Todo) error {
+	return r.appendTodo("done.txt", t)
+}
+
+// LoadArchive reads todos from done.txt
+func (r *Repository) LoadArchive() ([]todo.Todo, error) {
+	return r.readTodos("done.txt")
+}
+
+// SaveArchive writes todos to done.txt (full rewrite)
+func (r *Repository) SaveArchive(todos []todo.Todo) error {
+	return r.writeTodos("done.txt", todos)
+}
+
+// AppendToTrash adds a deleted todo to deleted.txt
+func (r *Repository) AppendToTrash(t todo.Todo) error {
+	return r.appendTodo("deleted.txt", t)
+}
+
+// LoadTrash reads todos from deleted.txt
+func (r *Repository) LoadTrash() ([]todo.Todo, error) {
+	return r.readTodos("deleted.txt")
+}
+
+// SaveTrash writes todos to deleted.txt (full rewrite)
+func (r *Repository) SaveTrash(todos []todo.Todo) error {
+	return r.writeTodos("deleted.txt", todos)
+}
+
+// todoFile returns the name of the todo file within its collection
+func (r *Repository) todoFile() string {
+	return filepath.Base(r.url.Path)
+}
+
+// fileURL resolves a file name against the todo file's collection
+// name is unescaped (as todoFile returns it); String escapes it exactly once
+func (r *Repository) fileURL(name string) string {
+	return r.url.ResolveReference(&url.URL{Path: name}).String()
+}
+
+// appendTodo adds a todo to a file
+// There is no append over HTTP, so the file is read and written back (still conditional on its ETag)
+func (r *Repository) appendTodo(name string, t todo.Todo) error {
+	todos, err := r.readTodos(name)
+	if err != nil {
+		return err
+	}
+	return r.writeTodos(name, append(todos, t))
+}
+
+// readTodos fetches a file and parses its todos
+func (r *Repository) readTodos(name string) ([]todo.Todo, error) {
+	data, err := r.read(name)
+	if err != nil {
+		return nil, err
+	}
+	return todotxt.Unmarshal(bytes.NewReader(data))
+}
+
+// writeTodos formats todos and writes them to a file
+func (r *Repository) writeTodos(name string, todos []todo.Todo) error {
+	var buf bytes.Buffer
+	if err := todotxt.Marshal(&buf, todos); err != nil {
+		return err
+	}
+	return r.write(name, buf.Bytes())
+}
+
+// read returns the contents of a file, preferring local changes that haven't reached the server yet
+func (r *Repository) read(name string) ([]byte, error) {
+	state := r.state(name)
+
+	if state.Pending {
+		// Try to deliver local changes first; the cached copy is the latest either way
+		if err := r.push(name); err != nil {
+			var conflict *ConflictError
+			if errors.As(err, &conflict) {
+				return nil, err
+			}
+		}
+		return r.readCache(name)
+	}
+
+	req, err := r.newRequest(http.MethodGet, name, nil)
+	if err != nil {
+		return nil, err
+	}
+	if state.ETag != "" && r.hasCache(name) {
+		req.Header.Set("If-None-Match", state.ETag)
+	}
+
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return r.readOffline(name, err)
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+	r.setOffline(false)
+
+	switch {
+	case resp.StatusCode == http.StatusNotModified:
+		return r.readCache(name)
+	case resp.StatusCode == http.StatusNotFound:
+		if err := r.writeCache(name, []byte{}, fileState{}); err != nil {
+			return nil, err
+		}
+		r.setState(name, fileState{})
+		return []byte{}, nil
+	case resp.StatusCode >= 200 && resp.StatusCode < 300:
+		data, err := io.ReadAll(resp.Body)
+		if err != nil {
+			return nil, err
+		}
+		newState := fileState{ETag: resp.Header.Get("ETag"), Exists: true}
+		if err := r.writeCache(name, data, newState); err != nil {
+			return nil, err
+		}
+		r.setState(name, newState)
+		return data, nil
+	default:
+		return nil, statusError(http.MethodGet, r.fileURL(name), resp)
+	}
+}
+
+// readOffline falls back to the cached copy of a file when the server can't be reached
+func (r *Repository) readOffline(name string, requestErr error) ([]byte, error) {
+	if !r.hasCache(name) {
+		return nil, requestErr
+	}
+	r.setOffline(true)
+	return r.readCache(name)
+}
+
+// write stores a file in the cache (when there is one) and sends it to the server
+// Offline writes stay in the cache and are sent on a later read or write
+func (r *Repository) write(name string, data []byte) error {
+	state := r.state(name)
+	state.Pending = true
+
+	if r.cacheDir == "" {
+		err := r.put(name, data, state)
+		var conflict *ConflictError
+		if errors.As(err, &conflict) {
+			r.forget(name)
+		}
+		return err
+	}
+
+	if err := r.writeCache(name, data, state); err != nil {
+		return err
+	}
+	r.setState(name, state)
+
+	err := r.push(name)
+	var conflict *ConflictError
+	if err != nil && !errors.As(err, &conflict) && r.Offline() {
+		return nil // kept in the cache until the server is back
+	}
+	return err
+}
+
+// push sends the cached copy of a file to the server
+// If the server has a newer version, the cached copy is set aside as <name>.conflict and the server's
+// version wins
+func (r *Repository) push(name string) error {
+	data, err := r.readCache(name)
+	if err != nil {
+		return err
+	}
+
+	err = r.put(name, data, r.state(name))
+	var conflict *ConflictError
+	if !errors.As(err, &conflict) {
+		return err
+	}
+
+	conflict.Kept = r.cachePath(name) + ".conflict"
+	if writeErr := os.WriteFile(conflict.Kept, data, 0o600); writeErr != nil {
+		return writeErr
+	}
+	r.forget(name)
+	return conflict
+}
+
+// forget drops the local copy of a file and its ETag after a conflict, so the next read fetches the
+// server's version in full and saves are checked against that version once it has been read
+// Until then a save can't overwrite the file on the server, and the rejected version isn't mistaken for it.
+func (r *Repository) forget(name string) {
+	if r.cacheDir != "" {
+		_ = os.Remove(r.cachePath(name))
+		_ = os.Remove(r.cachePath(name) + ".json")
+	}
+	r.setState(name, fileState{})
+}
+
+// put writes a file with PUT, conditional on the server still having the version this repository last saw
+func (r *Repository) put(name string, data []byte, state fileState) error {
+	req, err := r.newRequest(http.MethodPut, name, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "text/plain; charset=utf-8")
+	switch {
+	case state.ETag != "":
+		req.Header.Set("If-Match", state.ETag)
+	case !state.Exists:
+		req.Header.Set("If-None-Match", "*")
+	}
+
+	resp, err := r.client.Do(req)
+	if err != nil {
+		r.setOffline(true)
+		return err
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+	r.setOffline(false)
+
+	switch {
+	case resp.StatusCode == http.StatusPreconditionFailed:
+		return &ConflictError{URL: r.fileURL(name)}
+	case resp.StatusCode < 200 || resp.StatusCode >= 300:
+		return statusError(http.MethodPut, r.fileURL(name), resp)
+	}
+
+	newState := fileState{ETag: resp.Header.Get("ETag"), Exists: true}
+	if newState.ETag == "" {
+		// Some servers don't return the new ETag from PUT, so ask for it
+		newState.ETag = r.headETag(name)
+	}
+	if r.cacheDir != "" {
+		if err := r.writeCache(name, data, newState); err != nil {
+			return err
+		}
+	}
+	r.setState(name, newState)
+	return nil
+}
+
+// headETag returns the current ETag of a file, or "" if the server doesn't provide one
+func (r *Repository) headETag(name string) string {
+	req, err := r.newRequest(http.MethodHead, name, nil)
+	if err != nil {
+		return ""
+	}
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return ""
+	}
+	_ = resp.Body.Close()
+	return resp.Header.Get("ETag")
+}
+
+// newRequest builds a request for a file, with credentials if configured
+func (r *Repository) newRequest(method, name string, body io.Reader) (*http.Request, error) {
+	req, err := http.NewRequest(method, r.fileURL(name), body)
+	if err != nil {
+		return nil, err
+	}
+	if r.username != "" || r.password != "" {
+		req.SetBasicAuth(r.username, r.password)
+	}
+	return req, nil
+}
+
+// state returns what is known about a file, loading it from the cache on first use
+func (r *Repository) state(name string) fileState {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if state, ok := r.files[name]; ok {
+		return *state
+	}
+
+	state := &fileState{}
+	if r.cacheDir != "" {
+		//nolint:gosec // G304: the path is inside the cache directory chosen by the user
+		if data, err := os.ReadFile(r.cachePath(name) + ".json"); err == nil {
+			_ = json.Unmarshal(data, state)
+		}
+	}
+	r.files[name] = state
+	return *state
+}
+
+// setState records what is known about a file
+func (r *Repository) setState(name string, state fileState) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.files[name] = &state
+}
+
+// setOffline records whether the server could be reached
+func (r *Repository) setOffline(offline bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.offline = offline
+}
+
+// cachePath returns where the cached copy of a file is kept
+func (r *Repository) cachePath(name string) string {
+	return filepath.Join(r.cacheDir, name)
+}
+
+// hasCache returns true if there is a cached copy of a file
+func (r *Repository) hasCache(name string) bool {
+	if r.cacheDir == "" {
+		return false
+	}
+	_, err := os.Stat(r.cachePath(name))
+	return err == nil
+}
+
+// readCache returns the cached copy of a file
+func (r *Repository) readCache(name string) ([]byte, error) {
+	//nolint:gosec // G304: the path is inside the cache directory chosen by the user
+	return os.ReadFile(r.cachePath(name))
+}
+
+// writeCache stores a copy of a file and its state in the cache
+func (r *Repository) writeCache(name string, data []byte, state fileState) error {
+	if r.cacheDir == "" {
+		return nil
+	}
+	if err := os.MkdirAll(r.cacheDir, 0o700); err != nil {
+		return err
+	}
+	if err := os.WriteFile(r.cachePath(name), data, 0o600); err != nil {
+		return err
+	}
+
+	meta, err := json.Marshal(state)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(r.cachePath(name)+".json", meta, 0o600)
+}
+
+// statusError describes an unexpected response
+func statusError(method, url string, resp *http.Response) error {
+	if resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden {
+		return fmt.Errorf("%s %s: access denied (%s) - check your WebDAV username and password", method, url, resp.Status)
+	}
+	return fmt.Errorf("%s %s: unexpected response %s", method, url, resp.Status)
+}
@@ -0,0 +1,250 @@
+package webdav_test
+
+import (
+	"errors"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/matryer/is"
+	"github.com/quii/todo-eisenhower/adapters/webdav"
+	"github.com/quii/todo-eisenhower/adapters/webdav/webdavtest"
+	"github.com/quii/todo-eisenhower/domain/todo"
+)
+
+func TestRepository(t *testing.T) {
+	t.Run("loads with GET and saves with PUT", func(t *testing.T) {
+		//nolint:gocritic // importShadow: is := is.New(t) is idiomatic for github.com/matryer/is
+		is := is.New(t)
+		server := webdavtest.NewServer()
+		defer server.Close()
+		server.Put("/dav/todo.txt", "(A) Deploy API +ops\n")
+
+		repo, err := webdav.NewRepository(server.URL + "/dav/todo.txt")
+		is.NoErr(err)
+
+		todos, err := repo.LoadAll()
+		is.NoErr(err)
+		is.Equal(len(todos), 1)
+		is.Equal(todos[0].Description(), "Deploy API")
+
+		is.NoErr(repo.SaveAll(append(todos, todo.New("Book dentist", todo.PriorityB))))
+
+		content, _ := server.File("/dav/todo.txt")
+		is.Equal(content, "(A) Deploy API +ops\n(B) Book dentist\n")
+	})
+
+	t.Run("detects edits made elsewhere since loading", func(t *testing.T) {
+		is := is.New(t)
+		server := webdavtest.NewServer()
+		defer server.Close()
+		server.Put("/dav/todo.txt", "(A) Deploy API\n")
+
+		repo, err := webdav.NewRepository(server.URL + "/dav/todo.txt")
+		is.NoErr(err)
+		_, err = repo.LoadAll()
+		is.NoErr(err)
+
+		server.Put("/dav/todo.txt", "(A) Deploy API\n(B) Added on another machine\n")
+
+		err = repo.SaveAll([]todo.Todo{todo.New("Mine", todo.PriorityA)})
+		var conflict *webdav.ConflictError
+		is.True(errors.As(err, &conflict))
+
+		content, _ := server.File("/dav/todo.txt")
+		is.True(strings.Contains(content, "Added on another machine")) // not overwritten
+	})
+
+	t.Run("saves again after a conflict, against the server's version", func(t *testing.T) {
+		is := is.New(t)
+		for _, opts := range [][]webdav.Option{nil, {webdav.WithCacheDir(t.TempDir())}} {
+			server := webdavtest.NewServer()
+			server.Put("/dav/todo.txt", "(A) Deploy API\n")
+
+			repo, err := webdav.NewRepository(server.URL+"/dav/todo.txt", opts...)
+			is.NoErr(err)
+			_, err = repo.LoadAll()
+			is.NoErr(err)
+
+			server.Put("/dav/todo.txt", "(A) Deploy API\n(B) Added on another machine\n")
+			var conflict *webdav.ConflictError
+			is.True(errors.As(repo.SaveAll([]todo.Todo{todo.New("Mine", todo.PriorityA)}), &conflict))
+			// Saving the same stale list again still can't overwrite the other change
+			is.True(errors.As(repo.SaveAll([]todo.Todo{todo.New("Mine", todo.PriorityA)}), &conflict))
+
+			todos, err := repo.LoadAll()
+			is.NoErr(err)
+			is.Equal(len(todos), 2)
+			is.NoErr(repo.SaveAll(append(todos, todo.New("Mine", todo.PriorityA))))
+
+			content, _ := server.File("/dav/todo.txt")
+			is.Equal(content, "(A) Deploy API\n(B) Added on another machine\n(A) Mine\n")
+			server.Close()
+		}
+	})
+
+	t.Run("does not overwrite a file created elsewhere", func(t *testing.T) {
+		is := is.New(t)
+		server := webdavtest.NewServer()
+		defer server.Close()
+
+		repo, err := webdav.NewRepository(server.URL + "/dav/todo.txt")
+		is.NoErr(err)
+		todos, err := repo.LoadAll()
+		is.NoErr(err)
+		is.Equal(len(todos), 0)
+
+		server.Put("/dav/todo.txt", "(A) Created elsewhere\n")
+
+		err = repo.SaveAll([]todo.Todo{todo.New("Mine", todo.PriorityA)})
+		var conflict *webdav.ConflictError
+		is.True(errors.As(err, &conflict))
+	})
+
+	t.Run("handles spaces in the file name", func(t *testing.T) {
+		is := is.New(t)
+		server := webdavtest.NewServer()
+		defer server.Close()
+		server.Put("/dav/my todos/todo list.txt", "(A) Deploy API\n")
+
+		repo, err := webdav.NewRepository(server.URL + "/dav/my%20todos/todo%20list.txt")
+		is.NoErr(err)
+
+		todos, err := repo.LoadAll()
+		is.NoErr(err)
+		is.Equal(len(todos), 1)
+
+		is.NoErr(repo.SaveAll(append(todos, todo.New("Book dentist", todo.PriorityB))))
+		content, _ := server.File("/dav/my todos/todo list.txt")
+		is.Equal(content, "(A) Deploy API\n(B) Book dentist\n")
+
+		is.NoErr(repo.AppendToArchive(todo.New("Done", todo.PriorityA)))
+		archive, _ := server.File("/dav/my todos/done.txt")
+		is.Equal(archive, "(A) Done\n")
+	})
+
+	t.Run("archive and trash live next to the todo file", func(t *testing.T) {
+		is := is.New(t)
+		server := webdavtest.NewServer()
+		defer server.Close()
+
+		repo, err := webdav.NewRepository(server.URL + "/dav/todo.txt")
+		is.NoErr(err)
+
+		is.NoErr(repo.AppendToArchive(todo.New("Done", todo.PriorityA)))
+		is.NoErr(repo.AppendToTrash(todo.New("Deleted", todo.PriorityB)))
+
+		archive, _ := server.File("/dav/done.txt")
+		is.Equal(archive, "(A) Done\n")
+		trash, _ := server.File("/dav/deleted.txt")
+		is.Equal(trash, "(B) Deleted\n")
+	})
+
+	t.Run("unchanged files are not downloaded again", func(t *testing.T) {
+		is := is.New(t)
+		server := webdavtest.NewServer()
+		defer server.Close()
+		server.Put("/dav/todo.txt", "(A) Deploy API\n")
+
+		repo, err := webdav.NewRepository(server.URL+"/dav/todo.txt", webdav.WithCacheDir(t.TempDir()))
+		is.NoErr(err)
+
+		_, err = repo.LoadAll()
+		is.NoErr(err)
+		todos, err := repo.LoadAll()
+		is.NoErr(err)
+
+		is.Equal(len(todos), 1)
+		is.Equal(server.Gets(), 1)
+	})
+
+	t.Run("works offline from the cache and catches up later", func(t *testing.T) {
+		is := is.New(t)
+		server := webdavtest.NewServer()
+		server.Put("/dav/todo.txt", "(A) Deploy API\n")
+		cacheDir := t.TempDir()
+		url := server.URL + "/dav/todo.txt"
+
+		repo, err := webdav.NewRepository(url, webdav.WithCacheDir(cacheDir))
+		is.NoErr(err)
+		todos, err := repo.LoadAll()
+		is.NoErr(err)
+
+		// Go offline: the server keeps its files but stops answering
+		offlineServer := webdavtest.NewServer()
+		offlineServer.Put("/dav/todo.txt", "(A) Deploy API\n")
+		server.Close()
+
+		todos, err = repo.LoadAll()
+		is.NoErr(err)
+		is.True(repo.Offline())
+		is.Equal(len(todos), 1)
+
+		is.NoErr(repo.SaveAll(append(todos, todo.New("Written offline", todo.PriorityB))))
+
+		// A new session pointing at the server that is now reachable delivers the offline change
+		reconnected, err := webdav.NewRepository(offlineServer.URL+"/dav/todo.txt", webdav.WithCacheDir(cacheDir))
+		is.NoErr(err)
+		todos, err = reconnected.LoadAll()
+		is.NoErr(err)
+		is.Equal(len(todos), 2)
+		is.True(!reconnected.Offline())
+
+		content, _ := offlineServer.File("/dav/todo.txt")
+		is.True(strings.Contains(content, "Written offline"))
+		offlineServer.Close()
+	})
+
+	t.Run("keeps the local version when offline changes conflict", func(t *testing.T) {
+		is := is.New(t)
+		server := webdavtest.NewServer()
+		defer server.Close()
+		server.Put("/dav/todo.txt", "(A) Deploy API\n")
+		cacheDir := t.TempDir()
+
+		repo, err := webdav.NewRepository(server.URL+"/dav/todo.txt", webdav.WithCacheDir(cacheDir))
+		is.NoErr(err)
+		_, err = repo.LoadAll()
+		is.NoErr(err)
+
+		server.Put("/dav/todo.txt", "(A) Changed elsewhere\n")
+
+		err = repo.SaveAll([]todo.Todo{todo.New("Mine", todo.PriorityA)})
+		var conflict *webdav.ConflictError
+		is.True(errors.As(err, &conflict))
+
+		kept, err := os.ReadFile(conflict.Kept)
+		is.NoErr(err)
+		is.Equal(string(kept), "(A) Mine\n")
+
+		// The server's version wins on the next load
+		todos, err := repo.LoadAll()
+		is.NoErr(err)
+		is.Equal(todos[0].Description(), "Changed elsewhere")
+	})
+
+	t.Run("doesn't cache a rejected version as the server's", func(t *testing.T) {
+		is := is.New(t)
+		server := webdavtest.NewServer()
+		server.Put("/dav/todo.txt", "(A) Deploy API\n")
+
+		repo, err := webdav.NewRepository(server.URL+"/dav/todo.txt", webdav.WithCacheDir(t.TempDir()))
+		is.NoErr(err)
+		_, err = repo.LoadAll()
+		is.NoErr(err)
+
+		server.Put("/dav/todo.txt", "(A) Changed elsewhere\n")
+		var conflict *webdav.ConflictError
+		is.True(errors.As(repo.SaveAll([]todo.Todo{todo.New("Mine", todo.PriorityA)}), &conflict))
+
+		server.Close()
+		_, err = repo.LoadAll()
+		is.True(err != nil) // offline, with nothing trustworthy to show
+	})
+
+	t.Run("rejects URLs that are not http or https", func(t *testing.T) {
+		is := is.New(t)
+		_, err := webdav.NewRepository("ftp://example.com/todo.txt")
+		is.True(err != nil)
+	})
+}
@@ -0,0 +1,110 @@
+// Package webdavtest provides an in-memory stand-in for a WebDAV server, for testing.
+package webdavtest
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+)
+
+// Server stores files in memory and supports GET, HEAD and PUT with ETag preconditions
+// (If-Match, If-None-Match), like the subset of WebDAV the webdav repository uses
+type Server struct {
+	*httptest.Server
+
+	mu    sync.Mutex
+	files map[string][]byte
+	gets  int
+}
+
+// NewServer starts a server with no files; call Close when done
+func NewServer() *Server {
+	s := &Server{files: make(map[string][]byte)}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.handle))
+	return s
+}
+
+// Put replaces a file on the server, as if it was edited by another client
+func (s *Server) Put(path, content string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.files[path] = []byte(content)
+}
+
+// File returns the contents of a file on the server
+func (s *Server) File(path string) (string, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	data, ok := s.files[path]
+	return string(data), ok
+}
+
+// Gets returns how many GET requests returned a file body
+func (s *Server) Gets() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.gets
+}
+
+func (s *Server) handle(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	data, exists := s.files[r.URL.Path]
+	current := ""
+	if exists {
+		current = etag(data)
+	}
+
+	switch r.Method {
+	case http.MethodGet, http.MethodHead:
+		if !exists {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("ETag", current)
+		if r.Header.Get("If-None-Match") == current {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		if r.Method == http.MethodGet {
+			s.gets++
+			_, _ = w.Write(data)
+		}
+
+	case http.MethodPut:
+		if match := r.Header.Get("If-Match"); match != "" && match != current {
+			w.WriteHeader(http.StatusPreconditionFailed)
+			return
+		}
+		if r.Header.Get("If-None-Match") == "*" && exists {
+			w.WriteHeader(http.StatusPreconditionFailed)
+			return
+		}
+
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		s.files[r.URL.Path] = body
+		w.Header().Set("ETag", etag(body))
+		if exists {
+			w.WriteHeader(http.StatusNoContent)
+		} else {
+			w.WriteHeader(http.StatusCreated)
+		}
+
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+// etag derives a strong ETag from a file's contents
+func etag(data []byte) string {
+	sum := sha256.Sum256(data)
+	return fmt.Sprintf(`"%x"`, sum[:8])
+}
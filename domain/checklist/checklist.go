@@ -0,0 +1,219 @@
+// Package checklist reads Markdown checklist items ("- [ ] item") as todos and ticks their boxes.
+//
+// Items are written the way Obsidian and most Markdown notes apps write them:
+//
+//   - [ ] (A) Renew passport #admin #context/town 📅 2026-01-30
+//   - [x] Book venue #offsite ✅ 2026-01-12
+//   - [ ] Plan roadmap ⏫
+//
+// #tags become +projects, except #context/name, which becomes @name; #project/name is +name too.
+// The quadrant comes from a todo.txt priority at the start, or from an Obsidian Tasks priority
+// emoji: 🔺 Do First, ⏫ Schedule, 🔼 Delegate, 🔽 Eliminate and ⏬ Backlog. Items without one go to
+// the Backlog to be triaged. 📅 is the due date and ✅ the completion date.
+//
+// Each todo gets a note:path:line tag saying which item it came from, so its box can be ticked.
+package checklist
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/quii/todo-eisenhower/domain/todo"
+	"github.com/quii/todo-eisenhower/domain/todotxt"
+)
+
+// item matches a list item with a box: the box's contents are the second group, the text the third
+var item = regexp.MustCompile(`^(\s*(?:[-*+]|\d+[.)])\s+\[)([ xX])\]\s+(.*?)\s*$`)
+
+// fence opens and closes code blocks, whose contents aren't checklists
+var fence = regexp.MustCompile("^\\s*(```|~~~)")
+
+var (
+	hashTag      = regexp.MustCompile(`(?:^|\s)#([\p{L}\p{N}_\-/]*[\p{L}_\-/][\p{L}\p{N}_\-/]*)`)
+	dueEmoji     = regexp.MustCompile(`📅\s*(\d{4}-\d{2}-\d{2})`)
+	doneEmoji    = regexp.MustCompile(`✅\s*(\d{4}-\d{2}-\d{2})`)
+	nonWord      = regexp.MustCompile(`\W+`)
+	locationTag  = regexp.MustCompile(`(?:^|\s)note:(\S+):(\d+)`)
+	emojiMarkers = []struct {
+		emoji    string
+		priority todo.Priority
+	}{
+		{"🔺", todo.PriorityA},
+		{"⏫", todo.PriorityB},
+		{"🔼", todo.PriorityC},
+		{"🔽", todo.PriorityD},
+		{"⏬", todo.PriorityE},
+	}
+)
+
+// Unmarshal reads the checklist items in a note as todos tagged note:path:line
+// Ticked items are completed, on their ✅ date if they have one. now is the creation date of every todo.
+func Unmarshal(r io.Reader, path string, now time.Time) ([]todo.Todo, error) {
+	var todos []todo.Todo
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1<<20)
+	inCode := false
+	for line := 1; scanner.Scan(); line++ {
+		text := scanner.Text()
+		if fence.MatchString(text) {
+			inCode = !inCode
+			continue
+		}
+		if inCode {
+			continue
+		}
+
+		matches := item.FindStringSubmatch(text)
+		if matches == nil || matches[3] == "" {
+			continue
+		}
+		todos = append(todos, toTodo(matches[3], matches[2] != " ", path, line, now))
+	}
+	return todos, scanner.Err()
+}
+
+// toTodo reads an item's text
+func toTodo(text string, checked bool, path string, line int, now time.Time) todo.Todo {
+	priority, text, ok := todotxt.SplitPriority(text)
+	if !ok {
+		priority = todo.PriorityE
+		for _, marker := range emojiMarkers {
+			if strings.Contains(text, marker.emoji) {
+				priority = marker.priority
+				text = strings.ReplaceAll(text, marker.emoji, " ")
+				break
+			}
+		}
+	}
+
+	var completed *time.Time
+	if matches := doneEmoji.FindStringSubmatch(text); matches != nil {
+		if date, err := time.Parse(time.DateOnly, matches[1]); err == nil {
+			completed = &date
+		}
+		text = doneEmoji.ReplaceAllString(text, " ")
+	}
+	text = dueEmoji.ReplaceAllString(text, " due:$1")
+
+	var tags []string
+	text = hashTag.ReplaceAllStringFunc(text, func(match string) string {
+		if tag := tagFor(strings.TrimSpace(match)[1:]); tag != "" {
+			tags = append(tags, tag)
+		}
+		return " "
+	})
+
+	fields := append(strings.Fields(text), Tag(path, line))
+	t := todotxt.ParseNew(strings.Join(append(fields, tags...), " "), priority, now)
+	if checked {
+		if completed == nil {
+			completed = &now
+		}
+		t = t.ToggleCompletion(*completed)
+	}
+	return t
+}
+
+// tagFor turns a #tag into a todo.txt +project or @context
+func tagFor(name string) string {
+	prefix := "+"
+	if rest, ok := strings.CutPrefix(name, "context/"); ok {
+		prefix, name = "@", rest
+	} else if rest, ok := strings.CutPrefix(name, "project/"); ok {
+		name = rest
+	}
+
+	name = strings.Trim(nonWord.ReplaceAllString(name, "_"), "_")
+	if name == "" {
+		return ""
+	}
+	return prefix + name
+}
+
+// escapePath keeps spaces in note names from ending the tag
+var (
+	escapePath   = strings.NewReplacer("%", "%25", " ", "%20")
+	unescapePath = strings.NewReplacer("%20", " ", "%25", "%")
+)
+
+// Tag is the note:path:line tag of the item on line of the note at path
+// Spaces in the path are written as %20.
+func Tag(path string, line int) string {
+	return "note:" + escapePath.Replace(path) + ":" + strconv.Itoa(line)
+}
+
+// Location returns the note and line a todo's item was on, from its note:path:line tag
+func Location(t todo.Todo) (path string, line int, ok bool) {
+	matches := locationTag.FindStringSubmatch(t.Description())
+	if matches == nil {
+		return "", 0, false
+	}
+	line, err := strconv.Atoi(matches[2])
+	if err != nil {
+		return "", 0, false
+	}
+	return unescapePath.Replace(matches[1]), line, true
+}
+
+// Key identifies the item a todo came from by its note and text, so it is found after lines move
+// Key is empty for todos that didn't come from a note.
+func Key(t todo.Todo) string {
+	path, _, ok := Location(t)
+	if !ok {
+		return ""
+	}
+	return path + "\x00" + strings.Join(strings.Fields(locationTag.ReplaceAllString(t.Description(), " ")), " ")
+}
+
+// Apply ticks or unticks the box of t's item in the note's content to match whether t is completed
+// The item is looked for on its line and then anywhere in the note; changed is false when the box
+// is already right or the item has gone.
+func Apply(content []byte, t todo.Todo) (updated []byte, changed bool) {
+	path, line, ok := Location(t)
+	if !ok {
+		return content, false
+	}
+
+	items, err := Unmarshal(bytes.NewReader(content), path, time.Time{})
+	if err != nil {
+		return content, false
+	}
+	key := Key(t)
+	target := 0
+	for _, candidate := range items {
+		if Key(candidate) != key {
+			continue
+		}
+		_, candidateLine, _ := Location(candidate)
+		if target == 0 || candidateLine == line {
+			target = candidateLine
+		}
+	}
+	if target == 0 {
+		return content, false
+	}
+
+	lines := bytes.SplitAfter(content, []byte("\n"))
+	box := item.FindSubmatchIndex(lines[target-1])
+	want := byte(' ')
+	if t.IsCompleted() {
+		want = 'x'
+	}
+	current := lines[target-1][box[4]]
+	if (current == ' ') == (want == ' ') {
+		return content, false
+	}
+
+	updated = bytes.Clone(content)
+	offset := 0
+	for _, l := range lines[:target-1] {
+		offset += len(l)
+	}
+	updated[offset+box[4]] = want
+	return updated, true
+}
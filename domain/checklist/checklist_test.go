@@ -0,0 +1,95 @@
+package checklist_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/matryer/is"
+	"github.com/quii/todo-eisenhower/domain/checklist"
+	"github.com/quii/todo-eisenhower/domain/todo"
+)
+
+// now is Tuesday 2026-01-20
+var now = time.Date(2026, 1, 20, 9, 0, 0, 0, time.UTC)
+
+const note = "# Week\n\n" +
+	"- [ ] (A) Renew passport #admin #context/town 📅 2026-01-30\n" +
+	"- [x] Book venue #project/offsite ✅ 2026-01-12\n" +
+	"  * [ ] Plan roadmap ⏫\n" +
+	"1. [X] Reply to vendor\n" +
+	"- [ ] Read #1 of the newsletter\n" +
+	"- not a task\n" +
+	"```\n- [ ] an example in a code block\n```\n"
+
+func TestUnmarshal(t *testing.T) {
+	//nolint:gocritic // importShadow: is := is.New(t) is idiomatic for github.com/matryer/is
+	is := is.New(t)
+	todos, err := checklist.Unmarshal(strings.NewReader(note), "Weekly/week 3.md", now)
+	is.NoErr(err)
+	is.Equal(len(todos), 5)
+
+	is.Equal(todos[0].String(), "(A) 2026-01-20 Renew passport note:Weekly/week%203.md:3 +admin @town due:2026-01-30 prioritised:2026-01-20\n")
+	is.Equal(todos[1].String(), "x 2026-01-12 2026-01-20 (E) Book venue note:Weekly/week%203.md:4 +offsite\n")
+	is.Equal(todos[2].Priority(), todo.PriorityB)
+	is.Equal(todos[2].Description(), "Plan roadmap note:Weekly/week%203.md:5")
+	is.True(todos[3].IsCompleted())
+	is.Equal(todos[3].CompletionDate(), &now)
+	is.Equal(todos[4].Description(), "Read #1 of the newsletter note:Weekly/week%203.md:7") // #1 isn't a tag
+	is.Equal(todos[4].Priority(), todo.PriorityE)
+}
+
+func TestLocationAndKey(t *testing.T) {
+	is := is.New(t)
+	todos, err := checklist.Unmarshal(strings.NewReader(note), "Weekly/week 3.md", now)
+	is.NoErr(err)
+
+	path, line, ok := checklist.Location(todos[0])
+	is.True(ok)
+	is.Equal(path, "Weekly/week 3.md")
+	is.Equal(line, 3)
+	is.Equal(checklist.Key(todos[0]), "Weekly/week 3.md\x00Renew passport")
+
+	_, _, ok = checklist.Location(todo.New("Not from a note", todo.PriorityA))
+	is.True(!ok)
+	is.Equal(checklist.Key(todo.New("Not from a note", todo.PriorityA)), "")
+}
+
+func TestApply(t *testing.T) {
+	t.Run("ticks and unticks the item's box", func(t *testing.T) {
+		is := is.New(t)
+		todos, err := checklist.Unmarshal(strings.NewReader(note), "week.md", now)
+		is.NoErr(err)
+
+		ticked, changed := checklist.Apply([]byte(note), todos[0].ToggleCompletion(now))
+		is.True(changed)
+		is.Equal(string(ticked), strings.Replace(note, "- [ ] (A) Renew", "- [x] (A) Renew", 1))
+
+		unticked, changed := checklist.Apply([]byte(note), todos[3].ToggleCompletion(now))
+		is.True(changed)
+		is.Equal(string(unticked), strings.Replace(note, "1. [X] Reply", "1. [ ] Reply", 1))
+
+		_, changed = checklist.Apply([]byte(note), todos[1])
+		is.True(!changed) // already ticked
+	})
+
+	t.Run("finds items that moved to another line", func(t *testing.T) {
+		is := is.New(t)
+		todos, err := checklist.Unmarshal(strings.NewReader(note), "week.md", now)
+		is.NoErr(err)
+
+		moved := "New line\r\n" + strings.ReplaceAll(note, "\n", "\r\n")
+		ticked, changed := checklist.Apply([]byte(moved), todos[2].ToggleCompletion(now))
+		is.True(changed)
+		is.Equal(string(ticked), strings.Replace(moved, "* [ ] Plan", "* [x] Plan", 1))
+	})
+
+	t.Run("leaves notes without the item alone", func(t *testing.T) {
+		is := is.New(t)
+		todos, err := checklist.Unmarshal(strings.NewReader(note), "week.md", now)
+		is.NoErr(err)
+
+		_, changed := checklist.Apply([]byte("- [ ] Something else\n"), todos[0].ToggleCompletion(now))
+		is.True(!changed)
+	})
+}
@@ -0,0 +1,367 @@
+// Package ics provides encoding and decoding of todos as iCalendar (RFC 5545) VTODO components,
+// so todos with due dates show up in calendar apps and calendar tasks can be brought back in.
+// It follows the convention of encoding packages like encoding/json, as todotxt does.
+//
+// Quadrants map to iCalendar priorities (1 is the highest, 9 the lowest):
+//
+//	Do First  1    Delegate   5    Backlog  9
+//	Schedule  3    Eliminate  7
+//
+// Projects and contexts are written as CATEGORIES, keeping their + and @ prefixes so they can be
+// told apart when read back. Each todo gets a UID that stays the same across exports, and WithUID
+// keeps it in the todo so it survives edits too.
+package ics
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/quii/todo-eisenhower/domain/todo"
+	"github.com/quii/todo-eisenhower/domain/todotxt"
+)
+
+const (
+	dateFormat     = "20060102"
+	dateTimeFormat = "20060102T150405Z"
+	uidDomain      = "@todo-eisenhower"
+	maxLineOctets  = 75 // content lines longer than this are folded
+)
+
+// uidPattern finds a uid:VALUE kept in a todo's description by Unmarshal
+var uidPattern = regexp.MustCompile(`(?:^|\s)uid:(\S+)`)
+
+// UID returns the todo's iCalendar UID
+// Todos imported from a calendar keep their original UID, URL-escaped, in a uid: tag; other todos get one made from
+// their creation date and description, so it doesn't change when they are moved or completed.
+func UID(t todo.Todo) string {
+	if matches := uidPattern.FindStringSubmatch(t.Description()); matches != nil {
+		if uid, err := url.QueryUnescape(matches[1]); err == nil {
+			return uid
+		}
+		return matches[1]
+	}
+	return generatedUID(t.Description(), t.CreationDate())
+}
+
+// WithUID returns the todo with its UID kept in a uid: tag, and false if it already had one
+// A generated UID changes when the description is edited, so exports keep it in the todo to stop
+// calendar apps seeing an edited todo as a new task.
+func WithUID(t todo.Todo) (todo.Todo, bool) {
+	if uidPattern.MatchString(t.Description()) {
+		return t, false
+	}
+	tagged := todotxt.FormatForInput(t) + " uid:" + url.QueryEscape(UID(t))
+	return todotxt.ParseEdit(t, tagged, t.Priority()), true
+}
+
+func generatedUID(description string, creationDate *time.Time) string {
+	key := description
+	if creationDate != nil {
+		key = creationDate.Format(todotxt.DateFormat) + " " + key
+	}
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:8]) + uidDomain
+}
+
+// summary is the todo's description without its uid: tag
+func summary(t todo.Todo) string {
+	return strings.TrimSpace(uidPattern.ReplaceAllString(t.Description(), ""))
+}
+
+// Priority returns the iCalendar priority for a todo's priority; 0 means undefined
+func Priority(p todo.Priority) int {
+	switch p {
+	case todo.PriorityA:
+		return 1
+	case todo.PriorityB:
+		return 3
+	case todo.PriorityC:
+		return 5
+	case todo.PriorityD:
+		return 7
+	case todo.PriorityE:
+		return 9
+	default:
+		return 0
+	}
+}
+
+// TodoPriority returns the todo priority for an iCalendar priority, so the todo lands in the quadrant
+// it was exported from: 1-2 Do First, 3-4 Schedule, 5-6 Delegate, 7-8 Eliminate and 9 Backlog
+func TodoPriority(priority int) todo.Priority {
+	switch {
+	case priority >= 1 && priority <= 2:
+		return todo.PriorityA
+	case priority >= 3 && priority <= 4:
+		return todo.PriorityB
+	case priority >= 5 && priority <= 6:
+		return todo.PriorityC
+	case priority >= 7 && priority <= 8:
+		return todo.PriorityD
+	case priority == 9:
+		return todo.PriorityE
+	default:
+		return todo.PriorityNone
+	}
+}
+
+// Marshal writes todos as a calendar of VTODO components; now is the DTSTAMP of each one
+func Marshal(w io.Writer, todos []todo.Todo, now time.Time) error {
+	e := &encoder{w: w}
+	e.line("BEGIN:VCALENDAR")
+	e.line("VERSION:2.0")
+	e.line("PRODID:-//quii//todo-eisenhower//EN")
+	for _, t := range todos {
+		e.todo(t, now)
+	}
+	e.line("END:VCALENDAR")
+	return e.err
+}
+
+// encoder writes folded content lines, remembering the first error
+type encoder struct {
+	w   io.Writer
+	err error
+}
+
+func (e *encoder) todo(t todo.Todo, now time.Time) {
+	e.line("BEGIN:VTODO")
+	e.line("UID:" + UID(t))
+	e.line("DTSTAMP:" + now.UTC().Format(dateTimeFormat))
+	if created := t.CreationDate(); created != nil {
+		e.line("CREATED:" + created.Format(dateTimeFormat))
+	}
+	e.line("SUMMARY:" + escape(summary(t)))
+	if priority := Priority(t.Priority()); priority != 0 {
+		e.line("PRIORITY:" + strconv.Itoa(priority))
+	}
+	if categories := categories(t); len(categories) > 0 {
+		e.line("CATEGORIES:" + strings.Join(categories, ","))
+	}
+	if due := t.DueDate(); due != nil {
+		e.line("DUE;VALUE=DATE:" + due.Format(dateFormat))
+	}
+	if t.IsCompleted() {
+		e.line("STATUS:COMPLETED")
+		if completed := t.CompletionDate(); completed != nil {
+			e.line("COMPLETED:" + completed.Format(dateTimeFormat))
+		}
+	} else {
+		e.line("STATUS:NEEDS-ACTION")
+	}
+	e.line("END:VTODO")
+}
+
+// line writes a content line, folding it so no line is longer than 75 octets
+func (e *encoder) line(content string) {
+	if e.err != nil {
+		return
+	}
+
+	var folded strings.Builder
+	limit := maxLineOctets
+	for len(content) > limit {
+		cut := limit
+		for cut > 0 && !isRuneStart(content[cut]) {
+			cut-- // don't split a multi-byte character
+		}
+		folded.WriteString(content[:cut])
+		folded.WriteString("\r\n ")
+		content = content[cut:]
+		limit = maxLineOctets - 1 // continuation lines start with a space
+	}
+	folded.WriteString(content)
+	folded.WriteString("\r\n")
+
+	_, e.err = io.WriteString(e.w, folded.String())
+}
+
+func isRuneStart(b byte) bool {
+	return b&0xC0 != 0x80
+}
+
+// categories are the todo's projects and contexts with their prefixes
+func categories(t todo.Todo) []string {
+	var result []string
+	for _, project := range t.Projects() {
+		result = append(result, escape("+"+project))
+	}
+	for _, context := range t.Contexts() {
+		result = append(result, escape("@"+context))
+	}
+	return result
+}
+
+// escape escapes a TEXT value
+func escape(s string) string {
+	return strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\n", `\n`, "\r", "").Replace(s)
+}
+
+// unescape reverses escape
+func unescape(s string) string {
+	return strings.NewReplacer(`\\`, `\`, `\;`, ";", `\,`, ",", `\n`, "\n", `\N`, "\n").Replace(s)
+}
+
+// Unmarshal reads the VTODO and VEVENT components of a calendar as todos.
+// This is the inverse operation of Marshal. An event's start date becomes the todo's due date.
+// A UID that Marshal wouldn't have generated is kept in a uid: tag, so the todo is recognised
+// when the calendar is imported again.
+func Unmarshal(r io.Reader) ([]todo.Todo, error) {
+	lines, err := unfold(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var todos []todo.Todo
+	var current map[string][]string
+	for _, line := range lines {
+		name, value := splitLine(line)
+		switch {
+		case name == "BEGIN" && (value == "VTODO" || value == "VEVENT"):
+			current = map[string][]string{}
+		case name == "END" && (value == "VTODO" || value == "VEVENT"):
+			if current != nil {
+				if t, ok := componentTodo(current, value == "VEVENT"); ok {
+					todos = append(todos, t)
+				}
+			}
+			current = nil
+		case current != nil:
+			current[name] = append(current[name], value)
+		}
+	}
+	return todos, nil
+}
+
+// unfold reads content lines, joining folded lines back together
+func unfold(r io.Reader) ([]string, error) {
+	var lines []string
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if (strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")) && len(lines) > 0 {
+			lines[len(lines)-1] += line[1:]
+			continue
+		}
+		if line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return lines, scanner.Err()
+}
+
+// splitLine splits a content line into its property name (without parameters) and value
+func splitLine(line string) (name, value string) {
+	nameAndParams, value, _ := strings.Cut(line, ":")
+	name, _, _ = strings.Cut(nameAndParams, ";")
+	return strings.ToUpper(name), value
+}
+
+// componentTodo converts a component's properties into a todo; components without a summary are skipped
+func componentTodo(props map[string][]string, isEvent bool) (todo.Todo, bool) {
+	text := strings.Join(strings.Fields(unescape(first(props, "SUMMARY"))), " ")
+	if text == "" {
+		return todo.Todo{}, false
+	}
+
+	priority := todo.PriorityNone
+	if value, err := strconv.Atoi(first(props, "PRIORITY")); err == nil {
+		priority = TodoPriority(value)
+	}
+
+	for _, value := range props["CATEGORIES"] {
+		for _, category := range splitCategories(value) {
+			text += " " + tag(category)
+		}
+	}
+
+	creationDate := parseDate(first(props, "CREATED"))
+	dueProperty := "DUE"
+	if isEvent {
+		dueProperty = "DTSTART"
+	}
+
+	// Parse the summary and categories like a todo typed into the matrix, for its tags and any due: date
+	parsed := todotxt.ParseNew(text, priority, time.Time{})
+	dueDate := parseDate(first(props, dueProperty))
+	if dueDate == nil {
+		dueDate = parsed.DueDate()
+	}
+
+	description := parsed.Description()
+	if uid := first(props, "UID"); uid != "" && uid != generatedUID(description, creationDate) && !uidPattern.MatchString(description) {
+		description += " uid:" + url.QueryEscape(uid) // escaped so an @ or + isn't read as a tag
+	}
+
+	completed := strings.EqualFold(first(props, "STATUS"), "COMPLETED")
+	var completionDate *time.Time
+	if completed {
+		completionDate = parseDate(first(props, "COMPLETED"))
+	}
+
+	return todo.NewFull(description, priority, completed, completionDate, creationDate, dueDate, nil,
+		parsed.Projects(), parsed.Contexts()), true
+}
+
+// splitCategories splits a CATEGORIES value on unescaped commas
+func splitCategories(value string) []string {
+	var categories []string
+	var current strings.Builder
+	for i := 0; i < len(value); i++ {
+		switch {
+		case value[i] == '\\' && i+1 < len(value):
+			current.WriteByte(value[i])
+			current.WriteByte(value[i+1])
+			i++
+		case value[i] == ',':
+			categories = append(categories, unescape(current.String()))
+			current.Reset()
+		default:
+			current.WriteByte(value[i])
+		}
+	}
+	return append(categories, unescape(current.String()))
+}
+
+// nonWord matches the characters that can't be part of a todo.txt tag
+var nonWord = regexp.MustCompile(`\W+`)
+
+// tag turns a category into a project or context; categories without a prefix become projects
+func tag(category string) string {
+	prefix := "+"
+	category = strings.TrimSpace(category)
+	if strings.HasPrefix(category, "@") {
+		prefix = "@"
+	}
+	name := strings.Trim(nonWord.ReplaceAllString(category, "_"), "_")
+	if name == "" {
+		return ""
+	}
+	return prefix + name
+}
+
+func first(props map[string][]string, name string) string {
+	if values := props[name]; len(values) > 0 {
+		return strings.TrimSpace(values[0])
+	}
+	return ""
+}
+
+// parseDate reads the date part of a DATE or DATE-TIME value
+func parseDate(value string) *time.Time {
+	if len(value) < len(dateFormat) {
+		return nil
+	}
+	date, err := time.Parse(dateFormat, value[:len(dateFormat)])
+	if err != nil {
+		return nil
+	}
+	return &date
+}
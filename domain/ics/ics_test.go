@@ -0,0 +1,161 @@
+package ics_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/matryer/is"
+	"github.com/quii/todo-eisenhower/domain/ics"
+	"github.com/quii/todo-eisenhower/domain/todo"
+	"github.com/quii/todo-eisenhower/domain/todotxt"
+)
+
+var now = time.Date(2026, 1, 20, 9, 30, 0, 0, time.UTC)
+
+func parse(t *testing.T, lines string) []todo.Todo {
+	t.Helper()
+	todos, err := todotxt.Unmarshal(strings.NewReader(lines))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return todos
+}
+
+func TestMarshal(t *testing.T) {
+	t.Run("writes todos as VTODOs", func(t *testing.T) {
+		//nolint:gocritic // importShadow: is := is.New(t) is idiomatic for github.com/matryer/is
+		is := is.New(t)
+		todos := parse(t, "(B) 2026-01-02 Plan roadmap, v2 +ops @laptop due:2026-01-23\nx 2026-01-19 2026-01-10 (C) Book venue\n")
+
+		var buf bytes.Buffer
+		is.NoErr(ics.Marshal(&buf, todos, now))
+
+		uid := ics.UID(todos[0])
+		is.Equal(buf.String(), strings.ReplaceAll(`BEGIN:VCALENDAR
+VERSION:2.0
+PRODID:-//quii//todo-eisenhower//EN
+BEGIN:VTODO
+UID:`+uid+`
+DTSTAMP:20260120T093000Z
+CREATED:20260102T000000Z
+SUMMARY:Plan roadmap\, v2
+PRIORITY:3
+CATEGORIES:+ops,@laptop
+DUE;VALUE=DATE:20260123
+STATUS:NEEDS-ACTION
+END:VTODO
+BEGIN:VTODO
+UID:`+ics.UID(todos[1])+`
+DTSTAMP:20260120T093000Z
+CREATED:20260110T000000Z
+SUMMARY:Book venue
+PRIORITY:5
+STATUS:COMPLETED
+COMPLETED:20260119T000000Z
+END:VTODO
+END:VCALENDAR
+`, "\n", "\r\n"))
+	})
+
+	t.Run("folds long lines", func(t *testing.T) {
+		is := is.New(t)
+		todos := parse(t, "(A) "+strings.Repeat("Überprüfen ", 12)+"\n")
+
+		var buf bytes.Buffer
+		is.NoErr(ics.Marshal(&buf, todos, now))
+
+		for _, line := range strings.Split(buf.String(), "\r\n") {
+			is.True(len(line) <= 75)
+		}
+		roundTripped, err := ics.Unmarshal(&buf)
+		is.NoErr(err)
+		is.Equal(roundTripped[0].Description(), todos[0].Description())
+	})
+}
+
+func TestUID(t *testing.T) {
+	is := is.New(t)
+	original := parse(t, "(A) 2026-01-02 Fix prod +ops\n")[0]
+	moved := original.ChangePriority(todo.PriorityB).ToggleCompletion(now)
+
+	is.Equal(ics.UID(original), ics.UID(moved)) // stable when moved or completed
+	is.True(strings.HasSuffix(ics.UID(original), "@todo-eisenhower"))
+	is.True(ics.UID(original) != ics.UID(parse(t, "(A) 2026-01-02 Fix staging\n")[0]))
+
+	imported := parse(t, "(A) Call dentist uid:abc123%40google.com\n")[0]
+	is.Equal(ics.UID(imported), "abc123@google.com")
+}
+
+func TestWithUID(t *testing.T) {
+	is := is.New(t)
+	original := parse(t, "(A) 2026-01-02 Fix prod +ops due:2026-01-21\n")[0]
+
+	tagged, changed := ics.WithUID(original)
+	is.True(changed)
+	is.Equal(ics.UID(tagged), ics.UID(original))
+	is.Equal(tagged.Projects(), []string{"ops"})
+	is.Equal(*tagged.DueDate(), *original.DueDate())
+
+	_, changed = ics.WithUID(tagged)
+	is.True(!changed) // already kept
+}
+
+func TestUnmarshal(t *testing.T) {
+	t.Run("round trips todos written by Marshal", func(t *testing.T) {
+		is := is.New(t)
+		todos := parse(t, "(A) 2026-01-02 Fix prod +ops @laptop due:2026-01-21\nx 2026-01-19 2026-01-10 (D) Old idea\n(E) Someday\n")
+
+		var buf bytes.Buffer
+		is.NoErr(ics.Marshal(&buf, todos, now))
+		imported, err := ics.Unmarshal(&buf)
+		is.NoErr(err)
+
+		is.Equal(len(imported), 3)
+		for i := range todos {
+			is.Equal(imported[i].String(), todos[i].String())
+		}
+	})
+
+	t.Run("reads calendar tasks into quadrants by priority", func(t *testing.T) {
+		is := is.New(t)
+		calendar := "BEGIN:VCALENDAR\r\n" +
+			"BEGIN:VTODO\r\n" +
+			"UID:abc123@google.com\r\n" +
+			"SUMMARY:Renew passport\\; urgent\r\n" +
+			"PRIORITY:2\r\n" +
+			"CATEGORIES:Home Admin,@errands\r\n" +
+			"DUE;TZID=Europe/London:20260130T170000\r\n" +
+			"END:VTODO\r\n" +
+			"BEGIN:VTODO\r\n" +
+			"SUMMARY:No priority\r\n" +
+			"END:VTODO\r\n" +
+			"BEGIN:VEVENT\r\n" +
+			"SUMMARY:Team off\r\n" +
+			" site\r\n" +
+			"PRIORITY:6\r\n" +
+			"DTSTART;VALUE=DATE:20260212\r\n" +
+			"END:VEVENT\r\n" +
+			"END:VCALENDAR\r\n"
+
+		todos, err := ics.Unmarshal(strings.NewReader(calendar))
+		is.NoErr(err)
+		is.Equal(len(todos), 3)
+
+		passport := todos[0]
+		is.Equal(passport.Priority(), todo.PriorityA)
+		is.Equal(passport.Description(), "Renew passport; urgent uid:abc123%40google.com")
+		is.Equal(passport.Projects(), []string{"Home_Admin"})
+		is.Equal(passport.Contexts(), []string{"errands"})
+		is.Equal(passport.DueDate().Format(todotxt.DateFormat), "2026-01-30")
+		is.Equal(ics.UID(passport), "abc123@google.com")
+
+		is.Equal(todos[1].Priority(), todo.PriorityNone)
+
+		offsite := todos[2]
+		is.Equal(offsite.Description(), "Team offsite")
+		is.Equal(offsite.Priority(), todo.PriorityC)
+		is.Equal(offsite.DueDate().Format(todotxt.DateFormat), "2026-02-12")
+	})
+}
@@ -2,6 +2,7 @@
 package matrix
 
 import (
+	"strings"
 	"time"
 
 	"github.com/quii/todo-eisenhower/domain/todo"
@@ -168,8 +169,42 @@ func (m Matrix) AllTodosIncludingBacklog() []todo.Todo {
 	return all
 }
 
+// Locate finds the todo at a 1-based position in AllTodosIncludingBacklog order (Do First, Schedule,
+// Delegate, Eliminate, then Backlog); these positions are the ids the CLI and REST API use.
+// They are not line numbers: a hand-edited file, or a workspace of several files, is in a different order.
+// It returns the todo's quadrant and its index within that quadrant
+func (m Matrix) Locate(position int) (quadrant QuadrantType, index int, ok bool) {
+	if position < 1 {
+		return DoFirstQuadrant, 0, false
+	}
+	index = position - 1
+	for _, q := range []QuadrantType{DoFirstQuadrant, ScheduleQuadrant, DelegateQuadrant, EliminateQuadrant, BacklogQuadrant} {
+		todos := m.GetTodosForQuadrant(q)
+		if index < len(todos) {
+			return q, index, true
+		}
+		index -= len(todos)
+	}
+	return DoFirstQuadrant, 0, false
+}
+
+// Position is the inverse of Locate: the 1-based position of the todo at index in quadrant
+func (m Matrix) Position(quadrant QuadrantType, index int) int {
+	position := index + 1
+	for _, q := range []QuadrantType{DoFirstQuadrant, ScheduleQuadrant, DelegateQuadrant, EliminateQuadrant, BacklogQuadrant} {
+		if q == quadrant {
+			return position
+		}
+		position += len(m.GetTodosForQuadrant(q))
+	}
+	return position
+}
+
+// SourceFilterPrefix marks a filter that matches the workspace file a todo came from (e.g. "source:work")
+const SourceFilterPrefix = "source:"
+
 // FilterByTag returns a new Matrix containing only todos that match the given tag filter.
-// Filter format: "+project" for projects, "@context" for contexts.
+// Filter format: "+project" for projects, "@context" for contexts, "source:name" for workspace files.
 // Returns an empty matrix if the filter doesn't match any todos.
 func (m Matrix) FilterByTag(filter string) Matrix {
 	if filter == "" {
@@ -190,61 +225,21 @@ func filterTodosByTag(todos []todo.Todo, filter string) []todo.Todo {
 		return todos
 	}
 
-	if len(filter) < 2 {
-		return []todo.Todo{}
-	}
-
-	prefix := filter[0]
-	tag := filter[1:]
-
 	filtered := make([]todo.Todo, 0)
 	for _, t := range todos {
-		match := false
-
-		if prefix == '+' {
-			// Filter by project
-			for _, p := range t.Projects() {
-				if equalsFold(p, tag) {
-					match = true
-					break
-				}
-			}
-		} else if prefix == '@' {
-			// Filter by context
-			for _, c := range t.Contexts() {
-				if equalsFold(c, tag) {
-					match = true
-					break
-				}
-			}
-		}
-
-		if match {
+		if MatchesFilter(t, filter) {
 			filtered = append(filtered, t)
 		}
 	}
 	return filtered
 }
 
-// equalsFold is a simple case-insensitive string comparison
-func equalsFold(a, b string) bool {
-	if len(a) != len(b) {
-		return false
+// MatchesFilter returns true if the todo matches a filter in FilterByTag's format
+func MatchesFilter(t todo.Todo, filter string) bool {
+	if source, bySource := strings.CutPrefix(filter, SourceFilterPrefix); bySource {
+		return t.MatchesSource(source)
 	}
-	for i := 0; i < len(a); i++ {
-		ca, cb := a[i], b[i]
-		// Convert to lowercase if uppercase letter
-		if ca >= 'A' && ca <= 'Z' {
-			ca += 'a' - 'A'
-		}
-		if cb >= 'A' && cb <= 'Z' {
-			cb += 'a' - 'A'
-		}
-		if ca != cb {
-			return false
-		}
-	}
-	return true
+	return t.MatchesTag(filter)
 }
 
 // ToggleCompletionAt toggles the completion status of a todo at the specified position.
@@ -315,12 +310,40 @@ func (m Matrix) ArchiveTodoAt(quadrant QuadrantType, index int) (todo.Todo, Matr
 // ArchiveCompletedInQuadrant archives all completed todos in the specified quadrant.
 // Returns the archived todos and the updated matrix.
 func (m Matrix) ArchiveCompletedInQuadrant(quadrant QuadrantType) ([]todo.Todo, Matrix) {
+	return m.archiveInQuadrant(quadrant, todo.Todo.IsCompleted)
+}
+
+// ArchiveCompletedBefore archives completed todos across all quadrants whose
+// completion date is before the cutoff. Completed todos without a completion
+// date are treated as old enough to archive.
+// Returns the archived todos and the updated matrix.
+func (m Matrix) ArchiveCompletedBefore(cutoff time.Time) ([]todo.Todo, Matrix) {
+	completedBefore := func(t todo.Todo) bool {
+		if !t.IsCompleted() {
+			return false
+		}
+		completed := t.CompletionDate()
+		return completed == nil || completed.Before(cutoff)
+	}
+
+	allArchived := make([]todo.Todo, 0)
+	for _, q := range []QuadrantType{DoFirstQuadrant, ScheduleQuadrant, DelegateQuadrant, EliminateQuadrant} {
+		archived, updated := m.archiveInQuadrant(q, completedBefore)
+		allArchived = append(allArchived, archived...)
+		m = updated
+	}
+
+	return allArchived, m
+}
+
+// archiveInQuadrant removes the todos in a quadrant matching shouldArchive
+func (m Matrix) archiveInQuadrant(quadrant QuadrantType, shouldArchive func(todo.Todo) bool) ([]todo.Todo, Matrix) {
 	todos := m.GetTodosForQuadrant(quadrant)
 	archived := make([]todo.Todo, 0)
 	remaining := make([]todo.Todo, 0)
 
 	for _, t := range todos {
-		if t.IsCompleted() {
+		if shouldArchive(t) {
 			archived = append(archived, t)
 		} else {
 			remaining = append(remaining, t)
@@ -315,6 +315,42 @@ func TestMatrix_ArchiveAllCompleted(t *testing.T) {
 	})
 }
 
+func TestMatrix_ArchiveCompletedBefore(t *testing.T) {
+	now := time.Date(2026, 3, 20, 9, 0, 0, 0, time.UTC)
+	cutoff := now.AddDate(0, 0, -7)
+
+	t.Run("archives only todos completed before the cutoff", func(t *testing.T) {
+		is := is.New(t)
+
+		old := todo.New("Old", todo.PriorityA).ToggleCompletion(now.AddDate(0, 0, -10))
+		recent := todo.New("Recent", todo.PriorityB).ToggleCompletion(now.AddDate(0, 0, -2))
+		active := todo.New("Active", todo.PriorityC)
+
+		m := matrix.New([]todo.Todo{old, recent, active})
+
+		archived, updated := m.ArchiveCompletedBefore(cutoff)
+
+		is.Equal(len(archived), 1)
+		is.Equal(archived[0].Description(), "Old")
+		is.Equal(len(updated.DoFirst()), 0)
+		is.Equal(len(updated.Schedule()), 1)
+		is.Equal(len(updated.Delegate()), 1)
+	})
+
+	t.Run("archives completed todos without a completion date", func(t *testing.T) {
+		is := is.New(t)
+
+		undated := todo.NewCompleted("Undated", todo.PriorityA, nil)
+
+		m := matrix.New([]todo.Todo{undated})
+
+		archived, updated := m.ArchiveCompletedBefore(cutoff)
+
+		is.Equal(len(archived), 1)
+		is.Equal(len(updated.DoFirst()), 0)
+	})
+}
+
 func TestMatrix_Backlog(t *testing.T) {
 	t.Run("categorizes priority E todos into Backlog", func(t *testing.T) {
 		is := is.New(t)
@@ -407,6 +443,21 @@ func TestMatrix_FilterByTag(t *testing.T) {
 		is.Equal(len(filtered.AllTodos()), 2) // Total filtered
 	})
 
+	t.Run("filters by workspace file", func(t *testing.T) {
+		is := is.New(t)
+
+		todos := []todo.Todo{
+			todo.New("Deploy API", todo.PriorityA).WithSource("work"),
+			todo.New("Book dentist", todo.PriorityA).WithSource("personal"),
+		}
+
+		m := matrix.New(todos)
+		filtered := m.FilterByTag("source:Work")
+
+		is.Equal(len(filtered.DoFirst()), 1)
+		is.Equal(filtered.DoFirst()[0].Description(), "Deploy API")
+	})
+
 	t.Run("filters by context tag", func(t *testing.T) {
 		is := is.New(t)
 
@@ -466,3 +517,34 @@ func TestMatrix_FilterByTag(t *testing.T) {
 		is.Equal(len(filtered.AllTodos()), 1) // Should match case-insensitively
 	})
 }
+
+func TestMatrix_Locate(t *testing.T) {
+	is := is.New(t)
+
+	m := matrix.New([]todo.Todo{
+		todo.New("Idea", todo.PriorityE),
+		todo.New("Tidy desk", todo.PriorityD),
+		todo.New("Fix prod", todo.PriorityA),
+		todo.New("Plan roadmap", todo.PriorityB),
+	})
+
+	// Positions follow AllTodosIncludingBacklog: Do First, Schedule, Delegate, Eliminate, Backlog
+	for position, want := range m.AllTodosIncludingBacklog() {
+		quadrant, index, ok := m.Locate(position + 1)
+		is.True(ok)
+		is.Equal(m.GetTodosForQuadrant(quadrant)[index].Description(), want.Description())
+	}
+
+	quadrant, index, ok := m.Locate(2)
+	is.True(ok)
+	is.Equal(quadrant, matrix.ScheduleQuadrant)
+	is.Equal(index, 0)
+
+	is.Equal(m.Position(quadrant, index), 2)
+	is.Equal(m.Position(matrix.BacklogQuadrant, 0), 4)
+
+	_, _, ok = m.Locate(0)
+	is.True(!ok)
+	_, _, ok = m.Locate(5)
+	is.True(!ok)
+}
@@ -0,0 +1,94 @@
+package matrix
+
+import (
+	"strings"
+
+	"github.com/quii/todo-eisenhower/domain/todo"
+)
+
+// quadrantNames are the stable identifiers used when quadrants are written out (journals, CLI flags)
+var quadrantNames = map[QuadrantType]string{
+	DoFirstQuadrant:   "do-first",
+	ScheduleQuadrant:  "schedule",
+	DelegateQuadrant:  "delegate",
+	EliminateQuadrant: "eliminate",
+	BacklogQuadrant:   "backlog",
+}
+
+// quadrantTitles are the human readable quadrant names
+var quadrantTitles = map[QuadrantType]string{
+	DoFirstQuadrant:   "Do First",
+	ScheduleQuadrant:  "Schedule",
+	DelegateQuadrant:  "Delegate",
+	EliminateQuadrant: "Eliminate",
+	BacklogQuadrant:   "Backlog",
+}
+
+// quadrantColors are the colors quadrants are drawn in unless the user picks others
+var quadrantColors = map[QuadrantType]string{
+	DoFirstQuadrant:   "#FF6B6B",
+	ScheduleQuadrant:  "#4ECDC4",
+	DelegateQuadrant:  "#FFE66D",
+	EliminateQuadrant: "#95E1D3",
+}
+
+// String returns the stable identifier for the quadrant (e.g. "do-first")
+func (q QuadrantType) String() string {
+	return quadrantNames[q]
+}
+
+// Title returns the human readable name for the quadrant (e.g. "Do First")
+func (q QuadrantType) Title() string {
+	return quadrantTitles[q]
+}
+
+// Color returns the quadrant's default hex color (e.g. "#FF6B6B"), or "" for the backlog
+func (q QuadrantType) Color() string {
+	return quadrantColors[q]
+}
+
+// ParseQuadrant converts a quadrant identifier such as "do-first" or "schedule" into a QuadrantType.
+// Matching is case-insensitive and accepts spaces or underscores in place of hyphens.
+func ParseQuadrant(s string) (QuadrantType, bool) {
+	normalised := strings.ToLower(strings.TrimSpace(s))
+	normalised = strings.NewReplacer(" ", "-", "_", "-").Replace(normalised)
+
+	for q, name := range quadrantNames {
+		if name == normalised {
+			return q, true
+		}
+	}
+	return DoFirstQuadrant, false
+}
+
+// QuadrantFor returns the quadrant a todo with the given priority belongs to
+func QuadrantFor(priority todo.Priority) QuadrantType {
+	switch priority {
+	case todo.PriorityA:
+		return DoFirstQuadrant
+	case todo.PriorityB:
+		return ScheduleQuadrant
+	case todo.PriorityC:
+		return DelegateQuadrant
+	case todo.PriorityE:
+		return BacklogQuadrant
+	default:
+		return EliminateQuadrant
+	}
+}
+
+// PriorityFor returns the priority that places a todo in the given quadrant
+func PriorityFor(quadrant QuadrantType) todo.Priority {
+	switch quadrant {
+	case DoFirstQuadrant:
+		return todo.PriorityA
+	case ScheduleQuadrant:
+		return todo.PriorityB
+	case DelegateQuadrant:
+		return todo.PriorityC
+	case BacklogQuadrant:
+		return todo.PriorityE
+	default:
+		return todo.PriorityD
+	}
+}
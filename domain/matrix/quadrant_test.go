@@ -0,0 +1,53 @@
+package matrix_test
+
+import (
+	"testing"
+
+	"github.com/matryer/is"
+	"github.com/quii/todo-eisenhower/domain/matrix"
+	"github.com/quii/todo-eisenhower/domain/todo"
+)
+
+func TestQuadrantType(t *testing.T) {
+	t.Run("round trips through its identifier", func(t *testing.T) {
+		is := is.New(t)
+
+		for _, q := range []matrix.QuadrantType{
+			matrix.DoFirstQuadrant, matrix.ScheduleQuadrant, matrix.DelegateQuadrant,
+			matrix.EliminateQuadrant, matrix.BacklogQuadrant,
+		} {
+			parsed, ok := matrix.ParseQuadrant(q.String())
+			is.True(ok)
+			is.Equal(parsed, q)
+		}
+	})
+
+	t.Run("parses loosely written identifiers", func(t *testing.T) {
+		is := is.New(t)
+
+		q, ok := matrix.ParseQuadrant("Do First")
+		is.True(ok)
+		is.Equal(q, matrix.DoFirstQuadrant)
+
+		q, ok = matrix.ParseQuadrant("DO_FIRST")
+		is.True(ok)
+		is.Equal(q, matrix.DoFirstQuadrant)
+	})
+
+	t.Run("rejects unknown identifiers", func(t *testing.T) {
+		is := is.New(t)
+
+		_, ok := matrix.ParseQuadrant("someday")
+		is.True(!ok)
+	})
+
+	t.Run("maps priorities to quadrants and back", func(t *testing.T) {
+		is := is.New(t)
+
+		is.Equal(matrix.QuadrantFor(todo.PriorityA), matrix.DoFirstQuadrant)
+		is.Equal(matrix.QuadrantFor(todo.PriorityNone), matrix.EliminateQuadrant)
+		is.Equal(matrix.QuadrantFor(todo.PriorityE), matrix.BacklogQuadrant)
+		is.Equal(matrix.PriorityFor(matrix.DelegateQuadrant), todo.PriorityC)
+		is.Equal(matrix.DoFirstQuadrant.Title(), "Do First")
+	})
+}
@@ -0,0 +1,354 @@
+// Package org provides encoding and decoding for Emacs Org-mode todo files.
+// It follows the convention of encoding packages like encoding/json, like package todotxt.
+//
+// Each headline with a todo keyword is a todo; other headlines and text are skipped:
+//
+//   - TODO [#A] Renew passport :admin:@town:
+//     DEADLINE: <2026-01-30 Fri>
+//     :PROPERTIES:
+//     :CREATED:  [2026-01-20 Tue]
+//     :PRIORITISED: [2026-01-20 Tue]
+//     :END:
+//   - DONE [#B] Book venue :offsite:
+//     CLOSED: [2026-01-12 Mon 16:02]
+//
+// DONE, CANCELLED and CANCELED headlines are completed; TODO, NEXT, STARTED, WAITING, WAIT and HOLD
+// are open. Tags starting with @ are contexts, as is the Org convention, and other tags are
+// projects. DEADLINE is the due date; SCHEDULED is kept as a scheduled:YYYY-MM-DD tag. CLOSED is the
+// completion date, and the CREATED and PRIORITISED properties are the creation and prioritised dates.
+//
+// Marshal writes todos as TODO and DONE headlines, so other keywords, headlines and text don't
+// survive a round trip. Rewrite writes todos back into the file they were read from instead,
+// keeping everything that isn't a todo.
+package org
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/quii/todo-eisenhower/domain/todo"
+	"github.com/quii/todo-eisenhower/domain/todotxt"
+)
+
+// openKeywords and doneKeywords are the todo keywords read, by whether they mean completed
+var (
+	openKeywords = []string{"TODO", "NEXT", "STARTED", "WAITING", "WAIT", "HOLD"}
+	doneKeywords = []string{"DONE", "CANCELLED", "CANCELED"}
+)
+
+var (
+	headline  = regexp.MustCompile(`^\*+\s+(.*)$`)
+	cookie    = regexp.MustCompile(`^\[#([A-Z])\]\s*`)
+	tagList   = regexp.MustCompile(`\s+:([\w@#%:]+):\s*$`)
+	planning  = regexp.MustCompile(`(CLOSED|DEADLINE|SCHEDULED):\s*[<\[](\d{4}-\d{2}-\d{2})[^>\]]*[>\]]`)
+	property  = regexp.MustCompile(`^\s*:(CREATED|PRIORITISED):\s*[<\[](\d{4}-\d{2}-\d{2})`)
+	plansOnly = regexp.MustCompile(`^\s*(?:(?:CLOSED|DEADLINE|SCHEDULED):\s*[<\[][^>\]]*[>\]]\s*)+$`)
+	scheduled = regexp.MustCompile(`(?:^|\s)scheduled:(\d{4}-\d{2}-\d{2})`)
+)
+
+// entry is a todo headline and the dates found under it
+type entry struct {
+	keyword, priority, title string
+	tags                     []string
+	dates                    map[string]*time.Time // by planning keyword or property name
+	stars                    string                // the headline's level, such as "**"
+	start, end               int                   // the lines of the headline and the text under it
+}
+
+// Unmarshal reads the todo headlines of an Org file
+// This is the inverse operation of Marshal.
+func Unmarshal(r io.Reader) ([]todo.Todo, error) {
+	_, entries, err := read(r)
+	if err != nil {
+		return nil, err
+	}
+
+	todos := make([]todo.Todo, 0, len(entries))
+	for _, e := range entries {
+		todos = append(todos, e.toTodo())
+	}
+	return todos, nil
+}
+
+// read returns the lines of an Org file and its todo headlines
+func read(r io.Reader) ([]string, []*entry, error) {
+	var lines []string
+	var entries []*entry
+	var current *entry
+	flush := func() {
+		if current != nil {
+			current.end = len(lines)
+			entries = append(entries, current)
+			current = nil
+		}
+	}
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1<<20)
+	for ; scanner.Scan(); lines = append(lines, scanner.Text()) {
+		line := scanner.Text()
+		if matches := headline.FindStringSubmatch(line); matches != nil {
+			flush()
+			current = parseHeadline(matches[1])
+			if current != nil {
+				current.stars = strings.TrimSpace(strings.TrimSuffix(line, matches[1]))
+				current.start = len(lines)
+			}
+			continue
+		}
+		if current == nil {
+			continue
+		}
+
+		for _, matches := range planning.FindAllStringSubmatch(line, -1) {
+			current.dates[matches[1]] = parseDate(matches[2])
+		}
+		if matches := property.FindStringSubmatch(line); matches != nil {
+			current.dates[matches[1]] = parseDate(matches[2])
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, nil, err
+	}
+	flush()
+
+	return lines, entries, nil
+}
+
+// parseHeadline reads a headline's keyword, priority cookie, title and tags; it is nil for headlines that aren't todos
+func parseHeadline(text string) *entry {
+	keyword, rest, _ := strings.Cut(text, " ")
+	if !slices.Contains(openKeywords, keyword) && !slices.Contains(doneKeywords, keyword) {
+		return nil
+	}
+
+	e := &entry{keyword: keyword, dates: make(map[string]*time.Time)}
+	rest = strings.TrimSpace(rest)
+	if matches := cookie.FindStringSubmatch(rest); matches != nil {
+		e.priority = matches[1]
+		rest = rest[len(matches[0]):]
+	}
+	if matches := tagList.FindStringSubmatch(rest); matches != nil {
+		for tag := range strings.SplitSeq(matches[1], ":") {
+			if tag != "" {
+				e.tags = append(e.tags, tag)
+			}
+		}
+		rest = rest[:len(rest)-len(matches[0])]
+	}
+	e.title = strings.TrimSpace(rest)
+	return e
+}
+
+// toTodo converts the headline; +projects and @contexts typed into the title are read too
+func (e *entry) toTodo() todo.Todo {
+	text := e.title
+	if date := e.dates["SCHEDULED"]; date != nil {
+		text += " scheduled:" + date.Format(todotxt.DateFormat)
+	}
+	parsed := todotxt.ParseNew(text, todo.PriorityNone, time.Time{})
+
+	projects, contexts := parsed.Projects(), parsed.Contexts()
+	for _, tag := range e.tags {
+		if name, ok := strings.CutPrefix(tag, "@"); ok {
+			contexts = append(contexts, name)
+		} else {
+			projects = append(projects, tag)
+		}
+	}
+
+	return todo.NewFull(parsed.Description(), priorityFor(e.priority), slices.Contains(doneKeywords, e.keyword), e.dates["CLOSED"], e.dates["CREATED"],
+		e.dates["DEADLINE"], e.dates["PRIORITISED"], projects, contexts)
+}
+
+// priorityFor reads a priority cookie's letter; Org's default range is A to C, but D and E are kept too
+func priorityFor(letter string) todo.Priority {
+	switch letter {
+	case "A":
+		return todo.PriorityA
+	case "B":
+		return todo.PriorityB
+	case "C":
+		return todo.PriorityC
+	case "D":
+		return todo.PriorityD
+	case "E":
+		return todo.PriorityE
+	default:
+		return todo.PriorityNone
+	}
+}
+
+// Marshal writes todos as Org headlines
+// This is the inverse operation of Unmarshal.
+func Marshal(w io.Writer, todos []todo.Todo) error {
+	for _, t := range todos {
+		if _, err := io.WriteString(w, format(t)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Rewrite writes todos into the Org file read from original, in place of its todo headlines.
+// Unchanged todos keep their text exactly, and changed ones keep their level, their keyword while
+// it still fits, and the notes and other properties under them. Headlines and text that aren't
+// todos are kept as they are, and new todos are added at the end.
+func Rewrite(original io.Reader, w io.Writer, todos []todo.Todo) error {
+	lines, entries, err := read(original)
+	if err != nil {
+		return err
+	}
+
+	before := make([]todo.Todo, len(entries))
+	for i, e := range entries {
+		before[i] = e.toTodo()
+	}
+	written := make(map[int]string, len(entries)) // by the line each entry starts on
+	var added strings.Builder
+	for i, j := range todo.Pair(before, todos) {
+		switch {
+		case j < 0:
+			added.WriteString(format(todos[i]))
+		case before[j].String() == todos[i].String():
+			written[entries[j].start] = strings.Join(lines[entries[j].start:entries[j].end], "\n") + "\n"
+		default:
+			written[entries[j].start] = entries[j].rewrite(todos[i], lines)
+		}
+	}
+
+	var b strings.Builder
+	next := 0
+	for _, e := range entries {
+		for _, line := range lines[next:e.start] {
+			b.WriteString(line + "\n")
+		}
+		b.WriteString(written[e.start]) // nothing for deleted todos
+		next = e.end
+	}
+	for _, line := range lines[next:] {
+		b.WriteString(line + "\n")
+	}
+	b.WriteString(added.String())
+
+	_, err = io.WriteString(w, b.String())
+	return err
+}
+
+// rewrite writes a changed todo in place of the entry, keeping what's under it that isn't one of its dates
+func (e *entry) rewrite(t todo.Todo, lines []string) string {
+	keyword := e.keyword
+	if t.IsCompleted() != slices.Contains(doneKeywords, keyword) {
+		keyword = keywordFor(t)
+	}
+
+	var properties, notes []string
+	inDrawer := false
+	for _, line := range lines[e.start+1 : e.end] {
+		trimmed := strings.TrimSpace(line)
+		switch {
+		case inDrawer && strings.EqualFold(trimmed, ":END:"):
+			inDrawer = false
+		case inDrawer:
+			if !property.MatchString(line) {
+				properties = append(properties, line)
+			}
+		case strings.EqualFold(trimmed, ":PROPERTIES:") && properties == nil:
+			inDrawer = true
+			properties = []string{}
+		case !plansOnly.MatchString(line):
+			notes = append(notes, line)
+		}
+	}
+	return formatEntry(t, e.stars, keyword, properties, notes)
+}
+
+func keywordFor(t todo.Todo) string {
+	if t.IsCompleted() {
+		return "DONE"
+	}
+	return "TODO"
+}
+
+// format writes a todo's headline, planning line and properties
+func format(t todo.Todo) string {
+	return formatEntry(t, "*", keywordFor(t), nil, nil)
+}
+
+// formatEntry writes a todo's headline at a level, then its planning line and properties, then
+// other properties and notes kept from the file
+func formatEntry(t todo.Todo, stars, keyword string, properties, notes []string) string {
+	var b strings.Builder
+	b.WriteString(stars + " " + keyword + " ")
+	if t.Priority() != todo.PriorityNone {
+		fmt.Fprintf(&b, "[#%s] ", t.Priority())
+	}
+
+	title := t.Description()
+	var scheduledOn string
+	if matches := scheduled.FindStringSubmatch(title); matches != nil {
+		scheduledOn = matches[1]
+		title = strings.Join(strings.Fields(scheduled.ReplaceAllString(title, " ")), " ")
+	}
+	b.WriteString(title)
+
+	tags := append([]string{}, t.Projects()...)
+	for _, context := range t.Contexts() {
+		tags = append(tags, "@"+context)
+	}
+	if len(tags) > 0 {
+		b.WriteString(" :" + strings.Join(tags, ":") + ":")
+	}
+	b.WriteString("\n")
+
+	var plans []string
+	if date := t.CompletionDate(); date != nil {
+		plans = append(plans, "CLOSED: ["+dayStamp(*date)+"]")
+	}
+	if date := t.DueDate(); date != nil {
+		plans = append(plans, "DEADLINE: <"+dayStamp(*date)+">")
+	}
+	if scheduledOn != "" {
+		if date := parseDate(scheduledOn); date != nil {
+			plans = append(plans, "SCHEDULED: <"+dayStamp(*date)+">")
+		}
+	}
+	if len(plans) > 0 {
+		b.WriteString("  " + strings.Join(plans, " ") + "\n")
+	}
+
+	if t.CreationDate() != nil || t.PrioritisedDate() != nil || len(properties) > 0 {
+		b.WriteString("  :PROPERTIES:\n")
+		if date := t.CreationDate(); date != nil {
+			b.WriteString("  :CREATED: [" + dayStamp(*date) + "]\n")
+		}
+		if date := t.PrioritisedDate(); date != nil {
+			b.WriteString("  :PRIORITISED: [" + dayStamp(*date) + "]\n")
+		}
+		for _, line := range properties {
+			b.WriteString(line + "\n")
+		}
+		b.WriteString("  :END:\n")
+	}
+	for _, line := range notes {
+		b.WriteString(line + "\n")
+	}
+	return b.String()
+}
+
+// dayStamp is the inside of an Org date stamp, such as "2026-01-20 Tue"
+func dayStamp(date time.Time) string {
+	return date.Format("2006-01-02 Mon")
+}
+
+func parseDate(s string) *time.Time {
+	if date, err := time.Parse(todotxt.DateFormat, s); err == nil {
+		return &date
+	}
+	return nil
+}
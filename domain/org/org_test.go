@@ -0,0 +1,123 @@
+package org_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/matryer/is"
+	"github.com/quii/todo-eisenhower/domain/org"
+	"github.com/quii/todo-eisenhower/domain/todo"
+	"github.com/quii/todo-eisenhower/domain/todotxt"
+)
+
+const notes = `#+TITLE: Notes
+* Work
+** TODO [#A] Renew passport :admin:@town:
+   DEADLINE: <2026-01-30 Fri>
+   :PROPERTIES:
+   :CREATED:  [2026-01-20 Tue]
+   :ID:       passport
+   :PRIORITISED: [2026-01-20 Tue]
+   :END:
+   Bring the old one.
+** DONE [#B] Book venue :offsite:
+   CLOSED: [2026-01-12 Mon 16:02] SCHEDULED: <2026-01-10 Sat>
+** NEXT Plan roadmap +product
+** Meeting notes
+- [ ] not a todo
+* CANCELLED Old idea
+`
+
+func TestUnmarshal(t *testing.T) {
+	//nolint:gocritic // importShadow: is := is.New(t) is idiomatic for github.com/matryer/is
+	is := is.New(t)
+	todos, err := org.Unmarshal(strings.NewReader(notes))
+	is.NoErr(err)
+	is.Equal(len(todos), 4)
+
+	is.Equal(todos[0].String(), "(A) 2026-01-20 Renew passport +admin @town due:2026-01-30 prioritised:2026-01-20\n")
+	is.Equal(todos[1].String(), "x 2026-01-12 (B) Book venue scheduled:2026-01-10 +offsite\n")
+	is.Equal(todos[2].String(), "Plan roadmap +product\n")
+	is.True(todos[3].IsCompleted())
+}
+
+func TestMarshal(t *testing.T) {
+	is := is.New(t)
+	todos, err := org.Unmarshal(strings.NewReader(notes))
+	is.NoErr(err)
+
+	var buf bytes.Buffer
+	is.NoErr(org.Marshal(&buf, todos))
+	is.Equal(buf.String(), `* TODO [#A] Renew passport :admin:@town:
+  DEADLINE: <2026-01-30 Fri>
+  :PROPERTIES:
+  :CREATED: [2026-01-20 Tue]
+  :PRIORITISED: [2026-01-20 Tue]
+  :END:
+* DONE [#B] Book venue :offsite:
+  CLOSED: [2026-01-12 Mon] SCHEDULED: <2026-01-10 Sat>
+* TODO Plan roadmap :product:
+* DONE Old idea
+`)
+}
+
+func TestRoundTrip(t *testing.T) {
+	is := is.New(t)
+	lines := "(A) 2026-01-20 Fix prod +ops @laptop due:2026-01-21 prioritised:2026-01-20\n" +
+		"x 2026-01-19 2026-01-10 (C) Reply to vendor\n" +
+		"(E) Someday +ideas\n" +
+		"Unsorted\n"
+	todos, err := todotxt.Unmarshal(strings.NewReader(lines))
+	is.NoErr(err)
+
+	var buf bytes.Buffer
+	is.NoErr(org.Marshal(&buf, todos))
+	read, err := org.Unmarshal(&buf)
+	is.NoErr(err)
+
+	var out bytes.Buffer
+	is.NoErr(todotxt.Marshal(&out, read))
+	is.Equal(out.String(), lines)
+	is.Equal(read[2].Priority(), todo.PriorityE)
+}
+
+func TestRewrite(t *testing.T) {
+	is := is.New(t)
+	todos, err := org.Unmarshal(strings.NewReader(notes))
+	is.NoErr(err)
+
+	// Complete Renew passport, reprioritise Plan roadmap, archive Old idea and add a todo
+	now := time.Date(2026, 1, 21, 9, 0, 0, 0, time.UTC)
+	todos = []todo.Todo{
+		todos[1],
+		todos[0].ToggleCompletion(now),
+		todos[2].ChangePriority(todo.PriorityA),
+		todo.NewWithTags("Call mum", todo.PriorityC, nil, []string{"phone"}),
+	}
+
+	var buf bytes.Buffer
+	is.NoErr(org.Rewrite(strings.NewReader(notes), &buf, todos))
+	is.Equal(buf.String(), `#+TITLE: Notes
+* Work
+** DONE [#A] Renew passport :admin:@town:
+  CLOSED: [2026-01-21 Wed] DEADLINE: <2026-01-30 Fri>
+  :PROPERTIES:
+  :CREATED: [2026-01-20 Tue]
+  :PRIORITISED: [2026-01-20 Tue]
+   :ID:       passport
+  :END:
+   Bring the old one.
+** DONE [#B] Book venue :offsite:
+   CLOSED: [2026-01-12 Mon 16:02] SCHEDULED: <2026-01-10 Sat>
+** NEXT [#A] Plan roadmap :product:
+** Meeting notes
+- [ ] not a todo
+* TODO [#C] Call mum :@phone:
+`)
+
+	read, err := org.Unmarshal(&buf)
+	is.NoErr(err)
+	is.Equal(len(read), len(todos))
+}
@@ -0,0 +1,320 @@
+// Package taskimport reads the JSON exports of other task managers, Taskwarrior and Todoist, as todos.
+//
+// Tasks are placed in quadrants by Rules: a task is important when its priority is one of
+// Rules.Important, and urgent when it is due within Rules.UrgentWithinDays or, for Taskwarrior,
+// its urgency is at least Rules.UrgentAbove. Urgent and important is Do First, important is
+// Schedule, urgent is Delegate and neither is Eliminate.
+//
+// Each todo keeps the id of the task it came from in a tw:<uuid> or todoist:<id> tag, so importing the
+// same export again recognises tasks even after they have been edited in the matrix.
+package taskimport
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/quii/todo-eisenhower/domain/todo"
+	"github.com/quii/todo-eisenhower/domain/todotxt"
+)
+
+// Rules decide which quadrant an imported task goes to
+type Rules struct {
+	Important        []string // priorities that make a task important, such as "H" or "p1"
+	UrgentWithinDays int      // tasks due within this many days (or overdue) are urgent
+	UrgentAbove      float64  // Taskwarrior tasks with at least this urgency are urgent; 0 turns it off
+	Now              time.Time
+}
+
+// DefaultTaskwarriorRules treat high priority tasks as important, and tasks due within two days or
+// with an urgency of 8 or more as urgent
+func DefaultTaskwarriorRules(now time.Time) Rules {
+	return Rules{Important: []string{"H"}, UrgentWithinDays: 2, UrgentAbove: 8, Now: now}
+}
+
+// DefaultTodoistRules treat p1 and p2 tasks as important, and tasks due within two days as urgent
+func DefaultTodoistRules(now time.Time) Rules {
+	return Rules{Important: []string{"p1", "p2"}, UrgentWithinDays: 2, Now: now}
+}
+
+// priority places a task in a quadrant
+func (r Rules) priority(taskPriority string, due *time.Time, urgency float64) todo.Priority {
+	important := false
+	for _, p := range r.Important {
+		if taskPriority != "" && strings.EqualFold(p, taskPriority) {
+			important = true
+		}
+	}
+
+	urgent := r.UrgentAbove > 0 && urgency >= r.UrgentAbove
+	if due != nil {
+		today := time.Date(r.Now.Year(), r.Now.Month(), r.Now.Day(), 0, 0, 0, 0, time.UTC)
+		urgent = urgent || !due.After(today.AddDate(0, 0, r.UrgentWithinDays))
+	}
+
+	switch {
+	case urgent && important:
+		return todo.PriorityA
+	case important:
+		return todo.PriorityB
+	case urgent:
+		return todo.PriorityC
+	default:
+		return todo.PriorityD
+	}
+}
+
+// task is an imported task in a form shared by every format
+type task struct {
+	id             string // the task's id as a tag, such as tw:<uuid>
+	description    string
+	priority       string
+	project        string
+	tags           []string
+	due            *time.Time
+	created        *time.Time
+	completed      bool
+	completionDate *time.Time
+	urgency        float64
+}
+
+// sourceIDPattern finds the tw: or todoist: tag an imported todo keeps its task's id in
+var sourceIDPattern = regexp.MustCompile(`(?:^|\s)((?:tw|todoist):\S+)`)
+
+// Key identifies a task across imports: the tw:<uuid> or todoist:<id> tag its todo was given, or for
+// todos without one, their description, tags and due date
+func Key(t todo.Todo) string {
+	if matches := sourceIDPattern.FindStringSubmatch(t.Description()); matches != nil {
+		return matches[1]
+	}
+	return todotxt.FormatForInput(t)
+}
+
+// nonWord matches the characters that can't be part of a todo.txt tag
+var nonWord = regexp.MustCompile(`\W+`)
+
+// tagName turns a project or tag name like "Home.Garden" into one todo.txt can read, "Home_Garden"
+func tagName(name string) string {
+	return strings.Trim(nonWord.ReplaceAllString(name, "_"), "_")
+}
+
+// toTodo converts the task, keeping its dates; the project becomes a +project and tags become @contexts
+func (t task) toTodo(rules Rules) todo.Todo {
+	description := strings.Join(strings.Fields(t.description), " ")
+	priority := rules.priority(t.priority, t.due, t.urgency)
+
+	// Tags typed into the description are read like any other todo's
+	parsed := todotxt.ParseNew(description, priority, time.Time{})
+	projects := parsed.Projects()
+	if name := tagName(t.project); name != "" {
+		projects = append(projects, name)
+	}
+	contexts := parsed.Contexts()
+	for _, tag := range t.tags {
+		if name := tagName(tag); name != "" {
+			contexts = append(contexts, name)
+		}
+	}
+
+	due := t.due
+	if due == nil {
+		due = parsed.DueDate()
+	}
+
+	var prioritised *time.Time
+	if priority == todo.PriorityA && !t.completed {
+		prioritised = &rules.Now
+	}
+
+	description = parsed.Description()
+	if t.id != "" {
+		description += " " + t.id // added after parsing so the id is never read as a tag or date
+	}
+
+	return todo.NewFull(description, priority, t.completed, t.completionDate, t.created, due, prioritised, projects, contexts)
+}
+
+// taskwarriorTask is a task in the output of "task export"
+type taskwarriorTask struct {
+	UUID        string   `json:"uuid"`
+	Description string   `json:"description"`
+	Status      string   `json:"status"`
+	Project     string   `json:"project"`
+	Tags        []string `json:"tags"`
+	Priority    string   `json:"priority"`
+	Entry       string   `json:"entry"`
+	End         string   `json:"end"`
+	Due         string   `json:"due"`
+	Urgency     float64  `json:"urgency"`
+}
+
+// Taskwarrior reads the output of "task export"
+// Deleted tasks and the templates of recurring tasks are skipped.
+func Taskwarrior(r io.Reader, rules Rules) ([]todo.Todo, error) {
+	var tasks []taskwarriorTask
+	if err := decode(r, &tasks); err != nil {
+		return nil, err
+	}
+
+	var todos []todo.Todo
+	for _, t := range tasks {
+		if t.Description == "" || t.Status == "deleted" || t.Status == "recurring" {
+			continue
+		}
+		completed := t.Status == "completed"
+		imported := task{
+			id:          sourceID("tw", t.UUID),
+			description: t.Description,
+			priority:    t.Priority,
+			project:     t.Project,
+			tags:        t.Tags,
+			due:         parseDate(t.Due, "20060102"),
+			created:     parseDate(t.Entry, "20060102"),
+			completed:   completed,
+			urgency:     t.Urgency,
+		}
+		if completed {
+			imported.completionDate = parseDate(t.End, "20060102")
+		}
+		todos = append(todos, imported.toTodo(rules))
+	}
+	return todos, nil
+}
+
+// todoistTask is a task in a Todoist export, from either the REST or the Sync API
+type todoistTask struct {
+	ID        flexibleID `json:"id"`
+	Content   string     `json:"content"`
+	ProjectID flexibleID `json:"project_id"`
+	Labels    []string   `json:"labels"`
+	Priority  int        `json:"priority"` // 4 is p1, the highest
+	Due       *struct {
+		Date string `json:"date"`
+	} `json:"due"`
+	Checked     flexibleBool `json:"checked"`
+	IsCompleted flexibleBool `json:"is_completed"`
+	AddedAt     string       `json:"added_at"`
+	CreatedAt   string       `json:"created_at"`
+	DateAdded   string       `json:"date_added"`
+	CompletedAt string       `json:"completed_at"`
+}
+
+// todoistExport is a Sync API export, or a REST export saved with its projects
+type todoistExport struct {
+	Items    []todoistTask `json:"items"`
+	Tasks    []todoistTask `json:"tasks"`
+	Projects []struct {
+		ID   flexibleID `json:"id"`
+		Name string     `json:"name"`
+	} `json:"projects"`
+}
+
+// Todoist reads a Todoist JSON export: a list of tasks from the REST API, or an object with the
+// items (or tasks) and projects from the Sync API. Projects are named when the export includes them.
+func Todoist(r io.Reader, rules Rules) ([]todo.Todo, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var export todoistExport
+	if trimmed := bytes.TrimSpace(data); len(trimmed) > 0 && trimmed[0] == '[' {
+		err = decode(bytes.NewReader(data), &export.Tasks)
+	} else {
+		err = decode(bytes.NewReader(data), &export)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	projectNames := make(map[flexibleID]string, len(export.Projects))
+	for _, p := range export.Projects {
+		projectNames[p.ID] = p.Name
+	}
+
+	var todos []todo.Todo
+	for _, t := range append(export.Items, export.Tasks...) {
+		if t.Content == "" {
+			continue
+		}
+		imported := task{
+			id:          sourceID("todoist", string(t.ID)),
+			description: t.Content,
+			project:     projectNames[t.ProjectID],
+			tags:        t.Labels,
+			created:     parseDate(firstOf(t.AddedAt, t.CreatedAt, t.DateAdded), todotxt.DateFormat),
+			completed:   bool(t.Checked) || bool(t.IsCompleted),
+		}
+		if t.Priority >= 1 && t.Priority <= 4 {
+			imported.priority = "p" + strconv.Itoa(5-t.Priority)
+		}
+		if t.Due != nil {
+			imported.due = parseDate(t.Due.Date, todotxt.DateFormat)
+		}
+		if imported.completed {
+			imported.completionDate = parseDate(t.CompletedAt, todotxt.DateFormat)
+		}
+		todos = append(todos, imported.toTodo(rules))
+	}
+	return todos, nil
+}
+
+// sourceID is the tag a task's id is kept in, or "" when the export has no id for it
+func sourceID(app, id string) string {
+	id = strings.Join(strings.Fields(id), "")
+	if id == "" || id == "null" {
+		return ""
+	}
+	return app + ":" + id
+}
+
+// decode reads a JSON export
+func decode(r io.Reader, v any) error {
+	if err := json.NewDecoder(r).Decode(v); err != nil {
+		return fmt.Errorf("invalid export: %w", err)
+	}
+	return nil
+}
+
+// parseDate reads the date at the start of a value such as "20260102T090000Z" or "2026-01-02T09:00:00Z"
+func parseDate(value, layout string) *time.Time {
+	if len(value) < len(layout) {
+		return nil
+	}
+	date, err := time.Parse(layout, value[:len(layout)])
+	if err != nil {
+		return nil
+	}
+	return &date
+}
+
+func firstOf(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+// flexibleID is an id that older exports write as a number and newer ones as a string
+type flexibleID string
+
+func (id *flexibleID) UnmarshalJSON(data []byte) error {
+	*id = flexibleID(strings.Trim(string(data), `"`))
+	return nil
+}
+
+// flexibleBool is a flag that older exports write as 0 or 1 and newer ones as a boolean
+type flexibleBool bool
+
+func (b *flexibleBool) UnmarshalJSON(data []byte) error {
+	value := string(data)
+	*b = flexibleBool(value == "true" || value == "1")
+	return nil
+}
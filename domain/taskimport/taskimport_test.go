@@ -0,0 +1,98 @@
+package taskimport_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/matryer/is"
+	"github.com/quii/todo-eisenhower/domain/taskimport"
+	"github.com/quii/todo-eisenhower/domain/todo"
+)
+
+// now is Tuesday 2026-01-20
+var now = time.Date(2026, 1, 20, 9, 0, 0, 0, time.UTC)
+
+const taskwarriorExport = `[
+{"id":1,"uuid":"5f1c2a9e-0d3b-4c55-9a7e-1b2c3d4e5f60","description":"Fix prod","entry":"20260102T090000Z","due":"20260121T000000Z","priority":"H","project":"work.ops","status":"pending","tags":["laptop"],"urgency":14.2},
+{"id":2,"description":"Plan roadmap","entry":"20260110T090000Z","priority":"H","project":"work","status":"pending","urgency":6.5},
+{"id":3,"description":"Reply to vendor","entry":"20260115T090000Z","status":"pending","urgency":9.1},
+{"id":0,"description":"Book venue","entry":"20260105T090000Z","end":"20260112T160000Z","project":"offsite","status":"completed","urgency":0},
+{"id":0,"description":"Old idea","entry":"20260105T090000Z","status":"deleted"},
+{"id":0,"description":"Water plants","entry":"20260105T090000Z","status":"recurring"}
+]`
+
+func TestTaskwarrior(t *testing.T) {
+	t.Run("maps fields and places tasks by priority and urgency", func(t *testing.T) {
+		//nolint:gocritic // importShadow: is := is.New(t) is idiomatic for github.com/matryer/is
+		is := is.New(t)
+		todos, err := taskimport.Taskwarrior(strings.NewReader(taskwarriorExport), taskimport.DefaultTaskwarriorRules(now))
+		is.NoErr(err)
+		is.Equal(len(todos), 4) // deleted tasks and recurring templates are skipped
+
+		is.Equal(todos[0].String(), "(A) 2026-01-02 Fix prod tw:5f1c2a9e-0d3b-4c55-9a7e-1b2c3d4e5f60 +work_ops @laptop due:2026-01-21 prioritised:2026-01-20\n")
+		is.Equal(todos[1].String(), "(B) 2026-01-10 Plan roadmap +work\n")
+		is.Equal(todos[2].Priority(), todo.PriorityC) // urgency 9.1
+		is.Equal(todos[3].String(), "x 2026-01-12 2026-01-05 (D) Book venue +offsite\n")
+	})
+
+	t.Run("uses the given rules", func(t *testing.T) {
+		is := is.New(t)
+		rules := taskimport.Rules{Important: []string{"H", "M", ""}, UrgentWithinDays: 0, Now: now}
+		todos, err := taskimport.Taskwarrior(strings.NewReader(taskwarriorExport), rules)
+		is.NoErr(err)
+
+		is.Equal(todos[0].Priority(), todo.PriorityB) // due tomorrow isn't urgent today
+		is.Equal(todos[2].Priority(), todo.PriorityD) // urgency is ignored and no priority isn't important
+	})
+
+	t.Run("reports invalid files", func(t *testing.T) {
+		is := is.New(t)
+		_, err := taskimport.Taskwarrior(strings.NewReader(`{"description":`), taskimport.DefaultTaskwarriorRules(now))
+		is.True(err != nil)
+		is.True(strings.HasPrefix(err.Error(), "invalid export"))
+	})
+}
+
+func TestTodoist(t *testing.T) {
+	t.Run("reads a Sync API export with projects", func(t *testing.T) {
+		is := is.New(t)
+		export := `{
+			"projects": [{"id": "220474322", "name": "Home Admin"}],
+			"items": [
+				{"id": "2995104339", "content": "Renew passport", "project_id": "220474322", "priority": 4, "labels": ["errands"],
+				 "due": {"date": "2026-01-21", "is_recurring": false}, "checked": false, "added_at": "2026-01-02T09:00:00.000000Z"},
+				{"content": "Plan trip", "project_id": 220474322, "priority": 3, "checked": 0, "date_added": "2026-01-10T09:00:00Z"},
+				{"content": "Pay bill", "priority": 1, "checked": 1, "added_at": "2026-01-05T09:00:00Z", "completed_at": "2026-01-12T16:00:00Z"}
+			]
+		}`
+
+		todos, err := taskimport.Todoist(strings.NewReader(export), taskimport.DefaultTodoistRules(now))
+		is.NoErr(err)
+		is.Equal(len(todos), 3)
+
+		is.Equal(todos[0].String(), "(A) 2026-01-02 Renew passport todoist:2995104339 +Home_Admin @errands due:2026-01-21 prioritised:2026-01-20\n")
+		is.Equal(todos[1].String(), "(B) 2026-01-10 Plan trip +Home_Admin\n")
+		is.Equal(todos[2].String(), "x 2026-01-12 2026-01-05 (D) Pay bill\n")
+	})
+
+	t.Run("reads a REST API task list", func(t *testing.T) {
+		is := is.New(t)
+		export := `[{"content": "Call dentist @phone", "priority": 1, "is_completed": false, "created_at": "2026-01-19T09:00:00Z",
+			"due": {"date": "2026-01-20T10:00:00"}}]`
+
+		todos, err := taskimport.Todoist(strings.NewReader(export), taskimport.DefaultTodoistRules(now))
+		is.NoErr(err)
+		is.Equal(todos[0].String(), "(C) 2026-01-19 Call dentist @phone due:2026-01-20\n")
+	})
+}
+
+func TestKey(t *testing.T) {
+	is := is.New(t)
+	todos, err := taskimport.Taskwarrior(strings.NewReader(taskwarriorExport), taskimport.DefaultTaskwarriorRules(now))
+	is.NoErr(err)
+
+	is.Equal(taskimport.Key(todos[0]), "tw:5f1c2a9e-0d3b-4c55-9a7e-1b2c3d4e5f60")
+	is.Equal(taskimport.Key(todos[0].ChangePriority(todo.PriorityB)), "tw:5f1c2a9e-0d3b-4c55-9a7e-1b2c3d4e5f60")
+	is.Equal(taskimport.Key(todos[1]), "Plan roadmap +work") // no uuid in the export
+}
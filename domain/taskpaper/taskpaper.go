@@ -0,0 +1,367 @@
+// Package taskpaper provides encoding and decoding for TaskPaper files.
+// It follows the convention of encoding packages like encoding/json, like package todotxt.
+//
+// Tasks are lines starting with "- ", and projects are lines ending with a colon:
+//
+//	Renew passport @town @due(2026-01-30) @priority(A)
+//	Offsite:
+//		- Book venue @done(2026-01-12) @created(2026-01-05)
+//		- Send invites @phone
+//
+// A task's project (the nearest one above it) becomes its +project, and its plain @tags are
+// @contexts. @due, @done, @created and @prioritised hold the due, completion, creation and
+// prioritised dates; @priority(A) to @priority(E), or 1 to 5, is the priority. Other tags with
+// values, such as @start(2026-01-19), are kept as they are. Notes are skipped.
+//
+// Marshal writes tasks without a project first, then each project's tasks under it, so other
+// notes and the order of projects don't survive a round trip. Rewrite writes tasks back into the
+// file they were read from instead, keeping everything that isn't a task.
+package taskpaper
+
+import (
+	"bufio"
+	"io"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/quii/todo-eisenhower/domain/todo"
+	"github.com/quii/todo-eisenhower/domain/todotxt"
+)
+
+var (
+	taskLine    = regexp.MustCompile(`^(\s*)- (.*)$`)
+	projectLine = regexp.MustCompile(`^(\s*)([^\s-].*?):(?:\s+@.*)?\s*$`)
+	tag         = regexp.MustCompile(`(?:^|\s)@(\w[\w.-]*)(?:\(([^)]*)\))?`)
+	nonWord     = regexp.MustCompile(`\W+`)
+)
+
+// project is a project heading and how deeply it is indented, in whitespace characters
+type project struct {
+	name   string
+	indent int
+	prefix string // the whitespace it is indented by
+	end    int    // the line after the last line inside it
+}
+
+// task is a task line and the notes indented under it
+type task struct {
+	todo       todo.Todo
+	project    string
+	prefix     string // the whitespace it is indented by
+	start, end int    // its lines
+}
+
+// Unmarshal reads the tasks of a TaskPaper file
+// This is the inverse operation of Marshal.
+func Unmarshal(r io.Reader) ([]todo.Todo, error) {
+	_, tasks, _, err := read(r)
+	if err != nil {
+		return nil, err
+	}
+
+	todos := make([]todo.Todo, 0, len(tasks))
+	for _, t := range tasks {
+		todos = append(todos, t.todo)
+	}
+	return todos, nil
+}
+
+// read returns the lines of a TaskPaper file, its tasks and its project headings
+func read(r io.Reader) ([]string, []*task, []*project, error) {
+	var lines []string
+	var tasks []*task
+	var headings []*project
+	var projects []*project // the projects enclosing the current line, outermost first
+	var last *task          // the task that notes indented under it belong to
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1<<20)
+	for ; scanner.Scan(); lines = append(lines, scanner.Text()) {
+		line := scanner.Text()
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		i := len(lines)
+		prefix := line[:len(line)-len(strings.TrimLeft(line, " \t"))]
+
+		if matches := taskLine.FindStringSubmatch(line); matches != nil {
+			projects = enclosing(projects, len(prefix))
+			var name string
+			if len(projects) > 0 {
+				name = projects[len(projects)-1].name
+			}
+			last = &task{todo: parseTask(matches[2], name), project: name, prefix: prefix, start: i, end: i + 1}
+			tasks = append(tasks, last)
+		} else if matches := projectLine.FindStringSubmatch(line); matches != nil {
+			projects = append(enclosing(projects, len(prefix)), &project{name: projectName(matches[2]), indent: len(prefix), prefix: prefix, end: i + 1})
+			headings = append(headings, projects[len(projects)-1])
+			last = nil
+		} else if last != nil && len(prefix) > len(last.prefix) {
+			last.end = i + 1
+		} else {
+			last = nil
+		}
+
+		for _, p := range enclosing(projects, len(prefix)) {
+			p.end = i + 1
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, nil, nil, err
+	}
+
+	return lines, tasks, headings, nil
+}
+
+// enclosing drops the projects that a line indented by indent is no longer inside
+func enclosing(projects []*project, indent int) []*project {
+	for len(projects) > 0 && projects[len(projects)-1].indent >= indent {
+		projects = projects[:len(projects)-1]
+	}
+	return projects
+}
+
+// projectName turns a project heading like "Home Admin" into a todo.txt project, "Home_Admin"
+func projectName(heading string) string {
+	return strings.Trim(nonWord.ReplaceAllString(heading, "_"), "_")
+}
+
+// parseTask reads a task's text and tags; +projects and @contexts typed into the text are read too
+func parseTask(text, projectName string) todo.Todo {
+	var completed bool
+	var completionDate, creationDate, dueDate, prioritisedDate *time.Time
+	priority := todo.PriorityNone
+	var contexts, kept []string
+
+	rest := tag.ReplaceAllStringFunc(text, func(match string) string {
+		matches := tag.FindStringSubmatch(match)
+		name, value, hasValue := matches[1], matches[2], strings.Contains(match, "(")
+		switch strings.ToLower(name) {
+		case "done":
+			completed = true
+			completionDate = parseDate(value)
+		case "due":
+			dueDate = parseDate(value)
+		case "created":
+			creationDate = parseDate(value)
+		case "prioritised", "prioritized":
+			prioritisedDate = parseDate(value)
+		case "priority":
+			priority = priorityFor(value)
+		default:
+			if hasValue {
+				kept = append(kept, strings.TrimSpace(match))
+				return " "
+			}
+			contexts = append(contexts, name)
+		}
+		return " "
+	})
+
+	parsed := todotxt.ParseNew(rest, todo.PriorityNone, time.Time{})
+	var projects []string
+	if projectName != "" {
+		projects = append(projects, projectName)
+	}
+	for _, name := range parsed.Projects() {
+		if name != projectName {
+			projects = append(projects, name)
+		}
+	}
+	contexts = append(contexts, parsed.Contexts()...)
+
+	// Tags this package doesn't know are kept with their values, after the text
+	description := strings.Join(append([]string{parsed.Description()}, kept...), " ")
+	return todo.NewFull(strings.TrimSpace(description), priority, completed, completionDate, creationDate, dueDate, prioritisedDate, projects, contexts)
+}
+
+// priorityFor reads @priority(A) to (E), or 1 to 5
+func priorityFor(value string) todo.Priority {
+	switch strings.ToUpper(strings.TrimSpace(value)) {
+	case "A", "1":
+		return todo.PriorityA
+	case "B", "2":
+		return todo.PriorityB
+	case "C", "3":
+		return todo.PriorityC
+	case "D", "4":
+		return todo.PriorityD
+	case "E", "5":
+		return todo.PriorityE
+	default:
+		return todo.PriorityNone
+	}
+}
+
+// Marshal writes todos as TaskPaper tasks, grouped under their first project
+// This is the inverse operation of Unmarshal.
+func Marshal(w io.Writer, todos []todo.Todo) error {
+	entries := make([]entry, len(todos))
+	for i, t := range todos {
+		entries[i] = entry{todo: t}
+	}
+	_, err := io.WriteString(w, grouped(entries))
+	return err
+}
+
+// entry is a task to write and the notes that go under it, without their indentation
+type entry struct {
+	todo  todo.Todo
+	notes []string
+}
+
+// grouped writes tasks without a project first, then each project's tasks under it
+func grouped(entries []entry) string {
+	var order []string
+	byProject := make(map[string][]entry)
+	for _, e := range entries {
+		name := firstProject(e.todo)
+		if _, seen := byProject[name]; !seen && name != "" {
+			order = append(order, name)
+		}
+		byProject[name] = append(byProject[name], e)
+	}
+
+	var b strings.Builder
+	for _, e := range byProject[""] {
+		writeTask(&b, e, "", "")
+	}
+	for _, name := range order {
+		b.WriteString(name + ":\n")
+		for _, e := range byProject[name] {
+			writeTask(&b, e, "\t", name)
+		}
+	}
+	return b.String()
+}
+
+func writeTask(b *strings.Builder, e entry, prefix, projectName string) {
+	b.WriteString(prefix + format(e.todo, projectName) + "\n")
+	for _, note := range e.notes {
+		b.WriteString(prefix + "\t" + note + "\n")
+	}
+}
+
+func firstProject(t todo.Todo) string {
+	if projects := t.Projects(); len(projects) > 0 {
+		return projects[0]
+	}
+	return ""
+}
+
+// Rewrite writes todos into the TaskPaper file read from original, in place of its tasks.
+// Unchanged tasks keep their text exactly, and changed ones keep their place and notes unless
+// they've left their project. Projects, notes and other text are kept as they are. New tasks go
+// at the end of their project, or at the end of the file as Marshal writes them when the file
+// doesn't have their project.
+func Rewrite(original io.Reader, w io.Writer, todos []todo.Todo) error {
+	lines, tasks, headings, err := read(original)
+	if err != nil {
+		return err
+	}
+
+	before := make([]todo.Todo, len(tasks))
+	for i, t := range tasks {
+		before[i] = t.todo
+	}
+	written := make(map[int][]string, len(tasks)) // by the line each task starts on; deleted tasks have none
+	var added []entry
+	for i, j := range todo.Pair(before, todos) {
+		if j < 0 {
+			added = append(added, entry{todo: todos[i]})
+			continue
+		}
+		old, t := tasks[j], todos[i]
+		switch {
+		case old.todo.String() == t.String():
+			written[old.start] = lines[old.start:old.end]
+		case old.project == "" || slices.Contains(t.Projects(), old.project):
+			written[old.start] = append([]string{old.prefix + format(t, old.project)}, lines[old.start+1:old.end]...)
+		default:
+			// It has left its project, so it moves to its new one with its notes
+			var notes []string
+			for _, line := range lines[old.start+1 : old.end] {
+				notes = append(notes, strings.TrimPrefix(strings.TrimPrefix(line, old.prefix), "\t"))
+			}
+			added = append(added, entry{todo: t, notes: notes})
+		}
+	}
+
+	inserted := make(map[int]string) // new tasks, by the line they go before
+	var rest []entry
+	for _, e := range added {
+		i := slices.IndexFunc(headings, func(p *project) bool { return p.name == firstProject(e.todo) })
+		if i < 0 {
+			rest = append(rest, e)
+			continue
+		}
+		var b strings.Builder
+		writeTask(&b, e, headings[i].prefix+"\t", headings[i].name)
+		inserted[headings[i].end] += b.String()
+	}
+
+	var b strings.Builder
+	for i := 0; i < len(lines); i++ {
+		b.WriteString(inserted[i])
+		at := slices.IndexFunc(tasks, func(t *task) bool { return t.start == i })
+		if at < 0 {
+			b.WriteString(lines[i] + "\n")
+			continue
+		}
+		for _, line := range written[i] {
+			b.WriteString(line + "\n")
+		}
+		i = tasks[at].end - 1
+	}
+	b.WriteString(inserted[len(lines)])
+	b.WriteString(grouped(rest))
+
+	_, err = io.WriteString(w, b.String())
+	return err
+}
+
+// format writes a task line, leaving out the project it's written under
+func format(t todo.Todo, projectName string) string {
+	parts := []string{"-", t.Description()}
+	for _, name := range t.Projects() {
+		if name != projectName {
+			parts = append(parts, "+"+name)
+		}
+	}
+	for _, context := range t.Contexts() {
+		parts = append(parts, "@"+context)
+	}
+	if t.Priority() != todo.PriorityNone {
+		parts = append(parts, "@priority("+t.Priority().String()+")")
+	}
+	dates := []struct {
+		name string
+		date *time.Time
+	}{
+		{"due", t.DueDate()},
+		{"created", t.CreationDate()},
+		{"prioritised", t.PrioritisedDate()},
+		{"done", t.CompletionDate()},
+	}
+	for _, d := range dates {
+		if d.date != nil {
+			parts = append(parts, "@"+d.name+"("+d.date.Format(todotxt.DateFormat)+")")
+		}
+	}
+	if t.IsCompleted() && t.CompletionDate() == nil {
+		parts = append(parts, "@done")
+	}
+	return strings.Join(parts, " ")
+}
+
+func parseDate(s string) *time.Time {
+	// TaskPaper dates can have a time, as in @done(2026-01-12 16:02)
+	if len(s) >= len(todotxt.DateFormat) {
+		if date, err := time.Parse(todotxt.DateFormat, s[:len(todotxt.DateFormat)]); err == nil {
+			return &date
+		}
+	}
+	return nil
+}
@@ -0,0 +1,118 @@
+package taskpaper_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/matryer/is"
+	"github.com/quii/todo-eisenhower/domain/taskpaper"
+	"github.com/quii/todo-eisenhower/domain/todo"
+	"github.com/quii/todo-eisenhower/domain/todotxt"
+)
+
+const tasks = "- Renew passport @town @due(2026-01-30) @priority(A)\n" +
+	"Home Admin:\n" +
+	"\t- Pay council tax @priority(2) @created(2026-01-10)\n" +
+	"\tA note about bills\n" +
+	"\tUtilities:\n" +
+	"\t\t- Switch energy supplier @start(2026-01-19) +money\n" +
+	"\t- Book boiler service @done(2026-01-12 16:02)\n" +
+	"Offsite: @q1\n" +
+	"\t- Send invites @phone\n"
+
+func TestUnmarshal(t *testing.T) {
+	//nolint:gocritic // importShadow: is := is.New(t) is idiomatic for github.com/matryer/is
+	is := is.New(t)
+	todos, err := taskpaper.Unmarshal(strings.NewReader(tasks))
+	is.NoErr(err)
+	is.Equal(len(todos), 5)
+
+	is.Equal(todos[0].String(), "(A) Renew passport @town due:2026-01-30\n")
+	is.Equal(todos[1].String(), "(B) 2026-01-10 Pay council tax +Home_Admin\n")
+	is.Equal(todos[2].String(), "Switch energy supplier @start(2026-01-19) +Utilities +money\n")
+	is.Equal(todos[3].String(), "x 2026-01-12 Book boiler service +Home_Admin\n") // back out of Utilities
+	is.Equal(todos[4].String(), "Send invites +Offsite @phone\n")
+}
+
+func TestMarshal(t *testing.T) {
+	is := is.New(t)
+	todos, err := taskpaper.Unmarshal(strings.NewReader(tasks))
+	is.NoErr(err)
+
+	var buf bytes.Buffer
+	is.NoErr(taskpaper.Marshal(&buf, todos))
+	is.Equal(buf.String(), "- Renew passport @town @priority(A) @due(2026-01-30)\n"+
+		"Home_Admin:\n"+
+		"\t- Pay council tax @priority(B) @created(2026-01-10)\n"+
+		"\t- Book boiler service @done(2026-01-12)\n"+
+		"Utilities:\n"+
+		"\t- Switch energy supplier @start(2026-01-19) +money\n"+
+		"Offsite:\n"+
+		"\t- Send invites @phone\n")
+}
+
+func TestRoundTrip(t *testing.T) {
+	is := is.New(t)
+	lines := "(A) 2026-01-20 Fix prod +ops @laptop due:2026-01-21 prioritised:2026-01-20\n" +
+		"x 2026-01-19 2026-01-10 (C) Reply to vendor\n" +
+		"(E) Someday +ideas +later\n" +
+		"Unsorted\n"
+	todos, err := todotxt.Unmarshal(strings.NewReader(lines))
+	is.NoErr(err)
+
+	var buf bytes.Buffer
+	is.NoErr(taskpaper.Marshal(&buf, todos))
+	read, err := taskpaper.Unmarshal(&buf)
+	is.NoErr(err)
+	is.Equal(len(read), 4)
+
+	var out bytes.Buffer
+	is.NoErr(todotxt.Marshal(&out, read))
+	is.Equal(out.String(), "x 2026-01-19 2026-01-10 (C) Reply to vendor\n"+
+		"Unsorted\n"+
+		"(A) 2026-01-20 Fix prod +ops @laptop due:2026-01-21 prioritised:2026-01-20\n"+
+		"(E) Someday +ideas +later\n")
+	is.Equal(read[3].Priority(), todo.PriorityE)
+}
+
+func TestRewrite(t *testing.T) {
+	is := is.New(t)
+	original := "Notes from the planning meeting, kept as they are.\n" + tasks +
+		"\t\tWho's coming?\n"
+	todos, err := taskpaper.Unmarshal(strings.NewReader(original))
+	is.NoErr(err)
+
+	// Reprioritise Renew passport, complete Pay council tax, archive Book boiler service, move Send
+	// invites to Home Admin and add two tasks
+	now := time.Date(2026, 1, 21, 9, 0, 0, 0, time.UTC)
+	todos = []todo.Todo{
+		todos[1].ToggleCompletion(now),
+		todos[2],
+		todos[0].ChangePriority(todo.PriorityB),
+		todo.NewWithTags("Send invites", todo.PriorityNone, []string{"Home_Admin"}, []string{"phone"}),
+		todo.NewWithTags("Book train", todo.PriorityB, []string{"Offsite"}, nil),
+		todo.NewWithTags("Learn Go", todo.PriorityNone, []string{"Personal"}, nil),
+	}
+
+	var buf bytes.Buffer
+	is.NoErr(taskpaper.Rewrite(strings.NewReader(original), &buf, todos))
+	is.Equal(buf.String(), "Notes from the planning meeting, kept as they are.\n"+
+		"- Renew passport @town @priority(B) @due(2026-01-30)\n"+
+		"Home Admin:\n"+
+		"\t- Pay council tax @priority(B) @created(2026-01-10) @done(2026-01-21)\n"+
+		"\tA note about bills\n"+
+		"\tUtilities:\n"+
+		"\t\t- Switch energy supplier @start(2026-01-19) +money\n"+
+		"\t- Send invites @phone\n"+
+		"\t\tWho's coming?\n"+
+		"Offsite: @q1\n"+
+		"\t- Book train @priority(B)\n"+
+		"Personal:\n"+
+		"\t- Learn Go\n")
+
+	read, err := taskpaper.Unmarshal(&buf)
+	is.NoErr(err)
+	is.Equal(len(read), len(todos))
+}
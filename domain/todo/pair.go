@@ -0,0 +1,37 @@
+package todo
+
+// Pair matches the todos about to be saved with the todos read from a file, so file formats that
+// hold more than todos can write each todo back in its place.
+// For each todo in after it returns the index in before of the todo it replaces, or -1 for a new
+// todo. Unchanged todos are matched first, then todos with the same description, then the rest in order.
+func Pair(before, after []Todo) []int {
+	pairs := make([]int, len(after))
+	for i := range pairs {
+		pairs[i] = -1
+	}
+	taken := make([]bool, len(before))
+
+	for _, key := range []func(Todo) string{
+		Todo.String,
+		Todo.Description,
+		func(Todo) string { return "" },
+	} {
+		free := make(map[string][]int)
+		for j, t := range before {
+			if !taken[j] {
+				free[key(t)] = append(free[key(t)], j)
+			}
+		}
+		for i, t := range after {
+			if pairs[i] >= 0 {
+				continue
+			}
+			k := key(t)
+			if candidates := free[k]; len(candidates) > 0 {
+				pairs[i], taken[candidates[0]] = candidates[0], true
+				free[k] = candidates[1:]
+			}
+		}
+	}
+	return pairs
+}
@@ -0,0 +1,35 @@
+package todo_test
+
+import (
+	"testing"
+
+	"github.com/matryer/is"
+	"github.com/quii/todo-eisenhower/domain/todo"
+)
+
+func TestPair(t *testing.T) {
+	fixProd := todo.New("Fix prod", todo.PriorityA)
+	planRoadmap := todo.New("Plan roadmap", todo.PriorityB)
+	replyToEmails := todo.New("Reply to emails", todo.PriorityC)
+	before := []todo.Todo{fixProd, planRoadmap, replyToEmails}
+
+	t.Run("matches unchanged todos wherever they are", func(t *testing.T) {
+		is := is.New(t)
+		is.Equal(todo.Pair(before, []todo.Todo{replyToEmails, fixProd, planRoadmap}), []int{2, 0, 1})
+	})
+
+	t.Run("matches changed todos by description, then in order", func(t *testing.T) {
+		is := is.New(t)
+		after := []todo.Todo{
+			todo.New("Reply to all emails", todo.PriorityC),
+			planRoadmap.ChangePriority(todo.PriorityA),
+			fixProd,
+		}
+		is.Equal(todo.Pair(before, after), []int{2, 1, 0})
+	})
+
+	t.Run("leaves new todos unmatched", func(t *testing.T) {
+		is := is.New(t)
+		is.Equal(todo.Pair(before[:1], []todo.Todo{planRoadmap, fixProd}), []int{-1, 0})
+	})
+}
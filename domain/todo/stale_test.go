@@ -246,3 +246,15 @@ func TestIsStale_NoPriority(t *testing.T) {
 		is.Equal(td.IsStale(now), false)
 	})
 }
+
+func TestStalePolicy(t *testing.T) {
+	is := is.New(t)
+	creationDate := time.Date(2026, 1, 13, 0, 0, 0, 0, time.UTC) // Monday
+	now := time.Date(2026, 1, 16, 0, 0, 0, 0, time.UTC)          // Thursday (3 business days)
+	td := NewFull("Task", PriorityB, false, nil, &creationDate, nil, nil, nil, nil)
+
+	is.Equal(td.IsStale(now), false) // default allows 5 business days
+
+	strict := StalePolicy{DoFirstDays: 1, OtherDays: 2}
+	is.Equal(strict.IsStale(td, now), true)
+}
@@ -2,6 +2,7 @@
 package todo
 
 import (
+	"strings"
 	"time"
 )
 
@@ -26,8 +27,10 @@ type Todo struct {
 	creationDate    *time.Time // nil if no creation date recorded
 	dueDate         *time.Time // nil if no due date recorded
 	prioritisedDate *time.Time // nil if not Priority A or no prioritised date recorded
+	deletedDate     *time.Time // nil unless the todo has been moved to the trash
 	projects        []string
 	contexts        []string
+	source          string // workspace file the todo belongs to (not part of the todo.txt line)
 }
 
 // NewFull is a comprehensive constructor used by the todotxt parser to create todos with all fields
@@ -226,11 +229,42 @@ func (t Todo) DueDate() *time.Time {
 	return t.dueDate
 }
 
+// IsOverdue returns true if the todo is still open and its due date is before today
+func (t Todo) IsOverdue(now time.Time) bool {
+	if t.completed || t.dueDate == nil {
+		return false
+	}
+	due := time.Date(t.dueDate.Year(), t.dueDate.Month(), t.dueDate.Day(), 0, 0, 0, 0, time.UTC)
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	return due.Before(today)
+}
+
 // PrioritisedDate returns the todo's prioritised date (nil if not Priority A or no date recorded)
 func (t Todo) PrioritisedDate() *time.Time {
 	return t.prioritisedDate
 }
 
+// DeletedDate returns when the todo was moved to the trash (nil if not deleted)
+func (t Todo) DeletedDate() *time.Time {
+	return t.deletedDate
+}
+
+// Source returns the name of the workspace file the todo belongs to (empty outside workspaces)
+func (t Todo) Source() string {
+	return t.source
+}
+
+// WithSource returns a new Todo that belongs to the named workspace file
+func (t Todo) WithSource(source string) Todo {
+	t.source = source
+	return t
+}
+
+// MatchesSource returns true if the todo belongs to the named workspace file (case-insensitive)
+func (t Todo) MatchesSource(source string) bool {
+	return source != "" && strings.EqualFold(t.source, source)
+}
+
 // Projects returns the todo's project tags
 func (t Todo) Projects() []string {
 	return t.projects
@@ -241,11 +275,73 @@ func (t Todo) Contexts() []string {
 	return t.contexts
 }
 
+// MatchesTag returns true if the todo has the given tag (case-insensitive)
+// Filter format: "+project" for projects, "@context" for contexts
+func (t Todo) MatchesTag(filter string) bool {
+	if len(filter) < 2 {
+		return false
+	}
+
+	tag := filter[1:]
+	var tags []string
+	switch filter[0] {
+	case '+':
+		tags = t.projects
+	case '@':
+		tags = t.contexts
+	default:
+		return false
+	}
+
+	for _, candidate := range tags {
+		if strings.EqualFold(candidate, tag) {
+			return true
+		}
+	}
+	return false
+}
+
+// MatchesText returns true if the query appears in the description or tags (case-insensitive)
+func (t Todo) MatchesText(query string) bool {
+	query = strings.ToLower(query)
+	if strings.Contains(strings.ToLower(t.description), query) {
+		return true
+	}
+	for _, tag := range append(append([]string{}, t.projects...), t.contexts...) {
+		if strings.Contains(strings.ToLower(tag), query) {
+			return true
+		}
+	}
+	return false
+}
+
+// StalePolicy says how many business days a todo can sit in its quadrant before it is stale
+type StalePolicy struct {
+	DoFirstDays int // Do First: counted from the prioritised date
+	OtherDays   int // Schedule, Delegate and Eliminate: counted from the creation date
+}
+
+// DefaultStalePolicy is used unless the user configures different thresholds
+var DefaultStalePolicy = StalePolicy{DoFirstDays: 2, OtherDays: 5}
+
+// DefaultCharLimit is the longest todo description that can be typed, unless the user configures another
+const DefaultCharLimit = 200
+
+// DefaultWIPThreshold is how many incomplete todos a tag can have before it is flagged as high
+// work-in-progress, unless the user configures another
+const DefaultWIPThreshold = 5
+
 // IsStale returns true if the todo has been sitting in its quadrant for too long
 // Completed tasks are never stale
 // Priority A: stale after 2 business days from prioritisedDate
 // Priority B/C/D: stale after 5 business days from creationDate
 func (t Todo) IsStale(now time.Time) bool {
+	return DefaultStalePolicy.IsStale(t, now)
+}
+
+// IsStale returns true if the todo has been in its quadrant for more business days than the policy allows
+// Completed tasks are never stale
+func (p StalePolicy) IsStale(t Todo, now time.Time) bool {
 	// Completed tasks are never stale
 	if t.completed {
 		return false
@@ -258,7 +354,7 @@ func (t Todo) IsStale(now time.Time) bool {
 			return false // No prioritised date, can't be stale
 		}
 		businessDays := businessDaysBetween(*t.prioritisedDate, now)
-		return businessDays > 2
+		return businessDays > p.DoFirstDays
 
 	case PriorityB, PriorityC, PriorityD:
 		// Schedule/Delegate/Eliminate: check creation date
@@ -266,7 +362,7 @@ func (t Todo) IsStale(now time.Time) bool {
 			return false // No creation date, can't be stale
 		}
 		businessDays := businessDaysBetween(*t.creationDate, now)
-		return businessDays > 5
+		return businessDays > p.OtherDays
 
 	default:
 		// No priority or invalid priority
@@ -325,8 +421,10 @@ func (t Todo) ToggleCompletion(now time.Time) Todo {
 		creationDate:    t.creationDate,
 		dueDate:         t.dueDate,
 		prioritisedDate: t.prioritisedDate,
+		deletedDate:     t.deletedDate,
 		projects:        t.projects,
 		contexts:        t.contexts,
+		source:          t.source,
 	}
 }
 
@@ -340,11 +438,33 @@ func (t Todo) ChangePriority(newPriority Priority) Todo {
 		creationDate:    t.creationDate,
 		dueDate:         t.dueDate,
 		prioritisedDate: t.prioritisedDate,
+		deletedDate:     t.deletedDate,
 		projects:        t.projects,
 		contexts:        t.contexts,
+		source:          t.source,
 	}
 }
 
+// MarkDeleted returns a new Todo stamped with the date it was moved to the trash
+// The now parameter allows deterministic testing and follows dependency inversion
+func (t Todo) MarkDeleted(now time.Time) Todo {
+	t.deletedDate = &now
+	return t
+}
+
+// Restore returns a new Todo taken back out of the trash (deleted date cleared)
+// Priority is untouched, so the todo lands back in its original quadrant
+func (t Todo) Restore() Todo {
+	t.deletedDate = nil
+	return t
+}
+
+// DeletedBefore returns true if the todo was moved to the trash before the cutoff
+// Todos that are not deleted (or have no deleted date) are never considered expired
+func (t Todo) DeletedBefore(cutoff time.Time) bool {
+	return t.deletedDate != nil && t.deletedDate.Before(cutoff)
+}
+
 // String converts the Priority to its string representation
 func (p Priority) String() string {
 	switch p {
@@ -417,6 +537,11 @@ func (t Todo) String() string {
 		result += " prioritised:" + t.prioritisedDate.Format("2006-01-02")
 	}
 
+	// Add deleted date (only present on todos in the trash)
+	if t.deletedDate != nil {
+		result += " deleted:" + t.deletedDate.Format("2006-01-02")
+	}
+
 	result += "\n"
 	return result
 }
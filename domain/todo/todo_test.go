@@ -2,6 +2,7 @@ package todo_test
 
 import (
 	"testing"
+	"time"
 
 	"github.com/matryer/is"
 	"github.com/quii/todo-eisenhower/domain/todo"
@@ -65,3 +66,57 @@ func TestTodo(t *testing.T) {
 		is.Equal(len(item.Contexts()), 0) // expected no contexts
 	})
 }
+
+func TestTodo_MatchesTag(t *testing.T) {
+	is := is.New(t)
+	td := todo.NewWithTags("Deploy API", todo.PriorityA, []string{"WebApp"}, []string{"computer"})
+
+	is.True(td.MatchesTag("+WebApp"))
+	is.True(td.MatchesTag("+webapp")) // case-insensitive
+	is.True(td.MatchesTag("@computer"))
+	is.True(!td.MatchesTag("@WebApp")) // projects are not contexts
+	is.True(!td.MatchesTag("+Other"))
+	is.True(!td.MatchesTag("+"))      // too short to be a tag
+	is.True(!td.MatchesTag("WebApp")) // prefix required
+}
+
+func TestTodo_MatchesText(t *testing.T) {
+	is := is.New(t)
+	td := todo.NewWithTags("Deploy API", todo.PriorityA, []string{"WebApp"}, []string{"computer"})
+
+	is.True(td.MatchesText("deploy"))
+	is.True(td.MatchesText("api"))
+	is.True(td.MatchesText("webapp")) // tags are searched too
+	is.True(!td.MatchesText("database"))
+}
+
+func TestTodo_Source(t *testing.T) {
+	is := is.New(t)
+	td := todo.New("Deploy API", todo.PriorityB).WithSource("work")
+
+	is.Equal(td.Source(), "work")
+	is.True(td.MatchesSource("Work")) // case-insensitive
+	is.True(!td.MatchesSource("personal"))
+	is.True(!todo.New("Loose", todo.PriorityA).MatchesSource(""))
+
+	// Changes keep the todo in its workspace file
+	is.Equal(td.ToggleCompletion(time.Now()).Source(), "work")
+	is.Equal(td.ChangePriority(todo.PriorityA).Source(), "work")
+	is.Equal(td.String(), "(B) Deploy API\n") // the source is not part of the todo.txt line
+}
+
+func TestTodo_IsOverdue(t *testing.T) {
+	is := is.New(t)
+	now := time.Date(2026, 1, 20, 18, 0, 0, 0, time.UTC)
+	yesterday := time.Date(2026, 1, 19, 0, 0, 0, 0, time.UTC)
+	today := time.Date(2026, 1, 20, 0, 0, 0, 0, time.UTC)
+
+	due := func(date time.Time) todo.Todo {
+		return todo.NewFull("Ship it", todo.PriorityA, false, nil, nil, &date, nil, nil, nil)
+	}
+
+	is.True(due(yesterday).IsOverdue(now))
+	is.True(!due(today).IsOverdue(now))                          // due today isn't late yet
+	is.True(!todo.New("Ship it", todo.PriorityA).IsOverdue(now)) // no due date
+	is.True(!due(yesterday).ToggleCompletion(now).IsOverdue(now))
+}
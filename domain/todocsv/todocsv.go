@@ -0,0 +1,347 @@
+// Package todocsv provides encoding and decoding of todos as CSV, for spreadsheets.
+// It follows the convention of encoding packages like encoding/json, as todotxt does.
+//
+// Columns hold todo fields, named by Field. Without a Mapping, columns are written and read using
+// the field names as headers; a Mapping gives columns other headers, such as "Title=description".
+package todocsv
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/quii/todo-eisenhower/domain/matrix"
+	"github.com/quii/todo-eisenhower/domain/todo"
+	"github.com/quii/todo-eisenhower/domain/todotxt"
+)
+
+// Field is a todo field a column can hold
+type Field string
+
+// Fields a column can hold
+// Created and CompletedOn are only written: imported todos are created on the day they are imported.
+const (
+	Description Field = "description"
+	Quadrant    Field = "quadrant"  // e.g. "do-first"
+	Priority    Field = "priority"  // A to E
+	Urgent      Field = "urgent"    // yes or no
+	Important   Field = "important" // yes or no
+	Due         Field = "due"
+	Projects    Field = "projects" // space or comma separated
+	Contexts    Field = "contexts"
+	Completed   Field = "completed" // yes or no
+	Created     Field = "created"
+	CompletedOn Field = "completed_on"
+)
+
+// defaultFields are the columns written without a mapping, in order
+var defaultFields = []Field{
+	Description, Quadrant, Priority, Urgent, Important, Due, Projects, Contexts, Completed, Created, CompletedOn,
+}
+
+// Column is a CSV column and the field it holds
+type Column struct {
+	Header string
+	Field  Field
+}
+
+// Mapping lists the columns to write or read, in order; an empty Mapping uses the field names
+type Mapping []Column
+
+// ParseMapping reads a mapping such as "Title=description,Urgent=urgent,Important=important"
+func ParseMapping(spec string) (Mapping, error) {
+	var mapping Mapping
+	for _, pair := range strings.Split(spec, ",") {
+		if strings.TrimSpace(pair) == "" {
+			continue
+		}
+		header, name, ok := strings.Cut(pair, "=")
+		header, name = strings.TrimSpace(header), strings.TrimSpace(name)
+		if !ok || header == "" || name == "" {
+			return nil, fmt.Errorf("invalid mapping %q: expected COLUMN=field", pair)
+		}
+		field, ok := parseField(name)
+		if !ok {
+			return nil, fmt.Errorf("unknown field %q in mapping (expected one of %s)", name, fieldNames())
+		}
+		mapping = append(mapping, Column{Header: header, Field: field})
+	}
+	if len(mapping) == 0 {
+		return nil, errors.New("empty mapping")
+	}
+	return mapping, nil
+}
+
+func parseField(name string) (Field, bool) {
+	normalised := Field(strings.ReplaceAll(strings.ToLower(strings.TrimSpace(name)), " ", "_"))
+	for _, field := range defaultFields {
+		if field == normalised {
+			return field, true
+		}
+	}
+	return "", false
+}
+
+func fieldNames() string {
+	names := make([]string, len(defaultFields))
+	for i, field := range defaultFields {
+		names[i] = string(field)
+	}
+	return strings.Join(names, ", ")
+}
+
+// columns returns the mapping, or a column per field when it is empty
+func (m Mapping) columns() Mapping {
+	if len(m) > 0 {
+		return m
+	}
+	columns := make(Mapping, len(defaultFields))
+	for i, field := range defaultFields {
+		columns[i] = Column{Header: string(field), Field: field}
+	}
+	return columns
+}
+
+// Marshal writes todos as CSV with a header row
+func Marshal(w io.Writer, todos []todo.Todo, mapping Mapping) error {
+	columns := mapping.columns()
+	writer := csv.NewWriter(w)
+
+	header := make([]string, len(columns))
+	for i, column := range columns {
+		header[i] = column.Header
+	}
+	if err := writer.Write(header); err != nil {
+		return err
+	}
+
+	for _, t := range todos {
+		row := make([]string, len(columns))
+		for i, column := range columns {
+			row[i] = value(t, column.Field)
+		}
+		if err := writer.Write(row); err != nil {
+			return err
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
+
+// value is a todo's field as written in a cell
+func value(t todo.Todo, field Field) string {
+	quadrant := matrix.QuadrantFor(t.Priority())
+	switch field {
+	case Description:
+		return t.Description()
+	case Quadrant:
+		return quadrant.String()
+	case Priority:
+		return t.Priority().String()
+	case Urgent:
+		return yesNo(quadrant == matrix.DoFirstQuadrant || quadrant == matrix.DelegateQuadrant)
+	case Important:
+		return yesNo(quadrant == matrix.DoFirstQuadrant || quadrant == matrix.ScheduleQuadrant)
+	case Due:
+		return formatDate(t.DueDate())
+	case Projects:
+		return strings.Join(t.Projects(), " ")
+	case Contexts:
+		return strings.Join(t.Contexts(), " ")
+	case Completed:
+		return yesNo(t.IsCompleted())
+	case Created:
+		return formatDate(t.CreationDate())
+	case CompletedOn:
+		return formatDate(t.CompletionDate())
+	default:
+		return ""
+	}
+}
+
+func yesNo(b bool) string {
+	if b {
+		return "yes"
+	}
+	return "no"
+}
+
+func formatDate(date *time.Time) string {
+	if date == nil {
+		return ""
+	}
+	return date.Format(todotxt.DateFormat)
+}
+
+// Unmarshal reads todos from CSV with a header row.
+// This is the inverse operation of Marshal. Each row becomes a todo as if it had been typed into the
+// matrix on the day now: its creation date is now, and Do First todos are prioritised now.
+// Columns that aren't in the mapping are ignored, as are rows without a description.
+//
+// The quadrant comes from the priority column, or else the quadrant column, or else the urgent and
+// important columns: urgent and important is Do First, important is Schedule, urgent is Delegate
+// and neither is Eliminate. Without any of them todos have no priority.
+func Unmarshal(r io.Reader, mapping Mapping, now time.Time) ([]todo.Todo, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1 // spreadsheets often leave trailing cells out
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	index, err := columnIndex(header, mapping)
+	if err != nil {
+		return nil, err
+	}
+
+	var todos []todo.Todo
+	for row := 2; ; row++ {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return todos, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		cell := func(field Field) string {
+			if i, ok := index[field]; ok && i < len(record) {
+				return strings.TrimSpace(record[i])
+			}
+			return ""
+		}
+
+		t, ok, err := rowTodo(cell, index, now)
+		if err != nil {
+			return nil, fmt.Errorf("row %d: %w", row, err)
+		}
+		if ok {
+			todos = append(todos, t)
+		}
+	}
+}
+
+// columnIndex finds the column each field is read from
+func columnIndex(header []string, mapping Mapping) (map[Field]int, error) {
+	positions := make(map[string]int, len(header))
+	// Excel starts UTF-8 CSV files with a byte order mark
+	for i, name := range header {
+		positions[strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\uFEFF")))] = i
+	}
+
+	index := make(map[Field]int)
+	if len(mapping) == 0 {
+		for name, i := range positions {
+			if field, ok := parseField(name); ok {
+				index[field] = i
+			}
+		}
+	} else {
+		for _, column := range mapping {
+			i, ok := positions[strings.ToLower(column.Header)]
+			if !ok {
+				return nil, fmt.Errorf("no %q column in the header", column.Header)
+			}
+			index[column.Field] = i
+		}
+	}
+
+	if _, ok := index[Description]; !ok {
+		return nil, errors.New("no description column: name one with --map, e.g. Title=description")
+	}
+	return index, nil
+}
+
+// rowTodo converts a row into a todo; rows without a description are skipped
+func rowTodo(cell func(Field) string, index map[Field]int, now time.Time) (todo.Todo, bool, error) {
+	description := strings.Join(strings.Fields(cell(Description)), " ")
+	if description == "" {
+		return todo.Todo{}, false, nil
+	}
+
+	priority, err := rowPriority(cell, index)
+	if err != nil {
+		return todo.Todo{}, false, err
+	}
+
+	text := description + tags("+", cell(Projects)) + tags("@", cell(Contexts))
+	if due := cell(Due); due != "" {
+		text += " due:" + due
+	}
+	text = todotxt.ExpandDateShortcuts(text, now)
+
+	t := todotxt.ParseNew(text, priority, now)
+	if isYes(cell(Completed)) {
+		t = t.ToggleCompletion(now)
+	}
+	return t, true, nil
+}
+
+// rowPriority works out a row's priority from whichever of its priority columns are present
+func rowPriority(cell func(Field) string, index map[Field]int) (todo.Priority, error) {
+	if value := cell(Priority); value != "" {
+		for _, p := range []todo.Priority{todo.PriorityA, todo.PriorityB, todo.PriorityC, todo.PriorityD, todo.PriorityE} {
+			if strings.EqualFold(strings.Trim(value, "()"), p.String()) {
+				return p, nil
+			}
+		}
+		return todo.PriorityNone, fmt.Errorf("invalid priority %q (expected A to E)", value)
+	}
+
+	if value := cell(Quadrant); value != "" {
+		quadrant, ok := matrix.ParseQuadrant(value)
+		if !ok {
+			return todo.PriorityNone, fmt.Errorf("unknown quadrant %q", value)
+		}
+		return matrix.PriorityFor(quadrant), nil
+	}
+
+	_, hasUrgent := index[Urgent]
+	_, hasImportant := index[Important]
+	if !hasUrgent && !hasImportant {
+		return todo.PriorityNone, nil
+	}
+
+	urgent, important := isYes(cell(Urgent)), isYes(cell(Important))
+	switch {
+	case urgent && important:
+		return todo.PriorityA, nil
+	case important:
+		return todo.PriorityB, nil
+	case urgent:
+		return todo.PriorityC, nil
+	default:
+		return todo.PriorityD, nil
+	}
+}
+
+// tags turns a cell such as "ops, product" or "+ops +product" into " +ops +product"
+func tags(prefix, cell string) string {
+	var result strings.Builder
+	for _, name := range strings.FieldsFunc(cell, func(r rune) bool { return r == ',' || r == ' ' || r == ';' }) {
+		name = strings.TrimLeft(name, "+@")
+		if name != "" {
+			result.WriteString(" " + prefix + name)
+		}
+	}
+	return result.String()
+}
+
+// isYes reads a spreadsheet's idea of true: yes, y, true, 1, x or a tick
+func isYes(value string) bool {
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "yes", "y", "true", "1", "x", "✓", "✔":
+		return true
+	default:
+		return false
+	}
+}
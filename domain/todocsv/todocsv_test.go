@@ -0,0 +1,100 @@
+package todocsv_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/matryer/is"
+	"github.com/quii/todo-eisenhower/domain/todo"
+	"github.com/quii/todo-eisenhower/domain/todocsv"
+	"github.com/quii/todo-eisenhower/domain/todotxt"
+)
+
+// now is Tuesday 2026-01-20
+var now = time.Date(2026, 1, 20, 9, 0, 0, 0, time.UTC)
+
+func TestMarshal(t *testing.T) {
+	todos, err := todotxt.Unmarshal(strings.NewReader(
+		"(A) 2026-01-02 Fix prod, again +ops @laptop due:2026-01-21\n" +
+			"x 2026-01-19 2026-01-10 (C) Book venue +offsite\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("writes every field by default", func(t *testing.T) {
+		//nolint:gocritic // importShadow: is := is.New(t) is idiomatic for github.com/matryer/is
+		is := is.New(t)
+		var buf bytes.Buffer
+		is.NoErr(todocsv.Marshal(&buf, todos, nil))
+
+		is.Equal(buf.String(), "description,quadrant,priority,urgent,important,due,projects,contexts,completed,created,completed_on\n"+
+			`"Fix prod, again",do-first,A,yes,yes,2026-01-21,ops,laptop,no,2026-01-02,`+"\n"+
+			"Book venue,delegate,C,yes,no,,offsite,,yes,2026-01-10,2026-01-19\n")
+	})
+
+	t.Run("writes the mapped columns", func(t *testing.T) {
+		is := is.New(t)
+		mapping, err := todocsv.ParseMapping("Title=description, Urgent=urgent, Important=important")
+		is.NoErr(err)
+
+		var buf bytes.Buffer
+		is.NoErr(todocsv.Marshal(&buf, todos, mapping))
+		is.Equal(buf.String(), "Title,Urgent,Important\n\"Fix prod, again\",yes,yes\nBook venue,yes,no\n")
+	})
+}
+
+func TestUnmarshal(t *testing.T) {
+	t.Run("turns urgent and important into quadrants", func(t *testing.T) {
+		is := is.New(t)
+		mapping, err := todocsv.ParseMapping("Title=description,Urgent?=urgent,Important?=important,Tags=projects,Due Date=due")
+		is.NoErr(err)
+
+		sheet := "Title,Owner,Urgent?,Important?,Tags,Due Date\n" +
+			"Fix prod,Sam,Y,Y,ops,tomorrow\n" +
+			"Plan roadmap,Sam,,yes,\"ops, product\",\n" +
+			"Answer email,Ana,x,,,\n" +
+			"Reorganise wiki,Ana,no,no,,\n" +
+			",,,,,\n"
+		todos, err := todocsv.Unmarshal(strings.NewReader(sheet), mapping, now)
+		is.NoErr(err)
+		is.Equal(len(todos), 4) // the blank row is skipped
+
+		is.Equal(todos[0].String(), "(A) 2026-01-20 Fix prod +ops due:2026-01-21 prioritised:2026-01-20\n")
+		is.Equal(todos[1].String(), "(B) 2026-01-20 Plan roadmap +ops +product\n")
+		is.Equal(todos[2].Priority(), todo.PriorityC)
+		is.Equal(todos[3].Priority(), todo.PriorityD)
+	})
+
+	t.Run("reads back what Marshal wrote", func(t *testing.T) {
+		is := is.New(t)
+		original, err := todotxt.Unmarshal(strings.NewReader("(E) 2026-01-02 Learn Rust +growth @evenings due:2026-06-01\n"))
+		is.NoErr(err)
+
+		var buf bytes.Buffer
+		is.NoErr(todocsv.Marshal(&buf, original, nil))
+		todos, err := todocsv.Unmarshal(&buf, nil, now)
+		is.NoErr(err)
+
+		is.Equal(todos[0].String(), "(E) 2026-01-20 Learn Rust +growth @evenings due:2026-06-01\n")
+	})
+
+	t.Run("reports problems", func(t *testing.T) {
+		is := is.New(t)
+
+		_, err := todocsv.Unmarshal(strings.NewReader("Title\nFix prod\n"), nil, now)
+		is.True(strings.Contains(err.Error(), "no description column"))
+
+		mapping, err := todocsv.ParseMapping("Task=description")
+		is.NoErr(err)
+		_, err = todocsv.Unmarshal(strings.NewReader("Title\nFix prod\n"), mapping, now)
+		is.Equal(err.Error(), `no "Task" column in the header`)
+
+		_, err = todocsv.Unmarshal(strings.NewReader("description,priority\nFix prod,Z\n"), nil, now)
+		is.Equal(err.Error(), `row 2: invalid priority "Z" (expected A to E)`)
+
+		_, err = todocsv.ParseMapping("Title=summary")
+		is.True(strings.Contains(err.Error(), `unknown field "summary"`))
+	})
+}
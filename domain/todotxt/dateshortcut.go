@@ -1,4 +1,4 @@
-package ui
+package todotxt
 
 import (
 	"fmt"
@@ -8,9 +8,9 @@ import (
 	"time"
 )
 
-// parseDateShortcut converts date shortcuts to YYYY-MM-DD format
+// ParseDateShortcut converts date shortcuts to YYYY-MM-DD format
 // Supports: today, tomorrow, +3d, +2w, friday, jan25, 2026-01-20, etc.
-func parseDateShortcut(shortcut string, now time.Time) (string, error) {
+func ParseDateShortcut(shortcut string, now time.Time) (string, error) {
 	if shortcut == "" {
 		return "", fmt.Errorf("empty shortcut")
 	}
@@ -177,8 +177,8 @@ func endOfNextQuarter(from time.Time) time.Time {
 	return time.Date(endYear, endMonth+1, 0, 0, 0, 0, 0, from.Location())
 }
 
-// expandDateShortcuts finds due:shortcut patterns and expands them to due:YYYY-MM-DD
-func expandDateShortcuts(input string, now time.Time) string {
+// ExpandDateShortcuts finds due:shortcut patterns and expands them to due:YYYY-MM-DD
+func ExpandDateShortcuts(input string, now time.Time) string {
 	// Pattern to match due:shortcut (stops at space or end of string)
 	pattern := regexp.MustCompile(`due:(\S+)`)
 
@@ -189,7 +189,7 @@ func expandDateShortcuts(input string, now time.Time) string {
 	}
 
 	shortcut := match[1]
-	expanded, err := parseDateShortcut(shortcut, now)
+	expanded, err := ParseDateShortcut(shortcut, now)
 	if err != nil {
 		// If parsing fails, leave as-is
 		return input
@@ -1,10 +1,11 @@
-package ui
+package todotxt_test
 
 import (
 	"testing"
 	"time"
 
 	"github.com/matryer/is"
+	"github.com/quii/todo-eisenhower/domain/todotxt"
 )
 
 func TestParseDateShortcut(t *testing.T) {
@@ -189,7 +190,7 @@ func TestParseDateShortcut(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			is := is.New(t)
-			result, err := parseDateShortcut(tt.shortcut, refTime)
+			result, err := todotxt.ParseDateShortcut(tt.shortcut, refTime)
 
 			if tt.wantErr {
 				is.True(err != nil)
@@ -374,7 +375,7 @@ func TestEndOfPeriodShortcuts(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			is := is.New(t)
-			result, err := parseDateShortcut(tt.shortcut, tt.refDate)
+			result, err := todotxt.ParseDateShortcut(tt.shortcut, tt.refDate)
 			is.NoErr(err)
 			is.Equal(result, tt.expected)
 		})
@@ -432,7 +433,7 @@ func TestExpandDateShortcuts(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			is := is.New(t)
-			result := expandDateShortcuts(tt.input, refTime)
+			result := todotxt.ExpandDateShortcuts(tt.input, refTime)
 			is.Equal(result, tt.expected)
 		})
 	}
@@ -32,6 +32,7 @@ var (
 	contextPattern        = regexp.MustCompile(`@(\w+)`)
 	dueDatePattern        = regexp.MustCompile(`(?i)due:(\d{4}-\d{2}-\d{2})`)
 	prioritisedDatePattern = regexp.MustCompile(`(?i)prioritised:(\d{4}-\d{2}-\d{2})`)
+	deletedDatePattern     = regexp.MustCompile(`(?i)deleted:(\d{4}-\d{2}-\d{2})`)
 )
 
 // Unmarshal reads todo.txt format from an io.Reader and returns a slice of Todos.
@@ -137,17 +138,31 @@ func parseLine(line string) todo.Todo {
 		}
 	}
 
-	// Remove tags, due date, and prioritised date from description now that they're extracted
+	// Extract deleted date (only present on lines in the trash file)
+	var deletedDate *time.Time
+	if deletedDatePattern.MatchString(description) {
+		matches := deletedDatePattern.FindStringSubmatch(description)
+		if len(matches) > 1 {
+			deletedDate = parseDate(matches[1])
+		}
+	}
+
+	// Remove tags, due date, prioritised date, and deleted date from description now that they're extracted
 	description = projectPattern.ReplaceAllString(description, "")
 	description = contextPattern.ReplaceAllString(description, "")
 	description = dueDatePattern.ReplaceAllString(description, "")
 	description = prioritisedDatePattern.ReplaceAllString(description, "")
+	description = deletedDatePattern.ReplaceAllString(description, "")
 	// Clean up extra whitespace
 	description = strings.Join(strings.Fields(description), " ")
 	description = strings.TrimSpace(description)
 
 	// Use the comprehensive constructor with all extracted fields
-	return todo.NewFull(description, priority, completed, completionDate, creationDate, dueDate, prioritisedDate, projects, contexts)
+	t := todo.NewFull(description, priority, completed, completionDate, creationDate, dueDate, prioritisedDate, projects, contexts)
+	if deletedDate != nil {
+		t = t.MarkDeleted(*deletedDate)
+	}
+	return t
 }
 
 // extractTags extracts all matching tags using the given pattern
@@ -246,8 +261,19 @@ func ParseEdit(original todo.Todo, newDescription string, priority todo.Priority
 	prioritisedDate := original.PrioritisedDate()
 	isCompleted := original.IsCompleted()
 
-	// Use comprehensive constructor with all fields
-	return todo.NewFull(cleanDesc, priority, isCompleted, completionDate, creationDate, dueDate, prioritisedDate, projects, contexts)
+	// Use comprehensive constructor with all fields, keeping the todo in its workspace file
+	return todo.NewFull(cleanDesc, priority, isCompleted, completionDate, creationDate, dueDate, prioritisedDate, projects, contexts).
+		WithSource(original.Source())
+}
+
+// SplitPriority removes a leading priority marker such as "(A) " from user input.
+// ok is false when the input doesn't start with one, in which case it is returned unchanged.
+func SplitPriority(input string) (priority todo.Priority, rest string, ok bool) {
+	matches := priorityPattern.FindStringSubmatch(input)
+	if matches == nil {
+		return todo.PriorityNone, input, false
+	}
+	return parsePriority(matches[1]), input[len(matches[0]):], true
 }
 
 // FormatForInput formats a todo for user input by combining description, tags, and due date.
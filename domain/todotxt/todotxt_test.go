@@ -743,3 +743,59 @@ func assertTodo(is *is.I, got todo.Todo, wantDesc string, wantPriority todo.Prio
 	is.Equal(got.Priority(), wantPriority) // priority
 	is.Equal(got.IsCompleted(), wantCompleted) // completed
 }
+
+func TestParse_DeletedDates(t *testing.T) {
+	t.Run("parses deleted date and removes it from description", func(t *testing.T) {
+		is := is.New(t)
+		input := "(B) Old idea +project deleted:2026-01-20\n"
+
+		todos, err := todotxt.Unmarshal(strings.NewReader(input))
+		is.NoErr(err)
+
+		is.Equal(todos[0].Description(), "Old idea")
+		is.True(todos[0].DeletedDate() != nil)
+		is.Equal(todos[0].DeletedDate().Format("2006-01-02"), "2026-01-20")
+	})
+
+	t.Run("round-trip preserves deleted date", func(t *testing.T) {
+		is := is.New(t)
+		original := "(C) Task +project @context deleted:2026-01-20\n"
+
+		todos, err := todotxt.Unmarshal(strings.NewReader(original))
+		is.NoErr(err)
+
+		is.Equal(todos[0].String(), original)
+	})
+
+	t.Run("todos outside the trash have nil DeletedDate", func(t *testing.T) {
+		is := is.New(t)
+
+		todos, err := todotxt.Unmarshal(strings.NewReader("(A) Active task\n"))
+		is.NoErr(err)
+
+		is.True(todos[0].DeletedDate() == nil)
+	})
+}
+
+func TestParseEdit_KeepsSource(t *testing.T) {
+	is := is.New(t)
+	original := todo.New("Deploy API", todo.PriorityA).WithSource("work")
+
+	edited := todotxt.ParseEdit(original, "Deploy API v2 +ops", todo.PriorityA)
+
+	is.Equal(edited.Source(), "work")
+}
+
+func TestSplitPriority(t *testing.T) {
+	is := is.New(t)
+
+	priority, rest, ok := todotxt.SplitPriority("(B) Plan roadmap +work")
+	is.True(ok)
+	is.Equal(priority, todo.PriorityB)
+	is.Equal(rest, "Plan roadmap +work")
+
+	priority, rest, ok = todotxt.SplitPriority("Plan roadmap (B)")
+	is.True(!ok)
+	is.Equal(priority, todo.PriorityNone)
+	is.Equal(rest, "Plan roadmap (B)")
+}
@@ -19,5 +19,9 @@ func AddTodo(repo TodoRepository, m matrix.Matrix, description string, priority
 		return m, err
 	}
 
+	if err := recordEvents(repo, addEvent(ActionAdd, newTodo)); err != nil {
+		return updatedMatrix, err
+	}
+
 	return updatedMatrix, nil
 }
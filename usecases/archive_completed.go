@@ -33,5 +33,13 @@ func persistArchivedTodos(repo TodoRepository, original, updated matrix.Matrix,
 		return original, err
 	}
 
+	events := make([]Event, 0, len(archived))
+	for _, t := range archived {
+		events = append(events, removeEvent(ActionArchive, t))
+	}
+	if err := recordEvents(repo, events...); err != nil {
+		return updated, err
+	}
+
 	return updated, nil
 }
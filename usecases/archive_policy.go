@@ -0,0 +1,59 @@
+package usecases
+
+import (
+	"errors"
+	"time"
+
+	"github.com/quii/todo-eisenhower/domain/matrix"
+	"github.com/quii/todo-eisenhower/domain/todo"
+)
+
+// ArchivePolicy describes when completed todos are archived automatically
+type ArchivePolicy struct {
+	// OnStartup archives completed todos when the app starts
+	OnStartup bool
+	// OlderThanDays limits startup archiving to todos completed more than this many days ago (0 archives all completed)
+	OlderThanDays int
+	// OnQuit archives all completed todos when the app exits
+	OnQuit bool
+}
+
+// ApplyStartupArchivePolicy archives completed todos according to the policy when the app starts.
+// Returns the updated matrix and how many todos were archived.
+func ApplyStartupArchivePolicy(repo TodoRepository, m matrix.Matrix, policy ArchivePolicy, now time.Time) (matrix.Matrix, int, error) {
+	if !policy.OnStartup {
+		return m, 0, nil
+	}
+
+	var archived []todo.Todo
+	var updatedMatrix matrix.Matrix
+	if policy.OlderThanDays > 0 {
+		archived, updatedMatrix = m.ArchiveCompletedBefore(now.AddDate(0, 0, -policy.OlderThanDays))
+	} else {
+		archived, updatedMatrix = m.ArchiveAllCompleted()
+	}
+
+	updatedMatrix, err := persistArchivedTodos(repo, m, updatedMatrix, archived)
+	if err != nil && !errors.Is(err, ErrJournal) {
+		return m, 0, err
+	}
+
+	return updatedMatrix, len(archived), err
+}
+
+// ApplyQuitArchivePolicy archives all completed todos according to the policy when the app exits.
+// Returns the updated matrix and how many todos were archived.
+func ApplyQuitArchivePolicy(repo TodoRepository, m matrix.Matrix, policy ArchivePolicy) (matrix.Matrix, int, error) {
+	if !policy.OnQuit {
+		return m, 0, nil
+	}
+
+	archived, updatedMatrix := m.ArchiveAllCompleted()
+
+	updatedMatrix, err := persistArchivedTodos(repo, m, updatedMatrix, archived)
+	if err != nil && !errors.Is(err, ErrJournal) {
+		return m, 0, err
+	}
+
+	return updatedMatrix, len(archived), err
+}
@@ -0,0 +1,120 @@
+package usecases_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/matryer/is"
+	"github.com/quii/todo-eisenhower/adapters/memory"
+	"github.com/quii/todo-eisenhower/domain/todo"
+	"github.com/quii/todo-eisenhower/usecases"
+)
+
+func TestApplyStartupArchivePolicy(t *testing.T) {
+	now := time.Date(2026, 3, 20, 9, 0, 0, 0, time.UTC)
+
+	newRepo := func(is *is.I) *memory.Repository {
+		repo := memory.NewRepository()
+		err := repo.SaveAll([]todo.Todo{
+			todo.New("Active", todo.PriorityA),
+			todo.New("Done long ago", todo.PriorityA).ToggleCompletion(now.AddDate(0, 0, -30)),
+			todo.New("Done yesterday", todo.PriorityB).ToggleCompletion(now.AddDate(0, 0, -1)),
+		})
+		is.NoErr(err)
+		return repo
+	}
+
+	t.Run("archives completed todos older than the configured days", func(t *testing.T) {
+		is := is.New(t)
+		repo := newRepo(is)
+
+		m, err := usecases.LoadMatrix(repo)
+		is.NoErr(err)
+
+		policy := usecases.ArchivePolicy{OnStartup: true, OlderThanDays: 14}
+		updatedMatrix, count, err := usecases.ApplyStartupArchivePolicy(repo, m, policy, now)
+		is.NoErr(err)
+
+		is.Equal(count, 1)
+		is.Equal(len(updatedMatrix.DoFirst()), 1)
+		is.Equal(len(updatedMatrix.Schedule()), 1)
+		is.True(strings.Contains(repo.ArchiveString(), "Done long ago"))
+		is.True(!strings.Contains(repo.ArchiveString(), "Done yesterday"))
+
+		reloaded, err := usecases.LoadMatrix(repo)
+		is.NoErr(err)
+		is.Equal(len(reloaded.AllTodos()), 2)
+	})
+
+	t.Run("archives all completed todos when no age is configured", func(t *testing.T) {
+		is := is.New(t)
+		repo := newRepo(is)
+
+		m, err := usecases.LoadMatrix(repo)
+		is.NoErr(err)
+
+		policy := usecases.ArchivePolicy{OnStartup: true}
+		updatedMatrix, count, err := usecases.ApplyStartupArchivePolicy(repo, m, policy, now)
+		is.NoErr(err)
+
+		is.Equal(count, 2)
+		is.Equal(len(updatedMatrix.AllTodos()), 1)
+	})
+
+	t.Run("does nothing when startup archiving is disabled", func(t *testing.T) {
+		is := is.New(t)
+		repo := newRepo(is)
+
+		m, err := usecases.LoadMatrix(repo)
+		is.NoErr(err)
+
+		policy := usecases.ArchivePolicy{OnQuit: true}
+		updatedMatrix, count, err := usecases.ApplyStartupArchivePolicy(repo, m, policy, now)
+		is.NoErr(err)
+
+		is.Equal(count, 0)
+		is.Equal(len(updatedMatrix.AllTodos()), 3)
+		is.Equal(repo.ArchiveString(), "")
+	})
+}
+
+func TestApplyQuitArchivePolicy(t *testing.T) {
+	t.Run("archives all completed todos on quit", func(t *testing.T) {
+		is := is.New(t)
+		repo := memory.NewRepository()
+
+		err := repo.SaveAll([]todo.Todo{
+			todo.New("Active", todo.PriorityA),
+			todo.New("Done", todo.PriorityB).ToggleCompletion(time.Now()),
+		})
+		is.NoErr(err)
+
+		m, err := usecases.LoadMatrix(repo)
+		is.NoErr(err)
+
+		updatedMatrix, count, err := usecases.ApplyQuitArchivePolicy(repo, m, usecases.ArchivePolicy{OnQuit: true})
+		is.NoErr(err)
+
+		is.Equal(count, 1)
+		is.Equal(len(updatedMatrix.AllTodos()), 1)
+		is.True(strings.Contains(repo.ArchiveString(), "Done"))
+	})
+
+	t.Run("does nothing when quit archiving is disabled", func(t *testing.T) {
+		is := is.New(t)
+		repo := memory.NewRepository()
+
+		err := repo.SaveAll([]todo.Todo{
+			todo.New("Done", todo.PriorityB).ToggleCompletion(time.Now()),
+		})
+		is.NoErr(err)
+
+		m, err := usecases.LoadMatrix(repo)
+		is.NoErr(err)
+
+		_, count, err := usecases.ApplyQuitArchivePolicy(repo, m, usecases.ArchivePolicy{})
+		is.NoErr(err)
+		is.Equal(count, 0)
+	})
+}
@@ -24,5 +24,9 @@ func ArchiveTodo(repo TodoRepository, m matrix.Matrix, quadrant matrix.QuadrantT
 		return m, err
 	}
 
+	if err := recordEvents(repo, removeEvent(ActionArchive, archivedTodo)); err != nil {
+		return updatedMatrix, err
+	}
+
 	return updatedMatrix, nil
 }
@@ -37,6 +37,12 @@ func ChangePriority(repo TodoRepository, m matrix.Matrix, quadrant matrix.Quadra
 		return m, err
 	}
 
+	// The moved todo is always appended to its new quadrant
+	moved := updatedMatrix.GetTodosForQuadrant(matrix.QuadrantFor(newPriority))
+	if err := recordEvents(repo, changeEvent(ActionMove, selectedTodo, moved[len(moved)-1])); err != nil {
+		return updatedMatrix, err
+	}
+
 	return updatedMatrix, nil
 }
 
@@ -74,7 +80,7 @@ func updatePrioritisedDate(m matrix.Matrix, originalTodo todo.Todo, oldPriority,
 				prioritisedDate,
 				t.Projects(),
 				t.Contexts(),
-			)
+			).WithSource(t.Source())
 			updatedTodos = append(updatedTodos, updatedTodo)
 		} else {
 			updatedTodos = append(updatedTodos, t)
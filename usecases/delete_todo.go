@@ -1,18 +1,31 @@
 package usecases
 
 import (
+	"time"
+
 	"github.com/quii/todo-eisenhower/domain/matrix"
 	"github.com/quii/todo-eisenhower/domain/todo"
 )
 
-// DeleteTodo removes a todo from the matrix and persists the change
+// DeleteTodo removes a todo from the matrix and moves it to the trash
+// The todo is stamped with today's date so the trash can be purged by age later
 func DeleteTodo(repo TodoRepository, m matrix.Matrix, todoToDelete todo.Todo) (matrix.Matrix, error) {
 	updatedMatrix := m.RemoveTodo(todoToDelete)
 
-	err := saveAllTodos(repo, updatedMatrix)
+	// Move to trash first so a failed write never loses the todo
+	err := repo.AppendToTrash(todoToDelete.MarkDeleted(time.Now()))
 	if err != nil {
 		return m, err
 	}
 
+	err = saveAllTodos(repo, updatedMatrix)
+	if err != nil {
+		return m, err
+	}
+
+	if err := recordEvents(repo, removeEvent(ActionDelete, todoToDelete)); err != nil {
+		return updatedMatrix, err
+	}
+
 	return updatedMatrix, nil
 }
@@ -6,6 +6,13 @@ import (
 
 // EditTodo updates a todo at the specified position with new description and tags
 func EditTodo(repo TodoRepository, m matrix.Matrix, quadrant matrix.QuadrantType, index int, newDescription string) (matrix.Matrix, error) {
+	todos := m.GetTodosForQuadrant(quadrant)
+	if index < 0 || index >= len(todos) {
+		return m, nil
+	}
+	// Capture the original before editing, as the matrix updates its quadrant slice in place
+	before := todos[index]
+
 	updatedMatrix := m.EditTodo(quadrant, index, newDescription)
 
 	err := saveAllTodos(repo, updatedMatrix)
@@ -13,5 +20,10 @@ func EditTodo(repo TodoRepository, m matrix.Matrix, quadrant matrix.QuadrantType
 		return m, err
 	}
 
+	after := updatedMatrix.GetTodosForQuadrant(quadrant)[index]
+	if err := recordEvents(repo, changeEvent(ActionEdit, before, after)); err != nil {
+		return updatedMatrix, err
+	}
+
 	return updatedMatrix, nil
 }
@@ -0,0 +1,138 @@
+package usecases
+
+import (
+	"errors"
+	"net/url"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/quii/todo-eisenhower/domain/matrix"
+	"github.com/quii/todo-eisenhower/domain/todo"
+	"github.com/quii/todo-eisenhower/domain/todotxt"
+)
+
+// MailMessage is an email read from a mailbox
+type MailMessage struct {
+	ID      string // Message-ID, without the angle brackets
+	From    string // sender's address
+	Subject string
+	Flagged bool
+	Labels  []string // keywords or labels set by the mail client
+}
+
+// MailLog remembers which messages have been imported, so each is only imported once even after
+// its todo has been deleted
+type MailLog interface {
+	Imported() (map[string]bool, error) // by message ID
+	Add(ids []string) error
+}
+
+// Mailbox is what was read from a Maildir or mbox
+type Mailbox struct {
+	Messages   []MailMessage
+	Unreadable int // messages that couldn't be parsed, which were skipped
+}
+
+// MailImport counts what ImportMail did
+type MailImport struct {
+	Selected   int // flagged or labelled messages
+	Added      int // new todos
+	Unreadable int // messages in the mailbox that couldn't be read
+}
+
+// msgidTag finds a todo's message ID
+var msgidTag = regexp.MustCompile(`(?:^|\s)msgid:(\S+)`)
+
+// nonWord matches the characters that can't be part of a todo.txt tag
+var nonWord = regexp.MustCompile(`\W+`)
+
+// subjectTagPrefix matches the start of a key:value tag, such as due: or msgid:, in a subject
+// URLs aren't tags, so a colon followed by a slash is left alone.
+var subjectTagPrefix = regexp.MustCompile(`(^|\s)(\w+):([^\s/])`)
+
+// subjectEscaper swaps the characters that start +project and @context tags for their full-width forms
+var subjectEscaper = strings.NewReplacer("+", "＋", "@", "＠")
+
+// ImportMail adds a Schedule todo for each flagged message, and each message with one of labels
+// (compared without case). The todo is the message's subject, with the sender's address as a
+// context and a msgid: tag. Messages in the log, or whose todo is in the matrix or archive, are
+// skipped; the rest are added to the log. log may be nil, to only skip messages with todos.
+func ImportMail(repo TodoRepository, m matrix.Matrix, box Mailbox, labels []string, log MailLog, now time.Time) (matrix.Matrix, MailImport, error) {
+	result := MailImport{Unreadable: box.Unreadable}
+	imported := map[string]bool{}
+	if log != nil {
+		var err error
+		if imported, err = log.Imported(); err != nil {
+			return m, result, err
+		}
+	}
+
+	var todos []todo.Todo
+	var ids []string
+	for _, message := range box.Messages {
+		if !message.Flagged && !hasLabel(message.Labels, labels) {
+			continue
+		}
+		result.Selected++
+		if imported[message.ID] || slices.Contains(ids, message.ID) {
+			continue
+		}
+		ids = append(ids, message.ID)
+		todos = append(todos, mailTodo(message, now))
+	}
+
+	// The todos are saved even when the journal fails, so the messages are still logged
+	updatedMatrix, added, err := ImportTodos(repo, m, todos, messageID, now)
+	if err != nil && !errors.Is(err, ErrJournal) {
+		return m, MailImport{}, err
+	}
+	result.Added = added
+
+	if log != nil && len(ids) > 0 {
+		if logErr := log.Add(ids); logErr != nil {
+			return updatedMatrix, result, logErr
+		}
+	}
+	return updatedMatrix, result, err
+}
+
+// mailTodo is the todo for a message; its subject and ID are escaped so a +, @ or due: in them isn't
+// read as a tag
+func mailTodo(message MailMessage, now time.Time) todo.Todo {
+	subject := strings.Join(strings.Fields(message.Subject), " ")
+	if subject == "" {
+		subject = "(no subject)"
+	}
+	subject = subjectTagPrefix.ReplaceAllString(subjectEscaper.Replace(subject), "$1$2：$3")
+
+	description := subject
+	if sender := strings.Trim(nonWord.ReplaceAllString(strings.ToLower(message.From), "_"), "_"); sender != "" {
+		description += " @" + sender
+	}
+	description += " msgid:" + url.QueryEscape(message.ID)
+	return todotxt.ParseNew(description, todo.PriorityB, now)
+}
+
+// messageID is the message ID in a todo's msgid: tag, empty for todos that aren't from mail
+func messageID(t todo.Todo) string {
+	matches := msgidTag.FindStringSubmatch(t.Description())
+	if matches == nil {
+		return ""
+	}
+	id, err := url.QueryUnescape(matches[1])
+	if err != nil {
+		return matches[1]
+	}
+	return id
+}
+
+func hasLabel(have, want []string) bool {
+	for _, label := range have {
+		if slices.ContainsFunc(want, func(w string) bool { return strings.EqualFold(w, label) }) {
+			return true
+		}
+	}
+	return false
+}
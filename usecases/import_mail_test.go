@@ -0,0 +1,131 @@
+package usecases_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/matryer/is"
+	"github.com/quii/todo-eisenhower/adapters/memory"
+	"github.com/quii/todo-eisenhower/domain/todo"
+	"github.com/quii/todo-eisenhower/usecases"
+)
+
+type spyMailLog struct {
+	ids map[string]bool
+}
+
+func (l *spyMailLog) Imported() (map[string]bool, error) {
+	imported := map[string]bool{}
+	for id := range l.ids {
+		imported[id] = true
+	}
+	return imported, nil
+}
+
+func (l *spyMailLog) Add(ids []string) error {
+	for _, id := range ids {
+		l.ids[id] = true
+	}
+	return nil
+}
+
+func TestImportMail(t *testing.T) {
+	now := time.Date(2026, 1, 20, 9, 0, 0, 0, time.UTC)
+	messages := []usecases.MailMessage{
+		{ID: "a1@example.com", From: "Alice.Smith@example.com", Subject: "Contract  review", Flagged: true},
+		{ID: "b2@example.com", From: "bob@example.com", Subject: "Lunch?"},
+		{ID: "c3@example.com", From: "carol@example.com", Subject: "Invoice 42", Labels: []string{"Inbox", "ToDo"}},
+		{ID: "d4@example.com", From: "dave@example.com", Subject: "", Flagged: true},
+	}
+
+	t.Run("adds Schedule todos for flagged and labelled messages", func(t *testing.T) {
+		is := is.New(t)
+		repo := memory.NewRepository()
+		log := &spyMailLog{ids: map[string]bool{}}
+		m, err := usecases.LoadMatrix(repo)
+		is.NoErr(err)
+
+		m, result, err := usecases.ImportMail(repo, m, usecases.Mailbox{Messages: messages}, []string{"todo"}, log, now)
+		is.NoErr(err)
+		is.Equal(result, usecases.MailImport{Selected: 3, Added: 3})
+
+		schedule := m.Schedule()
+		is.Equal(len(schedule), 3)
+		is.Equal(schedule[0].Description(), "Contract review msgid:a1%40example.com")
+		is.Equal(schedule[0].Contexts(), []string{"alice_smith_example_com"})
+		is.Equal(schedule[0].Priority(), todo.PriorityB)
+		is.Equal(*schedule[0].CreationDate(), now)
+		is.Equal(schedule[1].Description(), "Invoice 42 msgid:c3%40example.com")
+		is.Equal(schedule[2].Description(), "(no subject) msgid:d4%40example.com")
+
+		is.Equal(log.ids, map[string]bool{"a1@example.com": true, "c3@example.com": true, "d4@example.com": true})
+	})
+
+	t.Run("skips messages already imported, even when their todo is gone", func(t *testing.T) {
+		is := is.New(t)
+		repo := memory.NewRepository()
+		log := &spyMailLog{ids: map[string]bool{"a1@example.com": true}}
+		is.NoErr(repo.SaveArchive([]todo.Todo{todo.NewCompleted("Invoice 42 msgid:c3%40example.com", todo.PriorityB, nil)}))
+		m, err := usecases.LoadMatrix(repo)
+		is.NoErr(err)
+
+		m, result, err := usecases.ImportMail(repo, m, usecases.Mailbox{Messages: append(messages, messages[3])}, []string{"todo"}, log, now)
+		is.NoErr(err)
+		is.Equal(result, usecases.MailImport{Selected: 4, Added: 1})
+		is.Equal(len(m.Schedule()), 1)
+
+		_, result, err = usecases.ImportMail(repo, m, usecases.Mailbox{Messages: messages}, []string{"todo"}, log, now)
+		is.NoErr(err)
+		is.Equal(result.Added, 0)
+	})
+
+	t.Run("without a log, skips messages whose todo is in the matrix", func(t *testing.T) {
+		is := is.New(t)
+		repo := memory.NewRepository()
+		m, err := usecases.LoadMatrix(repo)
+		is.NoErr(err)
+
+		m, _, err = usecases.ImportMail(repo, m, usecases.Mailbox{Messages: messages}, nil, nil, now)
+		is.NoErr(err)
+		is.Equal(len(m.Schedule()), 2)
+
+		_, result, err := usecases.ImportMail(repo, m, usecases.Mailbox{Messages: messages}, nil, nil, now)
+		is.NoErr(err)
+		is.Equal(result, usecases.MailImport{Selected: 2, Added: 0})
+	})
+
+	t.Run("doesn't read tags from the subject", func(t *testing.T) {
+		is := is.New(t)
+		repo := memory.NewRepository()
+		m, err := usecases.LoadMatrix(repo)
+		is.NoErr(err)
+		tricky := usecases.MailMessage{
+			ID: "e5@example.com", From: "eve@example.com", Flagged: true,
+			Subject: "+1 for C++ @ops msgid:other due:2026-02-01 see https://example.com",
+		}
+
+		m, _, err = usecases.ImportMail(repo, m, usecases.Mailbox{Messages: []usecases.MailMessage{tricky}}, nil, nil, now)
+		is.NoErr(err)
+
+		imported := m.Schedule()[0]
+		is.Equal(imported.Description(), "＋1 for C＋＋ ＠ops msgid：other due：2026-02-01 see https://example.com msgid:e5%40example.com")
+		is.Equal(len(imported.Projects()), 0)
+		is.Equal(imported.Contexts(), []string{"eve_example_com"})
+		is.True(imported.DueDate() == nil)
+
+		_, result, err := usecases.ImportMail(repo, m, usecases.Mailbox{Messages: []usecases.MailMessage{tricky}}, nil, nil, now)
+		is.NoErr(err)
+		is.Equal(result.Added, 0) // the todo is still found by its own message ID
+	})
+
+	t.Run("reports the messages that couldn't be read", func(t *testing.T) {
+		is := is.New(t)
+		repo := memory.NewRepository()
+		m, err := usecases.LoadMatrix(repo)
+		is.NoErr(err)
+
+		_, result, err := usecases.ImportMail(repo, m, usecases.Mailbox{Messages: messages, Unreadable: 2}, nil, nil, now)
+		is.NoErr(err)
+		is.Equal(result, usecases.MailImport{Selected: 2, Added: 2, Unreadable: 2})
+	})
+}
@@ -0,0 +1,76 @@
+package usecases
+
+import (
+	"time"
+
+	"github.com/quii/todo-eisenhower/domain/matrix"
+	"github.com/quii/todo-eisenhower/domain/todo"
+)
+
+// ImportTodos adds todos read from another format, such as a calendar, to the matrix.
+// Completed todos are history, so they go straight to the archive. Open Do First todos that arrive without
+// a prioritised date are prioritised at now, as if they had been added by hand, so they can go stale.
+// Todos that are already in the matrix or the archive are skipped, so importing the same file again
+// adds nothing: two todos are the same when key returns the same key for both.
+// It returns the updated matrix and how many todos were added to it or the archive.
+func ImportTodos(repo TodoRepository, m matrix.Matrix, imported []todo.Todo, key func(todo.Todo) string, now time.Time) (matrix.Matrix, int, error) {
+	archived, err := repo.LoadArchive()
+	if err != nil {
+		return m, 0, err
+	}
+
+	seen := make(map[string]bool)
+	for _, t := range append(m.AllTodosIncludingBacklog(), archived...) {
+		seen[key(t)] = true
+	}
+
+	updatedMatrix := m
+	var history []todo.Todo
+	var events []Event
+	for _, t := range imported {
+		if seen[key(t)] {
+			continue
+		}
+		seen[key(t)] = true
+
+		if t.IsCompleted() {
+			history = append(history, t)
+			events = append(events, Event{Action: ActionImport, Description: t.Description(), After: todoLine(t)})
+			continue
+		}
+		t = prioritisedOnImport(t, now)
+		updatedMatrix = updatedMatrix.AddTodo(t)
+		events = append(events, addEvent(ActionImport, t))
+	}
+
+	if len(events) == 0 {
+		return m, 0, nil
+	}
+
+	if len(history) < len(events) {
+		if err := saveAllTodos(repo, updatedMatrix); err != nil {
+			return m, 0, err
+		}
+	}
+
+	for _, t := range history {
+		if err := repo.AppendToArchive(t); err != nil {
+			return m, 0, err
+		}
+	}
+
+	if err := recordEvents(repo, events...); err != nil {
+		return updatedMatrix, len(events), err
+	}
+
+	return updatedMatrix, len(events), nil
+}
+
+// prioritisedOnImport gives an imported Do First todo without a prioritised date the import time
+func prioritisedOnImport(t todo.Todo, now time.Time) todo.Todo {
+	if t.Priority() != todo.PriorityA || t.PrioritisedDate() != nil {
+		return t
+	}
+	return todo.NewFull(t.Description(), t.Priority(), t.IsCompleted(), t.CompletionDate(), t.CreationDate(),
+		t.DueDate(), &now, t.Projects(), t.Contexts()).WithSource(t.Source())
+}
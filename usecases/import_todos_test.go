@@ -0,0 +1,79 @@
+package usecases_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/matryer/is"
+	"github.com/quii/todo-eisenhower/adapters/memory"
+	"github.com/quii/todo-eisenhower/domain/todo"
+	"github.com/quii/todo-eisenhower/usecases"
+)
+
+var importedAt = time.Date(2026, 3, 20, 9, 0, 0, 0, time.UTC)
+
+func TestImportTodos(t *testing.T) {
+	byDescription := func(t todo.Todo) string { return t.Description() }
+
+	t.Run("adds imported todos to their quadrants", func(t *testing.T) {
+		is := is.New(t)
+		repo := memory.NewRepository()
+		m, err := usecases.LoadMatrix(repo)
+		is.NoErr(err)
+
+		m, added, err := usecases.ImportTodos(repo, m, []todo.Todo{
+			todo.New("Renew passport", todo.PriorityA),
+			todo.New("Plan trip", todo.PriorityB),
+		}, byDescription, importedAt)
+		is.NoErr(err)
+		is.Equal(added, 2)
+		is.Equal(m.DoFirst()[0].Description(), "Renew passport")
+		is.Equal(m.Schedule()[0].Description(), "Plan trip")
+		is.Equal(m.DoFirst()[0].PrioritisedDate(), &importedAt) // prioritised on import, so it can go stale
+		is.Equal(m.Schedule()[0].PrioritisedDate(), nil)
+
+		saved, err := repo.LoadAll()
+		is.NoErr(err)
+		is.Equal(len(saved), 2)
+	})
+
+	t.Run("skips todos already in the matrix, the archive or the import", func(t *testing.T) {
+		is := is.New(t)
+		repo := memory.NewRepository()
+		is.NoErr(repo.SaveAll([]todo.Todo{todo.New("Renew passport", todo.PriorityA)}))
+		is.NoErr(repo.SaveArchive([]todo.Todo{todo.NewCompleted("Book flights", todo.PriorityB, nil)}))
+		m, err := usecases.LoadMatrix(repo)
+		is.NoErr(err)
+
+		m, added, err := usecases.ImportTodos(repo, m, []todo.Todo{
+			todo.New("Renew passport", todo.PriorityB),
+			todo.New("Book flights", todo.PriorityB),
+			todo.New("Pack", todo.PriorityC),
+			todo.New("Pack", todo.PriorityC),
+		}, byDescription, importedAt)
+		is.NoErr(err)
+		is.Equal(added, 1)
+		is.Equal(len(m.AllTodosIncludingBacklog()), 2)
+	})
+}
+
+func TestImportTodos_CompletedHistory(t *testing.T) {
+	is := is.New(t)
+	repo := memory.NewRepository()
+	is.NoErr(repo.SaveArchive([]todo.Todo{todo.NewCompleted("Old", todo.PriorityA, nil)}))
+	m, err := usecases.LoadMatrix(repo)
+	is.NoErr(err)
+
+	m, added, err := usecases.ImportTodos(repo, m, []todo.Todo{
+		todo.New("Open", todo.PriorityB),
+		todo.NewCompleted("Done last year", todo.PriorityC, nil),
+	}, func(t todo.Todo) string { return t.Description() }, importedAt)
+	is.NoErr(err)
+	is.Equal(added, 2)
+	is.Equal(len(m.AllTodosIncludingBacklog()), 1)
+
+	archived, err := repo.LoadArchive()
+	is.NoErr(err)
+	is.Equal(len(archived), 2)
+	is.Equal(archived[1].Description(), "Done last year")
+}
@@ -0,0 +1,153 @@
+package usecases
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/quii/todo-eisenhower/domain/matrix"
+	"github.com/quii/todo-eisenhower/domain/todo"
+)
+
+// Action names the kind of change recorded in the journal
+type Action string
+
+// Actions recorded by the use cases
+const (
+	ActionAdd       Action = "add"
+	ActionEdit      Action = "edit"
+	ActionMove      Action = "move"
+	ActionComplete  Action = "complete"
+	ActionReopen    Action = "reopen"
+	ActionDelete    Action = "delete"
+	ActionRestore   Action = "restore"
+	ActionPurge     Action = "purge"
+	ActionArchive   Action = "archive"
+	ActionUnarchive Action = "unarchive"
+	ActionImport    Action = "import"
+)
+
+// Event is a single change to a todo, as recorded in the journal
+// Before and After are todo.txt lines; either is empty when the todo didn't exist on that side of the change
+type Event struct {
+	Timestamp    time.Time
+	Action       Action
+	Description  string // the todo's description, for human readable summaries
+	Before       string
+	After        string
+	FromQuadrant string
+	ToQuadrant   string
+	User         string
+}
+
+// Summary describes the event in a short sentence, e.g. "Move 'Deploy API' to Do First"
+func (e Event) Summary() string {
+	verb := strings.ToUpper(string(e.Action[:1])) + string(e.Action[1:])
+	summary := fmt.Sprintf("%s '%s'", verb, e.Description)
+
+	switch e.Action {
+	case ActionAdd, ActionMove, ActionRestore, ActionUnarchive, ActionImport:
+		if quadrant, ok := matrix.ParseQuadrant(e.ToQuadrant); ok {
+			summary += " to " + quadrant.Title()
+		}
+	case ActionPurge:
+		summary += " from trash"
+	}
+	return summary
+}
+
+// Journal is the port for an append-only history of changes
+type Journal interface {
+	Record(event Event) error
+}
+
+// JournaledRepository is a TodoRepository that also records every change made through the use cases
+type JournaledRepository struct {
+	TodoRepository
+	journal Journal
+	user    string
+	now     func() time.Time
+}
+
+// WithJournal wraps a repository so use cases record their changes to the journal, attributed to user
+func WithJournal(repo TodoRepository, journal Journal, user string) *JournaledRepository {
+	return &JournaledRepository{
+		TodoRepository: repo,
+		journal:        journal,
+		user:           user,
+		now:            time.Now,
+	}
+}
+
+// Record stamps the event with the time and user, then appends it to the journal
+func (r *JournaledRepository) Record(event Event) error {
+	if event.Timestamp.IsZero() {
+		event.Timestamp = r.now()
+	}
+	if event.User == "" {
+		event.User = r.user
+	}
+	return r.journal.Record(event)
+}
+
+// ErrJournal is wrapped by errors from recording a change that was saved
+// Use cases return the updated matrix along with it, so callers can keep showing what's on disk.
+var ErrJournal = errors.New("recording change in journal")
+
+// eventRecorder is implemented by repositories that keep a journal
+type eventRecorder interface {
+	Record(event Event) error
+}
+
+// recordEvents appends events to the repository's journal, if it has one
+func recordEvents(repo TodoRepository, events ...Event) error {
+	recorder, ok := repo.(eventRecorder)
+	if !ok {
+		return nil
+	}
+
+	for _, event := range events {
+		if err := recorder.Record(event); err != nil {
+			return fmt.Errorf("%w: %w", ErrJournal, err)
+		}
+	}
+	return nil
+}
+
+// changeEvent describes a todo changing from before to after
+func changeEvent(action Action, before, after todo.Todo) Event {
+	return Event{
+		Action:       action,
+		Description:  after.Description(),
+		Before:       todoLine(before),
+		After:        todoLine(after),
+		FromQuadrant: matrix.QuadrantFor(before.Priority()).String(),
+		ToQuadrant:   matrix.QuadrantFor(after.Priority()).String(),
+	}
+}
+
+// addEvent describes a todo entering the matrix
+func addEvent(action Action, after todo.Todo) Event {
+	return Event{
+		Action:      action,
+		Description: after.Description(),
+		After:       todoLine(after),
+		ToQuadrant:  matrix.QuadrantFor(after.Priority()).String(),
+	}
+}
+
+// removeEvent describes a todo leaving the matrix
+func removeEvent(action Action, before todo.Todo) Event {
+	return Event{
+		Action:       action,
+		Description:  before.Description(),
+		Before:       todoLine(before),
+		FromQuadrant: matrix.QuadrantFor(before.Priority()).String(),
+	}
+}
+
+// todoLine returns the todo.txt line for a todo, without the trailing newline
+func todoLine(t todo.Todo) string {
+	return strings.TrimSuffix(t.String(), "\n")
+}
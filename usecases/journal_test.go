@@ -0,0 +1,137 @@
+package usecases_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/matryer/is"
+	"github.com/quii/todo-eisenhower/adapters/memory"
+	"github.com/quii/todo-eisenhower/domain/matrix"
+	"github.com/quii/todo-eisenhower/domain/todo"
+	"github.com/quii/todo-eisenhower/usecases"
+)
+
+// spyJournal keeps recorded events in memory for assertions
+type spyJournal struct {
+	events []usecases.Event
+}
+
+func (j *spyJournal) Record(event usecases.Event) error {
+	j.events = append(j.events, event)
+	return nil
+}
+
+// failingJournal can't record anything, like a journal file on a full disk
+type failingJournal struct{}
+
+func (failingJournal) Record(usecases.Event) error {
+	return errors.New("disk full")
+}
+
+func TestJournal(t *testing.T) {
+	newJournaledRepo := func(is *is.I, todos ...todo.Todo) (*usecases.JournaledRepository, *spyJournal, matrix.Matrix) {
+		journal := &spyJournal{}
+		repo := usecases.WithJournal(memory.NewRepository(), journal, "alice")
+		is.NoErr(repo.SaveAll(todos))
+		m, err := usecases.LoadMatrix(repo)
+		is.NoErr(err)
+		return repo, journal, m
+	}
+
+	t.Run("records adding a todo", func(t *testing.T) {
+		is := is.New(t)
+		repo, journal, m := newJournaledRepo(is)
+
+		_, err := usecases.AddTodo(repo, m, "Deploy API +ops", todo.PriorityB)
+		is.NoErr(err)
+
+		events := journal.events
+		is.Equal(len(events), 1)
+		is.Equal(events[0].Action, usecases.ActionAdd)
+		is.Equal(events[0].Before, "")
+		is.Equal(events[0].ToQuadrant, "schedule")
+		is.Equal(events[0].User, "alice")
+		is.True(!events[0].Timestamp.IsZero())
+	})
+
+	t.Run("records moving a todo between quadrants", func(t *testing.T) {
+		is := is.New(t)
+		repo, journal, m := newJournaledRepo(is, todo.New("Deploy API", todo.PriorityB))
+
+		_, err := usecases.ChangePriority(repo, m, matrix.ScheduleQuadrant, 0, todo.PriorityA)
+		is.NoErr(err)
+
+		events := journal.events
+		is.Equal(len(events), 1)
+		is.Equal(events[0].Action, usecases.ActionMove)
+		is.Equal(events[0].Before, "(B) Deploy API")
+		is.Equal(events[0].After, "(A) Deploy API prioritised:"+time.Now().Format("2006-01-02"))
+		is.Equal(events[0].FromQuadrant, "schedule")
+		is.Equal(events[0].ToQuadrant, "do-first")
+	})
+
+	t.Run("records completing, editing, archiving and deleting", func(t *testing.T) {
+		is := is.New(t)
+		repo, journal, m := newJournaledRepo(is,
+			todo.New("Write report", todo.PriorityA),
+			todo.New("Old idea", todo.PriorityD),
+		)
+
+		m, err := usecases.EditTodo(repo, m, matrix.DoFirstQuadrant, 0, "Write quarterly report")
+		is.NoErr(err)
+		m, err = usecases.ToggleCompletion(repo, m, matrix.DoFirstQuadrant, 0)
+		is.NoErr(err)
+		m, err = usecases.ArchiveAllCompleted(repo, m)
+		is.NoErr(err)
+		_, err = usecases.DeleteTodo(repo, m, m.Eliminate()[0])
+		is.NoErr(err)
+
+		events := journal.events
+		is.Equal(len(events), 4)
+		is.Equal(events[0].Action, usecases.ActionEdit)
+		is.Equal(events[0].Before, "(A) Write report")
+		is.Equal(events[0].After, "(A) Write quarterly report")
+		is.Equal(events[1].Action, usecases.ActionComplete)
+		is.Equal(events[2].Action, usecases.ActionArchive)
+		is.Equal(events[2].FromQuadrant, "do-first")
+		is.Equal(events[2].ToQuadrant, "")
+		is.Equal(events[3].Action, usecases.ActionDelete)
+		is.Equal(events[3].Before, "(D) Old idea")
+	})
+
+	t.Run("a journal failure keeps the saved change", func(t *testing.T) {
+		is := is.New(t)
+		repo := usecases.WithJournal(memory.NewRepository(), failingJournal{}, "alice")
+		is.NoErr(repo.SaveAll([]todo.Todo{todo.New("Write report", todo.PriorityA)}))
+		m, err := usecases.LoadMatrix(repo)
+		is.NoErr(err)
+
+		m, err = usecases.ToggleCompletion(repo, m, matrix.DoFirstQuadrant, 0)
+		is.True(errors.Is(err, usecases.ErrJournal))
+		is.True(m.DoFirst()[0].IsCompleted()) // returned matrix matches what was saved
+
+		saved, err := usecases.LoadMatrix(repo)
+		is.NoErr(err)
+		is.True(saved.DoFirst()[0].IsCompleted())
+	})
+
+	t.Run("repositories without a journal record nothing", func(t *testing.T) {
+		is := is.New(t)
+		repo := memory.NewRepository()
+
+		m, err := usecases.LoadMatrix(repo)
+		is.NoErr(err)
+		_, err = usecases.AddTodo(repo, m, "Deploy API", todo.PriorityA)
+		is.NoErr(err)
+	})
+}
+
+func TestEvent_Summary(t *testing.T) {
+	is := is.New(t)
+
+	is.Equal(usecases.Event{Action: usecases.ActionMove, Description: "Deploy API", ToQuadrant: "do-first"}.Summary(), "Move 'Deploy API' to Do First")
+	is.Equal(usecases.Event{Action: usecases.ActionAdd, Description: "Plan sprint", ToQuadrant: "schedule"}.Summary(), "Add 'Plan sprint' to Schedule")
+	is.Equal(usecases.Event{Action: usecases.ActionComplete, Description: "Deploy API"}.Summary(), "Complete 'Deploy API'")
+	is.Equal(usecases.Event{Action: usecases.ActionPurge, Description: "Old idea"}.Summary(), "Purge 'Old idea' from trash")
+}
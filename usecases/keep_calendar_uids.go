@@ -0,0 +1,39 @@
+package usecases
+
+import (
+	"github.com/quii/todo-eisenhower/domain/ics"
+	"github.com/quii/todo-eisenhower/domain/matrix"
+)
+
+// KeepCalendarUIDs tags every todo that has no uid: tag with the UID it is exported to calendars with,
+// and saves them. Calendar apps then keep seeing the same task after its description is edited.
+func KeepCalendarUIDs(repo TodoRepository, m matrix.Matrix) (matrix.Matrix, error) {
+	updatedMatrix := m
+	var events []Event
+	for _, quadrant := range []matrix.QuadrantType{
+		matrix.DoFirstQuadrant, matrix.ScheduleQuadrant, matrix.DelegateQuadrant, matrix.EliminateQuadrant, matrix.BacklogQuadrant,
+	} {
+		for index, t := range updatedMatrix.GetTodosForQuadrant(quadrant) {
+			tagged, changed := ics.WithUID(t)
+			if !changed {
+				continue
+			}
+			updatedMatrix = updatedMatrix.UpdateTodoAtIndex(quadrant, index, tagged)
+			events = append(events, changeEvent(ActionEdit, t, tagged))
+		}
+	}
+
+	if len(events) == 0 {
+		return m, nil
+	}
+
+	if err := saveAllTodos(repo, updatedMatrix); err != nil {
+		return m, err
+	}
+
+	if err := recordEvents(repo, events...); err != nil {
+		return updatedMatrix, err
+	}
+
+	return updatedMatrix, nil
+}
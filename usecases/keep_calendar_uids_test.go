@@ -0,0 +1,57 @@
+package usecases_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/matryer/is"
+	"github.com/quii/todo-eisenhower/adapters/memory"
+	"github.com/quii/todo-eisenhower/domain/ics"
+	"github.com/quii/todo-eisenhower/domain/matrix"
+	"github.com/quii/todo-eisenhower/domain/todo"
+	"github.com/quii/todo-eisenhower/domain/todotxt"
+	"github.com/quii/todo-eisenhower/usecases"
+)
+
+func TestKeepCalendarUIDs(t *testing.T) {
+	created := time.Date(2026, 1, 20, 0, 0, 0, 0, time.UTC)
+
+	t.Run("keeps each todo's UID through later edits", func(t *testing.T) {
+		is := is.New(t)
+		repo := memory.NewRepository()
+		fixProd := todotxt.ParseNew("Fix prod +ops", todo.PriorityA, created)
+		is.NoErr(repo.SaveAll([]todo.Todo{fixProd}))
+		m, err := usecases.LoadMatrix(repo)
+		is.NoErr(err)
+
+		m, err = usecases.KeepCalendarUIDs(repo, m)
+		is.NoErr(err)
+		uid := ics.UID(fixProd)
+		is.Equal(ics.UID(m.DoFirst()[0]), uid)
+		is.Equal(m.DoFirst()[0].Projects(), []string{"ops"})
+
+		// Editing starts from the todo as it is typed, uid: tag included
+		edited := strings.Replace(todotxt.FormatForInput(m.DoFirst()[0]), "Fix prod", "Fix prod database", 1)
+		m, err = usecases.EditTodo(repo, m, matrix.DoFirstQuadrant, 0, edited)
+		is.NoErr(err)
+		is.True(strings.HasPrefix(m.DoFirst()[0].Description(), "Fix prod database"))
+		is.Equal(ics.UID(m.DoFirst()[0]), uid)
+
+		saved, err := repo.LoadAll()
+		is.NoErr(err)
+		is.Equal(ics.UID(saved[0]), uid)
+	})
+
+	t.Run("leaves todos that already have a UID alone", func(t *testing.T) {
+		is := is.New(t)
+		repo := memory.NewRepository()
+		is.NoErr(repo.SaveAll([]todo.Todo{todo.New("Dentist uid:abc%40calendar", todo.PriorityB)}))
+		m, err := usecases.LoadMatrix(repo)
+		is.NoErr(err)
+
+		updated, err := usecases.KeepCalendarUIDs(repo, m)
+		is.NoErr(err)
+		is.Equal(updated, m)
+	})
+}
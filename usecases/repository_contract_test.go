@@ -1,13 +1,18 @@
 package usecases_test
 
 import (
+	"os/exec"
 	"path/filepath"
 	"testing"
 	"time"
 
 	"github.com/matryer/is"
+	"github.com/quii/todo-eisenhower/adapters/encrypted"
 	"github.com/quii/todo-eisenhower/adapters/file"
+	"github.com/quii/todo-eisenhower/adapters/git"
 	"github.com/quii/todo-eisenhower/adapters/memory"
+	"github.com/quii/todo-eisenhower/adapters/webdav"
+	"github.com/quii/todo-eisenhower/adapters/webdav/webdavtest"
 	"github.com/quii/todo-eisenhower/domain/todo"
 	"github.com/quii/todo-eisenhower/usecases"
 )
@@ -55,6 +60,85 @@ func TestRepositoryContract_File(t *testing.T) {
 	})
 }
 
+// TestRepositoryContract_FileWithMonthlyArchive runs the contract tests against a file repository with archive rotation
+func TestRepositoryContract_FileWithMonthlyArchive(t *testing.T) {
+	tmpDir := t.TempDir()
+	repo := file.NewRepository(filepath.Join(tmpDir, "todo.txt"), file.WithArchiveRotation(file.MonthlyRotation))
+	repositoryContract(t, repo)
+}
+
+// TestRepositoryContract_Workspace runs the contract tests against a workspace spanning several files
+func TestRepositoryContract_Workspace(t *testing.T) {
+	tmpDir := t.TempDir()
+	repo, err := file.NewWorkspace([]string{
+		filepath.Join(tmpDir, "work.txt"),
+		filepath.Join(tmpDir, "personal.txt"),
+	})
+	if err != nil {
+		t.Fatalf("failed to create workspace: %v", err)
+	}
+	repositoryContract(t, repo)
+}
+
+// TestRepositoryContract_Git runs the contract tests against a file repository committed to git
+func TestRepositoryContract_Git(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+	tmpDir := t.TempDir()
+	if out, err := exec.Command("git", "init", "--quiet", tmpDir).CombinedOutput(); err != nil {
+		t.Fatalf("git init: %v\n%s", err, out)
+	}
+	t.Setenv("GIT_AUTHOR_NAME", "Test")
+	t.Setenv("GIT_AUTHOR_EMAIL", "test@example.com")
+	t.Setenv("GIT_COMMITTER_NAME", "Test")
+	t.Setenv("GIT_COMMITTER_EMAIL", "test@example.com")
+
+	fileRepo := file.NewRepository(filepath.Join(tmpDir, "todo.txt"))
+	repo := git.NewRepository(fileRepo, tmpDir, fileRepo.Files, git.WithDebounce(0))
+	repositoryContract(t, repo)
+	if err := repo.Close(); err != nil {
+		t.Fatalf("failed to commit: %v", err)
+	}
+}
+
+// TestRepositoryContract_Encrypted runs the contract tests against the encrypted file repository
+func TestRepositoryContract_Encrypted(t *testing.T) {
+	repo := encrypted.NewRepository(filepath.Join(t.TempDir(), "todo.txt.enc"), "passphrase", encrypted.WithKeyIterations(encrypted.MinKeyIterations))
+	repositoryContract(t, repo)
+}
+
+// TestRepositoryContract_WebDAV runs the contract tests against the WebDAV repository, with and without an offline cache
+func TestRepositoryContract_WebDAV(t *testing.T) {
+	t.Run("without cache", func(t *testing.T) {
+		server := webdavtest.NewServer()
+		defer server.Close()
+
+		repo, err := webdav.NewRepository(server.URL + "/dav/todo.txt")
+		if err != nil {
+			t.Fatalf("failed to create repository: %v", err)
+		}
+		repositoryContract(t, repo)
+	})
+
+	t.Run("with cache", func(t *testing.T) {
+		server := webdavtest.NewServer()
+		defer server.Close()
+
+		repo, err := webdav.NewRepository(server.URL+"/dav/todo.txt", webdav.WithCacheDir(t.TempDir()))
+		if err != nil {
+			t.Fatalf("failed to create repository: %v", err)
+		}
+		repositoryContract(t, repo)
+	})
+}
+
+// TestRepositoryContract_Journaled runs the contract tests against a repository wrapped with a journal
+func TestRepositoryContract_Journaled(t *testing.T) {
+	repo := usecases.WithJournal(memory.NewRepository(), &spyJournal{}, "test")
+	repositoryContract(t, repo)
+}
+
 // repositoryContract tests the contract for an empty repository
 func repositoryContract(t *testing.T, repo usecases.TodoRepository) {
 	t.Helper()
@@ -160,6 +244,52 @@ func repositoryContract(t *testing.T, repo usecases.TodoRepository) {
 		is.Equal(loaded[0].Description(), "Second batch 1")
 		is.Equal(loaded[0].Priority(), todo.PriorityC)
 	})
+
+	t.Run("AppendToArchive and LoadArchive round-trip", func(t *testing.T) {
+		completionDate := time.Date(2026, 1, 21, 0, 0, 0, 0, time.UTC)
+		err := repo.AppendToArchive(todo.NewCompleted("Archived task", todo.PriorityC, &completionDate))
+		is.NoErr(err)
+
+		archived, err := repo.LoadArchive()
+		is.NoErr(err)
+		is.Equal(len(archived), 1)
+		is.Equal(archived[0].Description(), "Archived task")
+		is.Equal(archived[0].Priority(), todo.PriorityC)
+		is.True(archived[0].IsCompleted())
+		is.Equal(archived[0].CompletionDate().Format("2006-01-02"), "2026-01-21")
+	})
+
+	t.Run("SaveArchive replaces the archive contents", func(t *testing.T) {
+		err := repo.SaveArchive([]todo.Todo{})
+		is.NoErr(err)
+
+		archived, err := repo.LoadArchive()
+		is.NoErr(err)
+		is.Equal(len(archived), 0)
+	})
+
+	t.Run("AppendToTrash and LoadTrash round-trip", func(t *testing.T) {
+		deletedDate := time.Date(2026, 1, 20, 0, 0, 0, 0, time.UTC)
+		err := repo.AppendToTrash(todo.New("Deleted task", todo.PriorityB).MarkDeleted(deletedDate))
+		is.NoErr(err)
+
+		trash, err := repo.LoadTrash()
+		is.NoErr(err)
+		is.Equal(len(trash), 1)
+		is.Equal(trash[0].Description(), "Deleted task")
+		is.Equal(trash[0].Priority(), todo.PriorityB)
+		is.True(trash[0].DeletedDate() != nil)
+		is.True(trash[0].DeletedDate().Equal(deletedDate))
+	})
+
+	t.Run("SaveTrash replaces the trash contents", func(t *testing.T) {
+		err := repo.SaveTrash([]todo.Todo{})
+		is.NoErr(err)
+
+		trash, err := repo.LoadTrash()
+		is.NoErr(err)
+		is.Equal(len(trash), 0)
+	})
 }
 
 // repositoryContractWithInitialData tests the contract when repository has initial data
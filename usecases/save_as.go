@@ -0,0 +1,11 @@
+package usecases
+
+import (
+	"github.com/quii/todo-eisenhower/domain/matrix"
+)
+
+// SaveAs writes every todo in the matrix to another repository
+// Used to keep a read-only session (such as todos piped in on stdin) by switching it to a file
+func SaveAs(repo TodoRepository, m matrix.Matrix) error {
+	return saveAllTodos(repo, m)
+}
@@ -11,6 +11,11 @@ type TodoRepository interface {
 	LoadAll() ([]todo.Todo, error)
 	SaveAll(todos []todo.Todo) error
 	AppendToArchive(todo todo.Todo) error
+	LoadArchive() ([]todo.Todo, error)
+	SaveArchive(todos []todo.Todo) error
+	AppendToTrash(todo todo.Todo) error
+	LoadTrash() ([]todo.Todo, error)
+	SaveTrash(todos []todo.Todo) error
 }
 
 func saveAllTodos(repo TodoRepository, m matrix.Matrix) error {
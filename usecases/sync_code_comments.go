@@ -0,0 +1,244 @@
+package usecases
+
+import (
+	"net/url"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/quii/todo-eisenhower/domain/matrix"
+	"github.com/quii/todo-eisenhower/domain/todo"
+	"github.com/quii/todo-eisenhower/domain/todotxt"
+)
+
+// CodeComment is a TODO, FIXME or HACK comment found in source code
+type CodeComment struct {
+	Path   string // slash-separated, starting with the directory that was scanned, made absolute so it's the same wherever the scan runs from
+	Line   int
+	Marker string // "TODO", "FIXME" or "HACK"
+	Text   string // the rest of the comment
+}
+
+// CodeSync counts the changes SyncCodeComments made
+type CodeSync struct {
+	Added    int // new comments
+	Moved    int // comments now on another line
+	Resolved int // comments that are no longer in the code
+}
+
+// CodeProject is the project of todos made from code comments
+const CodeProject = "code"
+
+// markerPriorities place comments in quadrants: a FIXME is broken code to fix now, a TODO is work
+// to plan, and a HACK is debt that can be handed to whoever next works on that code
+var markerPriorities = map[string]todo.Priority{
+	"FIXME": todo.PriorityA,
+	"TODO":  todo.PriorityB,
+	"HACK":  todo.PriorityC,
+}
+
+// fileTag finds the file:path:line a code todo was made from; the path is escaped by escapePath
+var fileTag = regexp.MustCompile(`(?:^|\s)file:(\S+):(\d+)`)
+
+// SyncCodeComments brings the matrix up to date with the comments found by scanning root.
+// Each new comment becomes a +code todo tagged with its file:path:line. Todos whose comment moved
+// get its new line; the todo is otherwise left as it is, including its quadrant. Open todos whose
+// comment is gone from root are completed, unless their file is in or under one of the unread paths
+// the scan couldn't read. Todos that were completed or archived aren't added again.
+// Identical comments in one file are told apart by the order they appear in. Completing a todo
+// never changes the code.
+func SyncCodeComments(repo TodoRepository, m matrix.Matrix, comments []CodeComment, root string, unread []string, now time.Time) (matrix.Matrix, CodeSync, error) {
+	var result CodeSync
+	archived, err := repo.LoadArchive()
+	if err != nil {
+		return m, result, err
+	}
+
+	keys := make([]string, len(comments))
+	found := make(map[string]CodeComment, len(comments))
+	seen := make(map[string]int)
+	for i, c := range comments {
+		same := commentKey(c.Path, commentText(c))
+		keys[i] = occurrenceKey(same, seen[same])
+		seen[same]++
+		found[keys[i]] = c
+	}
+
+	updatedMatrix := m
+	var events []Event
+	todoKeys := codeTodoKeys(archived, updatedMatrix)
+	known := make(map[string]bool)
+	for _, t := range archived {
+		if key, ok := todoKeys[t.String()]; ok {
+			known[key] = true
+		}
+	}
+
+	// Move and resolve the todos already in the matrix
+	for _, quadrant := range []matrix.QuadrantType{
+		matrix.DoFirstQuadrant, matrix.ScheduleQuadrant, matrix.DelegateQuadrant, matrix.EliminateQuadrant, matrix.BacklogQuadrant,
+	} {
+		for index, t := range updatedMatrix.GetTodosForQuadrant(quadrant) {
+			key, ok := todoKeys[t.String()]
+			if !ok {
+				continue
+			}
+			known[key] = true
+			if t.IsCompleted() {
+				continue
+			}
+
+			matches := fileTag.FindStringSubmatch(t.Description())
+			location := matches[1] + ":" + matches[2]
+			c, stillThere := found[key]
+			switch {
+			case stillThere && location != fileLocation(c):
+				moved := todotxt.ParseEdit(t, strings.Replace(todotxt.FormatForInput(t), "file:"+location, "file:"+fileLocation(c), 1), t.Priority())
+				updatedMatrix = updatedMatrix.UpdateTodoAtIndex(quadrant, index, moved)
+				events = append(events, changeEvent(ActionEdit, t, moved))
+				result.Moved++
+			case !stillThere && underRoot(unescapePath(matches[1]), root) && !underAny(unescapePath(matches[1]), unread):
+				resolved := t.ToggleCompletion(now)
+				updatedMatrix = updatedMatrix.UpdateTodoAtIndex(quadrant, index, resolved)
+				events = append(events, changeEvent(ActionComplete, t, resolved))
+				result.Resolved++
+			}
+		}
+	}
+
+	// Add the comments that aren't todos yet, in the order they were found
+	for i, c := range comments {
+		key := keys[i]
+		if known[key] {
+			continue
+		}
+		known[key] = true
+
+		description := commentText(c) + " +" + CodeProject + " file:" + fileLocation(c)
+		added := todotxt.ParseNew(description, markerPriorities[c.Marker], now)
+		updatedMatrix = updatedMatrix.AddTodo(added)
+		events = append(events, addEvent(ActionAdd, added))
+		result.Added++
+	}
+
+	if len(events) == 0 {
+		return m, result, nil
+	}
+
+	if err := saveAllTodos(repo, updatedMatrix); err != nil {
+		return m, CodeSync{}, err
+	}
+
+	if err := recordEvents(repo, events...); err != nil {
+		return updatedMatrix, result, err
+	}
+
+	return updatedMatrix, result, nil
+}
+
+// commentText is what a comment's todo says; a bare marker says the marker
+func commentText(c CodeComment) string {
+	text := strings.Join(strings.Fields(c.Text), " ")
+	if text == "" {
+		return c.Marker
+	}
+	// Read the text as a todo would be, so tags in the comment don't stop it matching its todo
+	return todotxt.ParseNew(text, todo.PriorityNone, time.Time{}).Description()
+}
+
+func fileLocation(c CodeComment) string {
+	return escapePath(c.Path) + ":" + strconv.Itoa(c.Line)
+}
+
+// pathEscaper encodes the characters url.PathEscape leaves that would start a tag
+var pathEscaper = strings.NewReplacer("+", "%2B", "@", "%40")
+
+// escapePath keeps a path in a single word that can't be read as a +project or @context,
+// so paths with spaces, + or @ in them survive the trip through todo.txt
+func escapePath(p string) string {
+	segments := strings.Split(p, "/")
+	for i, segment := range segments {
+		segments[i] = pathEscaper.Replace(url.PathEscape(segment))
+	}
+	return strings.Join(segments, "/")
+}
+
+// unescapePath reverses escapePath; a path that doesn't decode is used as written
+func unescapePath(p string) string {
+	unescaped, err := url.PathUnescape(p)
+	if err != nil {
+		return p
+	}
+	return unescaped
+}
+
+func commentKey(path, text string) string {
+	return path + "\x00" + text
+}
+
+// occurrenceKey tells apart identical comments in one file, numbered from 0 in line order
+func occurrenceKey(key string, occurrence int) string {
+	return key + "\x00" + strconv.Itoa(occurrence)
+}
+
+// codeTodoKeys identifies the todos made from code comments, keyed by their todo.txt line. Todos
+// for identical comments in one file are numbered in line order, as the comments are.
+func codeTodoKeys(archived []todo.Todo, m matrix.Matrix) map[string]string {
+	type codeTodo struct {
+		line string
+		key  string
+		at   int
+	}
+	groups := make(map[string][]codeTodo)
+	var order []string
+	for _, t := range append(archived, m.AllTodosIncludingBacklog()...) {
+		if !t.MatchesTag("+" + CodeProject) {
+			continue
+		}
+		matches := fileTag.FindStringSubmatch(t.Description())
+		if matches == nil {
+			continue
+		}
+		text := strings.Join(strings.Fields(fileTag.ReplaceAllString(t.Description(), "")), " ")
+		key := commentKey(unescapePath(matches[1]), text)
+		at, _ := strconv.Atoi(matches[2])
+		if groups[key] == nil {
+			order = append(order, key)
+		}
+		groups[key] = append(groups[key], codeTodo{line: t.String(), key: key, at: at})
+	}
+
+	keys := make(map[string]string)
+	for _, key := range order {
+		group := groups[key]
+		sort.SliceStable(group, func(i, j int) bool { return group[i].at < group[j].at })
+		for occurrence, ct := range group {
+			if _, ok := keys[ct.line]; !ok {
+				keys[ct.line] = occurrenceKey(ct.key, occurrence)
+			}
+		}
+	}
+	return keys
+}
+
+// underAny returns true if the slash-separated file path is one of roots or inside one of them
+func underAny(file string, roots []string) bool {
+	for _, root := range roots {
+		if underRoot(file, root) {
+			return true
+		}
+	}
+	return false
+}
+
+// underRoot returns true if the slash-separated file path is inside root
+func underRoot(file, root string) bool {
+	root = path.Clean(root)
+	if root == "." {
+		return !path.IsAbs(file) && !strings.HasPrefix(file, "../")
+	}
+	return file == root || strings.HasPrefix(file, root+"/")
+}
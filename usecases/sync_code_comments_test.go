@@ -0,0 +1,169 @@
+package usecases_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/matryer/is"
+	"github.com/quii/todo-eisenhower/adapters/memory"
+	"github.com/quii/todo-eisenhower/domain/todo"
+	"github.com/quii/todo-eisenhower/usecases"
+)
+
+func TestSyncCodeComments(t *testing.T) {
+	now := time.Date(2026, 1, 20, 9, 0, 0, 0, time.UTC)
+	comments := []usecases.CodeComment{
+		{Path: "repo/main.go", Line: 12, Marker: "FIXME", Text: "nil map panic"},
+		{Path: "repo/main.go", Line: 30, Marker: "TODO", Text: "retry with backoff"},
+		{Path: "repo/db.go", Line: 4, Marker: "HACK", Text: ""},
+	}
+
+	t.Run("adds a +code todo per comment in the marker's quadrant", func(t *testing.T) {
+		is := is.New(t)
+		repo := memory.NewRepository()
+		m, err := usecases.LoadMatrix(repo)
+		is.NoErr(err)
+
+		m, result, err := usecases.SyncCodeComments(repo, m, comments, "repo", nil, now)
+		is.NoErr(err)
+		is.Equal(result, usecases.CodeSync{Added: 3})
+		is.Equal(m.DoFirst()[0].Description(), "nil map panic file:repo/main.go:12")
+		is.Equal(m.DoFirst()[0].Projects(), []string{"code"})
+		is.Equal(m.Schedule()[0].Description(), "retry with backoff file:repo/main.go:30")
+		is.Equal(m.Delegate()[0].Description(), "HACK file:repo/db.go:4")
+
+		saved, err := repo.LoadAll()
+		is.NoErr(err)
+		is.Equal(len(saved), 3)
+	})
+
+	t.Run("re-running moves todos to new lines without adding duplicates", func(t *testing.T) {
+		is := is.New(t)
+		repo := memory.NewRepository()
+		m, err := usecases.LoadMatrix(repo)
+		is.NoErr(err)
+		m, _, err = usecases.SyncCodeComments(repo, m, comments, "repo", nil, now)
+		is.NoErr(err)
+
+		moved := append([]usecases.CodeComment{}, comments...)
+		moved[1].Line = 35
+		m, result, err := usecases.SyncCodeComments(repo, m, moved, "repo", nil, now)
+		is.NoErr(err)
+		is.Equal(result, usecases.CodeSync{Moved: 1})
+		is.Equal(len(m.AllTodosIncludingBacklog()), 3)
+		is.Equal(m.Schedule()[0].Description(), "retry with backoff file:repo/main.go:35")
+	})
+
+	t.Run("keeps the quadrant a todo was moved to", func(t *testing.T) {
+		is := is.New(t)
+		repo := memory.NewRepository()
+		is.NoErr(repo.SaveAll([]todo.Todo{
+			todo.NewWithTags("retry with backoff file:repo/main.go:30", todo.PriorityD, []string{"code"}, nil),
+		}))
+		m, err := usecases.LoadMatrix(repo)
+		is.NoErr(err)
+
+		m, result, err := usecases.SyncCodeComments(repo, m, comments[1:2], "repo", nil, now)
+		is.NoErr(err)
+		is.Equal(result, usecases.CodeSync{})
+		is.Equal(len(m.Eliminate()), 1)
+	})
+
+	t.Run("completes open todos whose comment is gone from the scanned directory", func(t *testing.T) {
+		is := is.New(t)
+		repo := memory.NewRepository()
+		m, err := usecases.LoadMatrix(repo)
+		is.NoErr(err)
+		m, _, err = usecases.SyncCodeComments(repo, m, comments, "repo", nil, now)
+		is.NoErr(err)
+		m, _, err = usecases.SyncCodeComments(repo, m, []usecases.CodeComment{
+			{Path: "other/lib.go", Line: 1, Marker: "TODO", Text: "document"},
+		}, "other", nil, now)
+		is.NoErr(err)
+
+		m, result, err := usecases.SyncCodeComments(repo, m, comments[:2], "repo", nil, now)
+		is.NoErr(err)
+		is.Equal(result, usecases.CodeSync{Resolved: 1})
+		is.True(m.Delegate()[0].IsCompleted())
+		is.True(!m.Schedule()[1].IsCompleted()) // other/ wasn't scanned
+	})
+
+	t.Run("keeps a todo for each of several identical comments in a file", func(t *testing.T) {
+		is := is.New(t)
+		repo := memory.NewRepository()
+		m, err := usecases.LoadMatrix(repo)
+		is.NoErr(err)
+		bare := []usecases.CodeComment{
+			{Path: "repo/main.go", Line: 5, Marker: "TODO"},
+			{Path: "repo/main.go", Line: 9, Marker: "TODO"},
+		}
+
+		m, result, err := usecases.SyncCodeComments(repo, m, bare, "repo", nil, now)
+		is.NoErr(err)
+		is.Equal(result, usecases.CodeSync{Added: 2})
+
+		m, result, err = usecases.SyncCodeComments(repo, m, bare, "repo", nil, now)
+		is.NoErr(err)
+		is.Equal(result, usecases.CodeSync{})
+
+		m, result, err = usecases.SyncCodeComments(repo, m, []usecases.CodeComment{
+			{Path: "repo/main.go", Line: 4, Marker: "TODO"},
+		}, "repo", nil, now)
+		is.NoErr(err)
+		is.Equal(result, usecases.CodeSync{Moved: 1, Resolved: 1})
+		is.Equal(m.Schedule()[0].Description(), "TODO file:repo/main.go:4")
+		is.True(m.Schedule()[1].IsCompleted())
+	})
+
+	t.Run("doesn't add comments whose todo was completed or archived", func(t *testing.T) {
+		is := is.New(t)
+		repo := memory.NewRepository()
+		is.NoErr(repo.SaveAll([]todo.Todo{
+			todo.NewWithTags("nil map panic file:repo/main.go:12", todo.PriorityA, []string{"code"}, nil).ToggleCompletion(now),
+		}))
+		is.NoErr(repo.SaveArchive([]todo.Todo{
+			todo.NewWithTags("retry with backoff file:repo/main.go:30", todo.PriorityB, []string{"code"}, nil).ToggleCompletion(now),
+		}))
+		m, err := usecases.LoadMatrix(repo)
+		is.NoErr(err)
+
+		m, result, err := usecases.SyncCodeComments(repo, m, comments, "repo", nil, now)
+		is.NoErr(err)
+		is.Equal(result, usecases.CodeSync{Added: 1})
+		is.Equal(len(m.AllTodosIncludingBacklog()), 2)
+	})
+
+	t.Run("escapes paths so spaces, + and @ don't break the file tag", func(t *testing.T) {
+		is := is.New(t)
+		repo := memory.NewRepository()
+		m, err := usecases.LoadMatrix(repo)
+		is.NoErr(err)
+		odd := []usecases.CodeComment{{Path: "repo/my docs/c++/@work.go", Line: 3, Marker: "TODO", Text: "tidy"}}
+
+		m, _, err = usecases.SyncCodeComments(repo, m, odd, "repo", nil, now)
+		is.NoErr(err)
+		is.Equal(m.Schedule()[0].Description(), "tidy file:repo/my%20docs/c%2B%2B/%40work.go:3")
+		is.Equal(m.Schedule()[0].Projects(), []string{"code"})
+		is.Equal(len(m.Schedule()[0].Contexts()), 0)
+
+		odd[0].Line = 4
+		m, result, err := usecases.SyncCodeComments(repo, m, odd, "repo", nil, now)
+		is.NoErr(err)
+		is.Equal(result, usecases.CodeSync{Moved: 1})
+		is.Equal(m.Schedule()[0].Description(), "tidy file:repo/my%20docs/c%2B%2B/%40work.go:4")
+	})
+
+	t.Run("doesn't complete todos in files the scan couldn't read", func(t *testing.T) {
+		is := is.New(t)
+		repo := memory.NewRepository()
+		m, err := usecases.LoadMatrix(repo)
+		is.NoErr(err)
+		m, _, err = usecases.SyncCodeComments(repo, m, comments, "repo", nil, now)
+		is.NoErr(err)
+
+		m, result, err := usecases.SyncCodeComments(repo, m, comments[2:], "repo", []string{"repo/main.go"}, now)
+		is.NoErr(err)
+		is.Equal(result, usecases.CodeSync{})
+		is.True(!m.DoFirst()[0].IsCompleted())
+	})
+}
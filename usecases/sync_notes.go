@@ -0,0 +1,127 @@
+package usecases
+
+import (
+	"strings"
+	"time"
+
+	"github.com/quii/todo-eisenhower/domain/checklist"
+	"github.com/quii/todo-eisenhower/domain/matrix"
+	"github.com/quii/todo-eisenhower/domain/todo"
+	"github.com/quii/todo-eisenhower/domain/todotxt"
+)
+
+// NotesSync counts the changes SyncNotes made
+type NotesSync struct {
+	Added     int // new unticked items
+	Moved     int // items now on another line
+	Completed int // items ticked in their note
+	Reopened  int // items unticked in their note
+	Removed   int // items no longer in their note, whose todos were completed
+}
+
+// SyncNotes brings the matrix up to date with the checklist items read from a notes directory,
+// as todos tagged note:path:line (see checklist.Unmarshal).
+// New unticked items are added; ticked items that were never todos are history and are skipped.
+// Ticking or unticking an item completes or reopens its todo, and open todos whose item is gone
+// are completed. A todo's quadrant, text and tags are left as they are in the matrix.
+// Ticking boxes when todos are completed is the notes repository's job, so it happens on every save.
+func SyncNotes(repo TodoRepository, m matrix.Matrix, items []todo.Todo, now time.Time) (matrix.Matrix, NotesSync, error) {
+	var result NotesSync
+	archived, err := repo.LoadArchive()
+	if err != nil {
+		return m, result, err
+	}
+
+	found := make(map[string]todo.Todo, len(items))
+	for _, item := range items {
+		found[checklist.Key(item)] = item
+	}
+
+	known := make(map[string]bool)
+	for _, t := range archived {
+		if key := checklist.Key(t); key != "" {
+			known[key] = true
+		}
+	}
+
+	updatedMatrix := m
+	var events []Event
+	for _, quadrant := range []matrix.QuadrantType{
+		matrix.DoFirstQuadrant, matrix.ScheduleQuadrant, matrix.DelegateQuadrant, matrix.EliminateQuadrant, matrix.BacklogQuadrant,
+	} {
+		for index, t := range updatedMatrix.GetTodosForQuadrant(quadrant) {
+			key := checklist.Key(t)
+			if key == "" {
+				continue
+			}
+			known[key] = true
+
+			item, stillThere := found[key]
+			if !stillThere {
+				if !t.IsCompleted() {
+					resolved := t.ToggleCompletion(now)
+					updatedMatrix = updatedMatrix.UpdateTodoAtIndex(quadrant, index, resolved)
+					events = append(events, changeEvent(ActionComplete, t, resolved))
+					result.Removed++
+				}
+				continue
+			}
+
+			updated := t
+			if tag, itemTag := locationTag(t), locationTag(item); tag != itemTag {
+				updated = todotxt.ParseEdit(t, strings.Replace(todotxt.FormatForInput(t), tag, itemTag, 1), t.Priority())
+				events = append(events, changeEvent(ActionEdit, t, updated))
+				result.Moved++
+			}
+			if item.IsCompleted() != t.IsCompleted() {
+				before := updated
+				completedOn := now
+				if item.IsCompleted() && item.CompletionDate() != nil {
+					completedOn = *item.CompletionDate()
+				}
+				updated = updated.ToggleCompletion(completedOn)
+				if updated.IsCompleted() {
+					events = append(events, changeEvent(ActionComplete, before, updated))
+					result.Completed++
+				} else {
+					events = append(events, changeEvent(ActionReopen, before, updated))
+					result.Reopened++
+				}
+			}
+			updatedMatrix = updatedMatrix.UpdateTodoAtIndex(quadrant, index, updated)
+		}
+	}
+
+	// Add the unticked items that aren't todos yet, in the order they were read
+	for _, item := range items {
+		key := checklist.Key(item)
+		if known[key] || item.IsCompleted() {
+			continue
+		}
+		known[key] = true
+
+		updatedMatrix = updatedMatrix.AddTodo(item)
+		events = append(events, addEvent(ActionAdd, item))
+		result.Added++
+	}
+
+	if len(events) == 0 {
+		return m, result, nil
+	}
+
+	if err := saveAllTodos(repo, updatedMatrix); err != nil {
+		return m, NotesSync{}, err
+	}
+
+	if err := recordEvents(repo, events...); err != nil {
+		return updatedMatrix, result, err
+	}
+
+	return updatedMatrix, result, nil
+}
+
+// locationTag is a note todo's note:path:line tag, as written in its description
+func locationTag(t todo.Todo) string {
+	path, line, _ := checklist.Location(t)
+	return checklist.Tag(path, line)
+}
@@ -0,0 +1,75 @@
+package usecases_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/matryer/is"
+	"github.com/quii/todo-eisenhower/adapters/memory"
+	"github.com/quii/todo-eisenhower/domain/checklist"
+	"github.com/quii/todo-eisenhower/domain/todo"
+	"github.com/quii/todo-eisenhower/usecases"
+)
+
+func TestSyncNotes(t *testing.T) {
+	now := time.Date(2026, 1, 20, 9, 0, 0, 0, time.UTC)
+	read := func(t *testing.T, note string) []todo.Todo {
+		t.Helper()
+		items, err := checklist.Unmarshal(strings.NewReader(note), "Inbox.md", now)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return items
+	}
+
+	t.Run("adds unticked items and skips ticked ones", func(t *testing.T) {
+		is := is.New(t)
+		repo := memory.NewRepository()
+		m, err := usecases.LoadMatrix(repo)
+		is.NoErr(err)
+
+		m, result, err := usecases.SyncNotes(repo, m, read(t, "- [ ] (A) Renew passport\n- [x] Book venue\n- [ ] Call mum\n"), now)
+		is.NoErr(err)
+		is.Equal(result, usecases.NotesSync{Added: 2})
+		is.Equal(m.DoFirst()[0].Description(), "Renew passport note:Inbox.md:1")
+		is.Equal(m.Backlog()[0].Description(), "Call mum note:Inbox.md:3")
+	})
+
+	t.Run("follows items to new lines and their boxes being ticked and unticked", func(t *testing.T) {
+		is := is.New(t)
+		repo := memory.NewRepository()
+		m, err := usecases.LoadMatrix(repo)
+		is.NoErr(err)
+		m, _, err = usecases.SyncNotes(repo, m, read(t, "- [ ] (A) Renew passport\n- [ ] Call mum\n"), now)
+		is.NoErr(err)
+
+		m, result, err := usecases.SyncNotes(repo, m, read(t, "# Today\n- [x] Renew passport ✅ 2026-01-19\n- [ ] Call mum\n"), now)
+		is.NoErr(err)
+		is.Equal(result, usecases.NotesSync{Moved: 2, Completed: 1})
+		is.Equal(m.DoFirst()[0].String(), "x 2026-01-19 2026-01-20 (A) Renew passport note:Inbox.md:2 prioritised:2026-01-20\n")
+		is.Equal(m.Backlog()[0].Description(), "Call mum note:Inbox.md:3")
+
+		m, result, err = usecases.SyncNotes(repo, m, read(t, "# Today\n- [ ] Renew passport\n- [ ] Call mum\n"), now)
+		is.NoErr(err)
+		is.Equal(result, usecases.NotesSync{Reopened: 1})
+		is.True(!m.DoFirst()[0].IsCompleted())
+	})
+
+	t.Run("completes todos whose item is gone and doesn't add archived items again", func(t *testing.T) {
+		is := is.New(t)
+		repo := memory.NewRepository()
+		archived := read(t, "- [x] Book venue\n")
+		is.NoErr(repo.SaveArchive(archived))
+		m, err := usecases.LoadMatrix(repo)
+		is.NoErr(err)
+		m, _, err = usecases.SyncNotes(repo, m, read(t, "- [ ] Call mum\n"), now)
+		is.NoErr(err)
+
+		m, result, err := usecases.SyncNotes(repo, m, read(t, "- [ ] Book venue\n"), now)
+		is.NoErr(err)
+		is.Equal(result, usecases.NotesSync{Removed: 1})
+		is.True(m.Backlog()[0].IsCompleted())
+		is.Equal(len(m.AllTodosIncludingBacklog()), 1)
+	})
+}
@@ -4,10 +4,17 @@ import (
 	"time"
 
 	"github.com/quii/todo-eisenhower/domain/matrix"
+	"github.com/quii/todo-eisenhower/domain/todo"
 )
 
 // ToggleCompletion toggles the completion status of a todo at the specified position
 func ToggleCompletion(repo TodoRepository, m matrix.Matrix, quadrant matrix.QuadrantType, index int) (matrix.Matrix, error) {
+	// Capture the original before toggling, as the matrix updates its quadrant slice in place
+	var before todo.Todo
+	if todos := m.GetTodosForQuadrant(quadrant); index >= 0 && index < len(todos) {
+		before = todos[index]
+	}
+
 	updatedMatrix, changed := m.ToggleCompletionAt(quadrant, index, time.Now())
 
 	if changed {
@@ -15,6 +22,15 @@ func ToggleCompletion(repo TodoRepository, m matrix.Matrix, quadrant matrix.Quad
 		if err != nil {
 			return m, err
 		}
+
+		after := updatedMatrix.GetTodosForQuadrant(quadrant)[index]
+		action := ActionComplete
+		if !after.IsCompleted() {
+			action = ActionReopen
+		}
+		if err := recordEvents(repo, changeEvent(action, before, after)); err != nil {
+			return updatedMatrix, err
+		}
 	}
 
 	return updatedMatrix, nil
@@ -0,0 +1,112 @@
+package usecases
+
+import (
+	"sort"
+	"time"
+
+	"github.com/quii/todo-eisenhower/domain/matrix"
+	"github.com/quii/todo-eisenhower/domain/todo"
+)
+
+// DefaultTrashRetentionDays is how long deleted todos stay in the trash before being purged
+const DefaultTrashRetentionDays = 30
+
+// LoadTrash returns the deleted todos, most recently deleted first
+func LoadTrash(repo TodoRepository) ([]todo.Todo, error) {
+	trashed, err := repo.LoadTrash()
+	if err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(trashed, func(i, j int) bool {
+		return dateAfter(trashed[i].DeletedDate(), trashed[j].DeletedDate())
+	})
+
+	return trashed, nil
+}
+
+// RestoreTodo takes a todo out of the trash and puts it back in the matrix
+// The todo keeps its priority, so it returns to the quadrant it was deleted from
+func RestoreTodo(repo TodoRepository, m matrix.Matrix, trashed todo.Todo) (matrix.Matrix, error) {
+	trash, err := repo.LoadTrash()
+	if err != nil {
+		return m, err
+	}
+
+	remaining, found := removeFirstMatch(trash, trashed)
+	if !found {
+		return m, nil // No-op if the todo is no longer in the trash
+	}
+
+	restored := trashed.Restore()
+	updatedMatrix := m.AddTodo(restored)
+
+	// Save the matrix first so a failed write never loses the todo
+	if err := saveAllTodos(repo, updatedMatrix); err != nil {
+		return m, err
+	}
+
+	if err := repo.SaveTrash(remaining); err != nil {
+		return m, err
+	}
+
+	if err := recordEvents(repo, addEvent(ActionRestore, restored)); err != nil {
+		return updatedMatrix, err
+	}
+
+	return updatedMatrix, nil
+}
+
+// PurgeTrash permanently removes todos that have been in the trash for longer than retentionDays
+// Returns the number of todos purged
+func PurgeTrash(repo TodoRepository, retentionDays int, now time.Time) (int, error) {
+	trash, err := repo.LoadTrash()
+	if err != nil {
+		return 0, err
+	}
+
+	cutoff := now.AddDate(0, 0, -retentionDays)
+	kept := make([]todo.Todo, 0, len(trash))
+	var events []Event
+	for _, t := range trash {
+		if t.DeletedBefore(cutoff) {
+			events = append(events, Event{Action: ActionPurge, Description: t.Description(), Before: todoLine(t)})
+		} else {
+			kept = append(kept, t)
+		}
+	}
+
+	purged := len(trash) - len(kept)
+	if purged == 0 {
+		return 0, nil
+	}
+
+	if err := repo.SaveTrash(kept); err != nil {
+		return 0, err
+	}
+
+	return purged, recordEvents(repo, events...)
+}
+
+// removeFirstMatch removes the first todo whose todo.txt line matches target
+func removeFirstMatch(todos []todo.Todo, target todo.Todo) ([]todo.Todo, bool) {
+	for i, t := range todos {
+		if t.String() == target.String() {
+			remaining := make([]todo.Todo, 0, len(todos)-1)
+			remaining = append(remaining, todos[:i]...)
+			return append(remaining, todos[i+1:]...), true
+		}
+	}
+	return todos, false
+}
+
+// dateAfter orders optional dates newest first, with missing dates last
+func dateAfter(d1, d2 *time.Time) bool {
+	if d1 == nil {
+		return false
+	}
+	if d2 == nil {
+		return true
+	}
+	return d1.After(*d2)
+}
@@ -0,0 +1,142 @@
+package usecases_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/matryer/is"
+	"github.com/quii/todo-eisenhower/adapters/memory"
+	"github.com/quii/todo-eisenhower/domain/todo"
+	"github.com/quii/todo-eisenhower/usecases"
+)
+
+func TestDeleteTodo(t *testing.T) {
+	t.Run("moves the deleted todo to the trash with a deleted date", func(t *testing.T) {
+		is := is.New(t)
+		repo := memory.NewRepository()
+
+		err := repo.SaveAll([]todo.Todo{
+			todo.New("Keep me", todo.PriorityA),
+			todo.New("Delete me", todo.PriorityB),
+		})
+		is.NoErr(err)
+
+		m, err := usecases.LoadMatrix(repo)
+		is.NoErr(err)
+
+		updatedMatrix, err := usecases.DeleteTodo(repo, m, m.Schedule()[0])
+		is.NoErr(err)
+		is.Equal(len(updatedMatrix.Schedule()), 0)
+
+		trash, err := repo.LoadTrash()
+		is.NoErr(err)
+		is.Equal(len(trash), 1)
+		is.Equal(trash[0].Description(), "Delete me")
+		is.Equal(trash[0].Priority(), todo.PriorityB)
+		is.True(trash[0].DeletedDate() != nil)
+		is.True(strings.Contains(repo.TrashString(), "deleted:"+time.Now().Format("2006-01-02")))
+	})
+}
+
+func TestRestoreTodo(t *testing.T) {
+	t.Run("restores a trashed todo to its original quadrant", func(t *testing.T) {
+		is := is.New(t)
+		repo := memory.NewRepository()
+
+		err := repo.SaveAll([]todo.Todo{todo.New("Delegate me", todo.PriorityC)})
+		is.NoErr(err)
+
+		m, err := usecases.LoadMatrix(repo)
+		is.NoErr(err)
+
+		m, err = usecases.DeleteTodo(repo, m, m.Delegate()[0])
+		is.NoErr(err)
+
+		trash, err := usecases.LoadTrash(repo)
+		is.NoErr(err)
+		is.Equal(len(trash), 1)
+
+		m, err = usecases.RestoreTodo(repo, m, trash[0])
+		is.NoErr(err)
+
+		is.Equal(len(m.Delegate()), 1)
+		is.Equal(m.Delegate()[0].Description(), "Delegate me")
+		is.True(m.Delegate()[0].DeletedDate() == nil)
+		is.Equal(repo.TrashString(), "")
+
+		// Restored todo is persisted without the deleted marker
+		is.True(!strings.Contains(repo.String(), "deleted:"))
+	})
+
+	t.Run("no-op when the todo is no longer in the trash", func(t *testing.T) {
+		is := is.New(t)
+		repo := memory.NewRepository()
+
+		m, err := usecases.LoadMatrix(repo)
+		is.NoErr(err)
+
+		ghost := todo.New("Ghost", todo.PriorityA).MarkDeleted(time.Now())
+		updatedMatrix, err := usecases.RestoreTodo(repo, m, ghost)
+		is.NoErr(err)
+		is.Equal(len(updatedMatrix.AllTodos()), 0)
+	})
+}
+
+func TestLoadTrash(t *testing.T) {
+	t.Run("returns most recently deleted todos first", func(t *testing.T) {
+		is := is.New(t)
+		repo := memory.NewRepository()
+
+		older := time.Date(2026, 1, 10, 0, 0, 0, 0, time.UTC)
+		newer := time.Date(2026, 1, 20, 0, 0, 0, 0, time.UTC)
+		err := repo.SaveTrash([]todo.Todo{
+			todo.New("Older", todo.PriorityA).MarkDeleted(older),
+			todo.New("Newer", todo.PriorityA).MarkDeleted(newer),
+		})
+		is.NoErr(err)
+
+		trash, err := usecases.LoadTrash(repo)
+		is.NoErr(err)
+		is.Equal(trash[0].Description(), "Newer")
+		is.Equal(trash[1].Description(), "Older")
+	})
+}
+
+func TestPurgeTrash(t *testing.T) {
+	t.Run("purges todos deleted longer ago than the retention period", func(t *testing.T) {
+		is := is.New(t)
+		repo := memory.NewRepository()
+
+		now := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+		err := repo.SaveTrash([]todo.Todo{
+			todo.New("Ancient", todo.PriorityA).MarkDeleted(now.AddDate(0, 0, -45)),
+			todo.New("Recent", todo.PriorityB).MarkDeleted(now.AddDate(0, 0, -3)),
+		})
+		is.NoErr(err)
+
+		purged, err := usecases.PurgeTrash(repo, 30, now)
+		is.NoErr(err)
+		is.Equal(purged, 1)
+
+		trash, err := repo.LoadTrash()
+		is.NoErr(err)
+		is.Equal(len(trash), 1)
+		is.Equal(trash[0].Description(), "Recent")
+	})
+
+	t.Run("no-op when nothing has expired", func(t *testing.T) {
+		is := is.New(t)
+		repo := memory.NewRepository()
+
+		now := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+		err := repo.SaveTrash([]todo.Todo{
+			todo.New("Recent", todo.PriorityB).MarkDeleted(now.AddDate(0, 0, -1)),
+		})
+		is.NoErr(err)
+
+		purged, err := usecases.PurgeTrash(repo, 30, now)
+		is.NoErr(err)
+		is.Equal(purged, 0)
+	})
+}
@@ -0,0 +1,93 @@
+package usecases
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/quii/todo-eisenhower/domain/matrix"
+	"github.com/quii/todo-eisenhower/domain/todo"
+)
+
+// LoadArchive returns the archived todos, most recently completed first
+func LoadArchive(repo TodoRepository) ([]todo.Todo, error) {
+	archived, err := repo.LoadArchive()
+	if err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(archived, func(i, j int) bool {
+		return dateAfter(archived[i].CompletionDate(), archived[j].CompletionDate())
+	})
+
+	return archived, nil
+}
+
+// SearchArchive narrows archived todos down to those matching every term in the query
+// Terms starting with + or @ match tags; any other term is a case-insensitive text search
+func SearchArchive(archived []todo.Todo, query string) []todo.Todo {
+	terms := strings.Fields(query)
+	if len(terms) == 0 {
+		return archived
+	}
+
+	matches := make([]todo.Todo, 0)
+	for _, t := range archived {
+		if matchesAllTerms(t, terms) {
+			matches = append(matches, t)
+		}
+	}
+	return matches
+}
+
+// UnarchiveTodo takes a todo back out of the archive and into its original quadrant
+// When uncomplete is true the todo is also marked as not done
+func UnarchiveTodo(repo TodoRepository, m matrix.Matrix, archived todo.Todo, uncomplete bool) (matrix.Matrix, error) {
+	archive, err := repo.LoadArchive()
+	if err != nil {
+		return m, err
+	}
+
+	remaining, found := removeFirstMatch(archive, archived)
+	if !found {
+		return m, nil // No-op if the todo is no longer in the archive
+	}
+
+	restored := archived
+	if uncomplete && restored.IsCompleted() {
+		restored = restored.ToggleCompletion(time.Now())
+	}
+	updatedMatrix := m.AddTodo(restored)
+
+	// Save the matrix first so a failed write never loses the todo
+	if err := saveAllTodos(repo, updatedMatrix); err != nil {
+		return m, err
+	}
+
+	if err := repo.SaveArchive(remaining); err != nil {
+		return m, err
+	}
+
+	if err := recordEvents(repo, addEvent(ActionUnarchive, restored)); err != nil {
+		return updatedMatrix, err
+	}
+
+	return updatedMatrix, nil
+}
+
+// matchesAllTerms returns true if the todo matches every search term
+func matchesAllTerms(t todo.Todo, terms []string) bool {
+	for _, term := range terms {
+		switch term[0] {
+		case '+', '@':
+			if !t.MatchesTag(term) {
+				return false
+			}
+		default:
+			if !t.MatchesText(term) {
+				return false
+			}
+		}
+	}
+	return true
+}
@@ -0,0 +1,136 @@
+package usecases_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/matryer/is"
+	"github.com/quii/todo-eisenhower/adapters/memory"
+	"github.com/quii/todo-eisenhower/domain/todo"
+	"github.com/quii/todo-eisenhower/usecases"
+)
+
+func TestLoadArchive(t *testing.T) {
+	t.Run("returns most recently completed todos first", func(t *testing.T) {
+		is := is.New(t)
+		repo := memory.NewRepository()
+
+		older := time.Date(2026, 1, 10, 0, 0, 0, 0, time.UTC)
+		newer := time.Date(2026, 1, 20, 0, 0, 0, 0, time.UTC)
+		err := repo.SaveArchive([]todo.Todo{
+			todo.NewCompleted("Older", todo.PriorityA, &older),
+			todo.NewCompleted("Newer", todo.PriorityA, &newer),
+		})
+		is.NoErr(err)
+
+		archived, err := usecases.LoadArchive(repo)
+		is.NoErr(err)
+		is.Equal(archived[0].Description(), "Newer")
+		is.Equal(archived[1].Description(), "Older")
+	})
+}
+
+func TestSearchArchive(t *testing.T) {
+	archived := []todo.Todo{
+		todo.NewCompletedWithTags("Deploy API", todo.PriorityA, nil, []string{"WebApp"}, []string{"computer"}),
+		todo.NewCompletedWithTags("Call accountant", todo.PriorityC, nil, nil, []string{"phone"}),
+		todo.NewCompletedWithTags("Fix API docs", todo.PriorityB, nil, []string{"Docs"}, nil),
+	}
+
+	t.Run("empty query returns everything", func(t *testing.T) {
+		is := is.New(t)
+		is.Equal(len(usecases.SearchArchive(archived, "  ")), 3)
+	})
+
+	t.Run("matches text case-insensitively", func(t *testing.T) {
+		is := is.New(t)
+		results := usecases.SearchArchive(archived, "api")
+		is.Equal(len(results), 2)
+	})
+
+	t.Run("filters by tag", func(t *testing.T) {
+		is := is.New(t)
+		results := usecases.SearchArchive(archived, "@phone")
+		is.Equal(len(results), 1)
+		is.Equal(results[0].Description(), "Call accountant")
+	})
+
+	t.Run("combines text and tag terms", func(t *testing.T) {
+		is := is.New(t)
+		results := usecases.SearchArchive(archived, "api +docs")
+		is.Equal(len(results), 1)
+		is.Equal(results[0].Description(), "Fix API docs")
+	})
+}
+
+func TestUnarchiveTodo(t *testing.T) {
+	t.Run("returns a todo to its original quadrant still completed", func(t *testing.T) {
+		is := is.New(t)
+		repo := memory.NewRepository()
+
+		done := todo.New("Ship release", todo.PriorityB).ToggleCompletion(time.Now())
+		err := repo.AppendToArchive(done)
+		is.NoErr(err)
+
+		m, err := usecases.LoadMatrix(repo)
+		is.NoErr(err)
+
+		archived, err := usecases.LoadArchive(repo)
+		is.NoErr(err)
+
+		m, err = usecases.UnarchiveTodo(repo, m, archived[0], false)
+		is.NoErr(err)
+
+		is.Equal(len(m.Schedule()), 1)
+		is.True(m.Schedule()[0].IsCompleted())
+		is.Equal(repo.ArchiveString(), "")
+		is.True(strings.Contains(repo.String(), "Ship release"))
+	})
+
+	t.Run("can un-complete the todo while unarchiving", func(t *testing.T) {
+		is := is.New(t)
+		repo := memory.NewRepository()
+
+		done := todo.New("Ship release", todo.PriorityB).ToggleCompletion(time.Now())
+		err := repo.AppendToArchive(done)
+		is.NoErr(err)
+
+		m, err := usecases.LoadMatrix(repo)
+		is.NoErr(err)
+
+		archived, err := usecases.LoadArchive(repo)
+		is.NoErr(err)
+
+		m, err = usecases.UnarchiveTodo(repo, m, archived[0], true)
+		is.NoErr(err)
+
+		is.Equal(len(m.Schedule()), 1)
+		is.True(!m.Schedule()[0].IsCompleted())
+		is.True(m.Schedule()[0].CompletionDate() == nil)
+		is.Equal(repo.String(), "(B) Ship release\n")
+	})
+
+	t.Run("only removes the unarchived todo from the archive", func(t *testing.T) {
+		is := is.New(t)
+		repo := memory.NewRepository()
+
+		err := repo.AppendToArchive(todo.New("Keep archived", todo.PriorityA).ToggleCompletion(time.Now()))
+		is.NoErr(err)
+		err = repo.AppendToArchive(todo.New("Bring back", todo.PriorityA).ToggleCompletion(time.Now()))
+		is.NoErr(err)
+
+		m, err := usecases.LoadMatrix(repo)
+		is.NoErr(err)
+
+		archived, err := usecases.LoadArchive(repo)
+		is.NoErr(err)
+		bringBack := usecases.SearchArchive(archived, "bring")[0]
+
+		_, err = usecases.UnarchiveTodo(repo, m, bringBack, true)
+		is.NoErr(err)
+
+		is.True(strings.Contains(repo.ArchiveString(), "Keep archived"))
+		is.True(!strings.Contains(repo.ArchiveString(), "Bring back"))
+	})
+}